@@ -7,9 +7,22 @@ USE gamefotball;
 -- ============================================================
 -- Eliminar tablas en orden correcto (hijos antes que padres)
 -- ============================================================
+DROP TABLE IF EXISTS auditoria;
+DROP TABLE IF EXISTS intentos_login;
+DROP TABLE IF EXISTS password_resets;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS estadisticas_usuario_zonas;
+DROP TABLE IF EXISTS estadisticas_usuario;
+DROP TABLE IF EXISTS reta_bajas;
+DROP TABLE IF EXISTS reta_sanciones;
+DROP TABLE IF EXISTS mensajes_leidos;
 DROP TABLE IF EXISTS mensajes_reta;
+DROP TABLE IF EXISTS reta_lista_espera;
 DROP TABLE IF EXISTS reta_jugadores;
+DROP TABLE IF EXISTS reta_serie_suscriptores;
 DROP TABLE IF EXISTS retas;
+DROP TABLE IF EXISTS reta_series;
+DROP TABLE IF EXISTS canchas;
 DROP TABLE IF EXISTS zonas;
 DROP TABLE IF EXISTS usuarios;
 
@@ -21,18 +34,133 @@ CREATE TABLE usuarios (
     username VARCHAR(100) NOT NULL UNIQUE,
     password VARCHAR(255) NOT NULL,
     nombre VARCHAR(150) NOT NULL,
+    rol ENUM('usuario', 'moderador', 'admin') NOT NULL DEFAULT 'usuario',
+    baneado_hasta DATETIME NULL,              -- 9999-12-31 = baneo permanente
+    motivo_baneo VARCHAR(255) NULL,
+    email VARCHAR(254) NULL UNIQUE,           -- Opcional; se usa para recuperar la password
+    posicion ENUM('portero', 'defensa', 'medio', 'delantero') NULL,
+    nivel ENUM('principiante', 'intermedio', 'avanzado') NULL,
+    zona_favorita VARCHAR(50) NULL,           -- FK a zonas (se agrega después de crear zonas)
+    telefono VARCHAR(16) NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
+-- ============================================================
+-- Tabla de refresh tokens (solo se guarda el hash SHA-256)
+-- ============================================================
+CREATE TABLE refresh_tokens (
+    id VARCHAR(36) PRIMARY KEY,
+    usuario_id VARCHAR(36) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expira_en DATETIME NOT NULL,
+    revocado_en DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    INDEX idx_refresh_usuario (usuario_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Tabla de tokens de recuperación de password (un solo uso, solo se guarda el hash SHA-256)
+-- ============================================================
+CREATE TABLE password_resets (
+    id VARCHAR(36) PRIMARY KEY,
+    usuario_id VARCHAR(36) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expira_en DATETIME NOT NULL,
+    usado_en DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    INDEX idx_password_resets_usuario (usuario_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Tabla de logins fallidos por username ("usuario:<username>") o IP ("ip:<ip>")
+-- ============================================================
+CREATE TABLE intentos_login (
+    clave VARCHAR(200) PRIMARY KEY,
+    fallos INT NOT NULL DEFAULT 0,
+    ultimo_fallo DATETIME NOT NULL,
+    bloqueado_hasta DATETIME NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Tabla de auditoría (bloqueos de login y acciones administrativas)
+-- ============================================================
+CREATE TABLE auditoria (
+    id VARCHAR(36) PRIMARY KEY,
+    evento VARCHAR(50) NOT NULL,
+    actor_id VARCHAR(36) NULL,
+    objetivo VARCHAR(200) NOT NULL,
+    ip VARCHAR(45) NULL,
+    detalle VARCHAR(500) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    INDEX idx_auditoria_evento (evento, created_at),
+    INDEX idx_auditoria_actor (actor_id, created_at),
+    INDEX idx_auditoria_objetivo (objetivo, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
 -- ============================================================
 -- Tabla de zonas geográficas
 -- ============================================================
 CREATE TABLE zonas (
     id VARCHAR(50) PRIMARY KEY,
     nombre VARCHAR(150) NOT NULL,
+    latitud DECIMAL(9,6) NOT NULL,            -- Centro de la zona
+    longitud DECIMAL(9,6) NOT NULL,
+    radio_km DECIMAL(5,2) NOT NULL DEFAULT 2.00,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
+ALTER TABLE usuarios
+    ADD CONSTRAINT fk_usuarios_zona_favorita FOREIGN KEY (zona_favorita) REFERENCES zonas(id) ON DELETE SET NULL;
+
+-- ============================================================
+-- Tabla de canchas (campos de juego de cada zona)
+-- ============================================================
+CREATE TABLE canchas (
+    id VARCHAR(36) PRIMARY KEY,
+    zona_id VARCHAR(50) NOT NULL,
+    nombre VARCHAR(255) NOT NULL,
+    direccion VARCHAR(255) NOT NULL,
+    superficie ENUM('pasto_natural', 'pasto_sintetico', 'tierra', 'concreto') NOT NULL,
+    capacidad INT NOT NULL,                   -- Jugadores en el campo
+    latitud DECIMAL(9,6) NULL,
+    longitud DECIMAL(9,6) NULL,
+    hora_apertura TIME NOT NULL,              -- Mismo horario todos los días
+    hora_cierre TIME NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_canchas_zona (zona_id),
+    FOREIGN KEY (zona_id) REFERENCES zonas(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Series de retas recurrentes (ej. martes y jueves a las 20:00); el scheduler crea sus retas
+-- con anticipación
+-- ============================================================
+CREATE TABLE reta_series (
+    id VARCHAR(36) PRIMARY KEY,
+    zona_id VARCHAR(50) NOT NULL,
+    titulo VARCHAR(255) NOT NULL,
+    dias_semana SET('lunes', 'martes', 'miercoles', 'jueves', 'viernes', 'sabado', 'domingo') NOT NULL,
+    hora TIME NOT NULL,
+    fecha_inicio DATE NOT NULL,
+    hasta DATE NULL,                          -- Fin por fecha (inclusive)
+    ocurrencias INT NULL,                     -- Fin por número de retas; ambos NULL = sin fin
+    max_jugadores INT NOT NULL DEFAULT 14,
+    duracion_minutos INT NOT NULL DEFAULT 120,
+    cancha_id VARCHAR(36) NULL,
+    latitud DECIMAL(9,6) NULL,
+    longitud DECIMAL(9,6) NULL,
+    creador_id VARCHAR(36) NOT NULL,
+    creador_nombre VARCHAR(100) NOT NULL,
+    estado VARCHAR(20) NOT NULL DEFAULT 'activa',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_series_zona_estado (zona_id, estado),
+    FOREIGN KEY (zona_id) REFERENCES zonas(id) ON DELETE CASCADE,
+    FOREIGN KEY (cancha_id) REFERENCES canchas(id) ON DELETE SET NULL,
+    FOREIGN KEY (creador_id) REFERENCES usuarios(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
 -- ============================================================
 -- Tabla de retas (partidos de fútbol)
 -- ============================================================
@@ -45,13 +173,39 @@ CREATE TABLE retas (
     jugadores_actuales INT NOT NULL DEFAULT 0,
     creador_id VARCHAR(36) NOT NULL,
     creador_nombre VARCHAR(100) NOT NULL,
+    estado VARCHAR(20) NOT NULL DEFAULT 'abierta',
+    latitud DECIMAL(9,6) NULL,                -- Cancha; NULL = se usa el centro de la zona
+    longitud DECIMAL(9,6) NULL,
+    cancha_id VARCHAR(36) NULL,               -- Cancha apartada de fecha_hora a fecha_hora + duracion_minutos
+    duracion_minutos INT NOT NULL DEFAULT 120,
+    serie_id VARCHAR(36) NULL,                -- Serie recurrente que generó la reta
+    serie_fecha DATE NULL,                    -- Día de la serie; no cambia aunque se edite fecha_hora
+    serie_editada BOOLEAN NOT NULL DEFAULT FALSE, -- Editada por separado: los cambios a la serie no la tocan
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     INDEX idx_zona_id (zona_id),
     INDEX idx_fecha_hora (fecha_hora),
+    INDEX idx_estado_fecha (estado, fecha_hora),
+    INDEX idx_retas_cancha_fecha (cancha_id, fecha_hora),
+    UNIQUE KEY unique_serie_ocurrencia (serie_id, serie_fecha), -- Una reta por día aunque corran varias réplicas
     FOREIGN KEY (zona_id) REFERENCES zonas(id) ON DELETE CASCADE,
+    FOREIGN KEY (cancha_id) REFERENCES canchas(id) ON DELETE SET NULL,
+    FOREIGN KEY (serie_id) REFERENCES reta_series(id) ON DELETE SET NULL,
     FOREIGN KEY (creador_id) REFERENCES usuarios(id) ON DELETE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
+-- ============================================================
+-- Jugadores habituales de una serie: se inscriben solos en cada reta nueva de la serie
+-- ============================================================
+CREATE TABLE reta_serie_suscriptores (
+    serie_id VARCHAR(36) NOT NULL,
+    usuario_id VARCHAR(36) NOT NULL,
+    nombre_jugador VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (serie_id, usuario_id),
+    FOREIGN KEY (serie_id) REFERENCES reta_series(id) ON DELETE CASCADE,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
 -- ============================================================
 -- Tabla de jugadores en retas
 -- ============================================================
@@ -67,6 +221,22 @@ CREATE TABLE reta_jugadores (
     INDEX idx_reta_id (reta_id)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
+-- ============================================================
+-- Lista de espera ordenada de retas llenas
+-- ============================================================
+CREATE TABLE reta_lista_espera (
+    id VARCHAR(36) PRIMARY KEY,
+    reta_id VARCHAR(36) NOT NULL,
+    usuario_id VARCHAR(36) NOT NULL,
+    nombre_jugador VARCHAR(100) NOT NULL,
+    posicion INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reta_id) REFERENCES retas(id) ON DELETE CASCADE,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_usuario_espera (reta_id, usuario_id),
+    INDEX idx_espera_posicion (reta_id, posicion)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
 -- ============================================================
 -- Tabla de mensajes del chat en vivo de cada reta
 -- ============================================================
@@ -75,11 +245,86 @@ CREATE TABLE mensajes_reta (
     reta_id VARCHAR(36) NOT NULL,
     usuario_id VARCHAR(36) NOT NULL,
     texto VARCHAR(500) NOT NULL,
-    creado_en TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    creado_en TIMESTAMP(3) DEFAULT CURRENT_TIMESTAMP(3), -- Milisegundos para ordenar y paginar el chat
+    editado_en TIMESTAMP(3) NULL,
+    eliminado_en TIMESTAMP(3) NULL, -- Marcador: el mensaje se queda en el historial con el texto vacío
+    eliminado_por VARCHAR(36) NULL, -- Autor o creador de la reta
     FOREIGN KEY (reta_id) REFERENCES retas(id) ON DELETE CASCADE,
     FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
     INDEX idx_mensajes_reta_id (reta_id),
-    INDEX idx_mensajes_creado_en (reta_id, creado_en ASC)
+    INDEX idx_mensajes_creado_en (reta_id, creado_en, id) -- Cursor de paginación (creado_en, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Último mensaje leído por cada usuario en el chat de cada reta
+-- ============================================================
+CREATE TABLE mensajes_leidos (
+    reta_id VARCHAR(36) NOT NULL,
+    usuario_id VARCHAR(36) NOT NULL,
+    ultimo_leido_id VARCHAR(36) NOT NULL,
+    ultimo_leido_en TIMESTAMP(3) NOT NULL, -- creado_en del mensaje, para comparar con el cursor (creado_en, id)
+    actualizado_en TIMESTAMP(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (reta_id, usuario_id),
+    FOREIGN KEY (reta_id) REFERENCES retas(id) ON DELETE CASCADE,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    FOREIGN KEY (ultimo_leido_id) REFERENCES mensajes_reta(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Sanciones del chat de cada reta (silencio temporal o expulsión); una por usuario y reta
+-- ============================================================
+CREATE TABLE reta_sanciones (
+    reta_id VARCHAR(36) NOT NULL,
+    usuario_id VARCHAR(36) NOT NULL,
+    tipo ENUM('silencio', 'expulsion') NOT NULL,
+    motivo VARCHAR(255) NULL,
+    hasta TIMESTAMP(3) NULL, -- NULL en las expulsiones: duran hasta que el creador las quite
+    creado_por VARCHAR(36) NOT NULL,
+    creado_en TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (reta_id, usuario_id),
+    FOREIGN KEY (reta_id) REFERENCES retas(id) ON DELETE CASCADE,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    FOREIGN KEY (creado_por) REFERENCES usuarios(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Salidas de jugadores inscritos (reta_jugadores se borra al salir); alimenta las estadísticas
+-- ============================================================
+CREATE TABLE reta_bajas (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reta_id VARCHAR(36) NOT NULL,
+    usuario_id VARCHAR(36) NOT NULL,
+    unido_en TIMESTAMP NOT NULL,              -- created_at de la fila borrada de reta_jugadores
+    salio_en TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reta_id) REFERENCES retas(id) ON DELETE CASCADE,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    INDEX idx_bajas_usuario_salio (usuario_id, salio_en),
+    INDEX idx_bajas_usuario_unido (usuario_id, unido_en)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ============================================================
+-- Resumen incremental de estadísticas por usuario. Cada consulta suma solo lo nuevo desde
+-- contado_hasta (fechas de creación) y jugadas_hasta (fecha_hora de retas ya cerradas)
+-- ============================================================
+CREATE TABLE estadisticas_usuario (
+    usuario_id VARCHAR(36) PRIMARY KEY,
+    retas_creadas INT NOT NULL DEFAULT 0,
+    retas_unidas INT NOT NULL DEFAULT 0,
+    retas_jugadas INT NOT NULL DEFAULT 0,
+    bajas_tardias INT NOT NULL DEFAULT 0,
+    mensajes_enviados INT NOT NULL DEFAULT 0,
+    contado_hasta DATETIME(3) NOT NULL,
+    jugadas_hasta DATETIME NOT NULL,
+    actualizado_en DATETIME(3) NULL,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE estadisticas_usuario_zonas (
+    usuario_id VARCHAR(36) NOT NULL,
+    zona_id VARCHAR(50) NOT NULL,
+    retas_jugadas INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (usuario_id, zona_id),
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 -- ============================================================
@@ -87,12 +332,17 @@ CREATE TABLE mensajes_reta (
 -- ============================================================
 
 -- Zonas de Suchiapa
-INSERT INTO zonas (id, nombre) VALUES
-('suchiapa_centro', 'Suchiapa Centro'),
-('suchiapa_norte',  'Suchiapa Norte'),
-('suchiapa_sur',    'Suchiapa Sur');
+INSERT INTO zonas (id, nombre, latitud, longitud, radio_km) VALUES
+('suchiapa_centro', 'Suchiapa Centro', 16.627500, -93.097500, 1.50),
+('suchiapa_norte',  'Suchiapa Norte',  16.645000, -93.095000, 2.00),
+('suchiapa_sur',    'Suchiapa Sur',    16.605000, -93.100000, 2.50);
+
+INSERT INTO canchas (id, zona_id, nombre, direccion, superficie, capacidad, latitud, longitud, hora_apertura, hora_cierre) VALUES
+('c-001', 'suchiapa_centro', 'Unidad Deportiva Suchiapa', 'Av. Central s/n, Centro', 'pasto_sintetico', 14, 16.628100, -93.096200, '07:00', '23:00'),
+('c-002', 'suchiapa_centro', 'Cancha del Parque',         'Calle 1a. Norte, Centro', 'concreto',        10, 16.626900, -93.098400, '06:00', '22:00'),
+('c-003', 'suchiapa_sur',    'Campo Las Palmas',          'Carretera a Villaflores km 2', 'pasto_natural', 22, 16.603800, -93.101500, '08:00', '19:00');
 
 
-INSERT INTO usuarios (id, username, password, nombre) VALUES
-('u-001', 'jesus-imanol', '$2a$10$DaW5YJlrFdh4cyVg/p1De./Dl10IUjDMfZDXzeADqKVq4kuipJrDu', 'Jesús Imanol'),
-('u-002', 'carlos-dev',   '$2a$10$DaW5YJlrFdh4cyVg/p1De./Dl10IUjDMfZDXzeADqKVq4kuipJrDu', 'Carlos Dev');
+INSERT INTO usuarios (id, username, password, nombre, rol) VALUES
+('u-001', 'jesus-imanol', '$2a$10$DaW5YJlrFdh4cyVg/p1De./Dl10IUjDMfZDXzeADqKVq4kuipJrDu', 'Jesús Imanol', 'admin'),
+('u-002', 'carlos-dev',   '$2a$10$DaW5YJlrFdh4cyVg/p1De./Dl10IUjDMfZDXzeADqKVq4kuipJrDu', 'Carlos Dev',   'usuario');
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"games-football-api/src/core"
 	dependenciesretas "games-football-api/src/retas/infraestructure/dependencies_retas"
 	dependenciesusuarios "games-football-api/src/usuarios/infraestructure/dependencies_usuarios"
+	dependencieszonas "games-football-api/src/zonas/infraestructure/dependencies_zonas"
 	"log"
 	"time"
 
@@ -17,8 +19,18 @@ func main() {
 		log.Fatalf("Error loading .env file")
 	}
 
+	if err := core.CargarZonaHoraria(); err != nil {
+		log.Fatalf("Error al cargar la zona horaria: %v", err)
+	}
+
 	r := gin.Default()
 
+	// c.ClientIP() (bloqueo de login por IP, auditoría) solo toma X-Forwarded-For de los proxies listados
+	// en TRUSTED_PROXIES; sin valor se usa la IP de la conexión y el header se ignora
+	if err := r.SetTrustedProxies(core.ListaDesdeEnv("TRUSTED_PROXIES")); err != nil {
+		log.Fatalf("TRUSTED_PROXIES inválido: %v", err)
+	}
+
 	// Configuración de CORS
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
@@ -37,12 +49,16 @@ func main() {
 			"version": "1.0.0",
 			"endpoints": gin.H{
 				"websocket": "/ws/retas",
+				"retas":     "/api/retas",
+				"zonas":     "/api/zonas",
 			},
 		})
 	})
 
-	dependenciesretas.InitRetas(r)
-	dependenciesusuarios.InitUsuarios(r)
+	// El hub de retas cierra los WebSockets de los usuarios que se banean en el módulo de usuarios
+	sesiones := dependenciesretas.InitRetas(r)
+	dependenciesusuarios.InitUsuarios(r, sesiones)
+	dependencieszonas.InitZonas(r)
 
 	if err := r.Run(":8080"); err != nil {
 		panic(err)
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClaimsContextKey es la llave con la que el middleware guarda los claims en el contexto de gin
+const ClaimsContextKey = "auth_claims"
+
+// AuthMiddleware resuelve el usuario actual a partir del header "Authorization: Bearer <token>"
+func AuthMiddleware(jwtManager *JWTManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := TokenDesdeHeader(c.GetHeader("Authorization"))
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"status":  "error",
+				"mensaje": "Token de acceso requerido",
+			})
+			return
+		}
+
+		claims, err := jwtManager.Validar(token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"status":  "error",
+				"mensaje": err.Error(),
+			})
+			return
+		}
+
+		c.Set(ClaimsContextKey, claims)
+		c.Next()
+	}
+}
+
+// ClaimsDesdeContexto obtiene los claims del usuario autenticado por AuthMiddleware
+func ClaimsDesdeContexto(c *gin.Context) (*Claims, bool) {
+	valor, ok := c.Get(ClaimsContextKey)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := valor.(*Claims)
+	return claims, ok
+}
+
+// TokenDesdeHeader extrae el token de un header con esquema Bearer
+func TokenDesdeHeader(header string) string {
+	const prefijo = "Bearer "
+	if len(header) <= len(prefijo) || !strings.EqualFold(header[:len(prefijo)], prefijo) {
+		return ""
+	}
+	return strings.TrimSpace(header[len(prefijo):])
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrCanchaNoEncontrada lo usan zonas, que administra las canchas, y retas, que las aparta
+var ErrCanchaNoEncontrada = errors.New("cancha no encontrada")
+
+// Cancha son los datos de una cancha que comparten los módulos: zonas la administra con sus demás
+// campos y retas la lee para apartarla
+type Cancha struct {
+	ID        string   `json:"id"`
+	ZonaID    string   `json:"zona_id"`
+	Nombre    string   `json:"nombre"`
+	Capacidad int      `json:"capacidad"`
+	Latitud   *float64 `json:"latitud,omitempty"`
+	Longitud  *float64 `json:"longitud,omitempty"`
+
+	// Horario diario en formato HH:MM; las retas deben empezar y terminar dentro de él
+	HoraApertura string `json:"hora_apertura"`
+	HoraCierre   string `json:"hora_cierre"`
+}
+
+// Horario retorna la apertura y el cierre en minutos desde la medianoche
+func (c *Cancha) Horario() (apertura, cierre int) {
+	return minutosDelDia(c.HoraApertura), minutosDelDia(c.HoraCierre)
+}
+
+func minutosDelDia(hora string) int {
+	t, err := time.Parse("15:04", hora)
+	if err != nil {
+		return 0
+	}
+	return t.Hour()*60 + t.Minute()
+}
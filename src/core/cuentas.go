@@ -0,0 +1,43 @@
+package core
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrCuentaNoEncontrada se retorna cuando el usuario del token ya no existe
+var ErrCuentaNoEncontrada = errors.New("usuario no encontrado")
+
+// LectorCuentas lee el rol y la suspensión vigentes de un usuario; RequiereRol lo usa para no depender
+// del rol guardado en el access token, que puede estar desactualizado hasta que expire
+type LectorCuentas interface {
+	// Cuenta retorna el rol actual del usuario y hasta cuándo está suspendido (nil si no tiene baneo)
+	Cuenta(usuarioID string) (rol string, baneadoHasta *time.Time, err error)
+}
+
+// CuentasMySQL implementa LectorCuentas sobre la tabla usuarios
+type CuentasMySQL struct {
+	db *sql.DB
+}
+
+func NewCuentasMySQL(db *sql.DB) *CuentasMySQL {
+	return &CuentasMySQL{db: db}
+}
+
+func (c *CuentasMySQL) Cuenta(usuarioID string) (string, *time.Time, error) {
+	var rol string
+	var baneadoHasta sql.NullTime
+	err := c.db.QueryRow("SELECT rol, baneado_hasta FROM usuarios WHERE id = ?", usuarioID).Scan(&rol, &baneadoHasta)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil, ErrCuentaNoEncontrada
+		}
+		return "", nil, fmt.Errorf("error al consultar usuario: %w", err)
+	}
+	if !baneadoHasta.Valid {
+		return rol, nil, nil
+	}
+	return rol, &baneadoHasta.Time, nil
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"database/sql"
 	"fmt"
+	"net/url"
 	"os"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -15,7 +16,9 @@ func NewMySQL() (*sql.DB, error) {
 	dbPort := os.Getenv("DB_PORT")
 	dbName := os.Getenv("DB_NAME")
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", dbUser, dbPassword, dbHost, dbPort, dbName)
+	// loc hace que los DATETIME se lean y se escriban en la ZonaHoraria de la API, no en UTC
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&loc=%s", dbUser, dbPassword, dbHost, dbPort, dbName,
+		url.QueryEscape(ZonaHoraria.String()))
 
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
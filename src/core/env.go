@@ -0,0 +1,48 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DuracionDesdeEnv lee una duración (ej. "15m", "720h") de una variable de entorno
+func DuracionDesdeEnv(key string, porDefecto time.Duration) (time.Duration, error) {
+	valor := os.Getenv(key)
+	if valor == "" {
+		return porDefecto, nil
+	}
+
+	d, err := time.ParseDuration(valor)
+	if err != nil {
+		return 0, fmt.Errorf("%s inválido: %w", key, err)
+	}
+	return d, nil
+}
+
+// EnteroDesdeEnv lee un entero positivo de una variable de entorno
+func EnteroDesdeEnv(key string, porDefecto int) (int, error) {
+	valor := os.Getenv(key)
+	if valor == "" {
+		return porDefecto, nil
+	}
+
+	n, err := strconv.Atoi(valor)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s inválido: debe ser un entero mayor a 0", key)
+	}
+	return n, nil
+}
+
+// ListaDesdeEnv lee una lista separada por comas de una variable de entorno; sin valor retorna nil
+func ListaDesdeEnv(key string) []string {
+	var lista []string
+	for _, valor := range strings.Split(os.Getenv(key), ",") {
+		if valor = strings.TrimSpace(valor); valor != "" {
+			lista = append(lista, valor)
+		}
+	}
+	return lista
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	defaultAccessTTL  = 15 * time.Minute    // Vigencia por defecto del access token
+	defaultRefreshTTL = 30 * 24 * time.Hour // Vigencia por defecto del refresh token
+)
+
+// Claims representa la información del usuario autenticado contenida en el access token
+type Claims struct {
+	UsuarioID string `json:"sub"`
+	Username  string `json:"username"`
+	Nombre    string `json:"nombre"`
+	Rol       string `json:"rol"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// JWTManager firma y valida access tokens JWT con HMAC-SHA256
+type JWTManager struct {
+	secret     []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+func NewJWTManager() (*JWTManager, error) {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		return nil, errors.New("JWT_SECRET no está configurado")
+	}
+
+	accessTTL, err := DuracionDesdeEnv("JWT_ACCESS_TTL", defaultAccessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshTTL, err := DuracionDesdeEnv("JWT_REFRESH_TTL", defaultRefreshTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWTManager{
+		secret:     []byte(secret),
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+	}, nil
+}
+
+// AccessTTL retorna la vigencia de los access tokens
+func (m *JWTManager) AccessTTL() time.Duration {
+	return m.accessTTL
+}
+
+// RefreshTTL retorna la vigencia de los refresh tokens
+func (m *JWTManager) RefreshTTL() time.Duration {
+	return m.refreshTTL
+}
+
+// Generar firma un access token para el usuario indicado
+func (m *JWTManager) Generar(usuarioID, username, nombre, rol string) (string, error) {
+	ahora := time.Now()
+	claims := Claims{
+		UsuarioID: usuarioID,
+		Username:  username,
+		Nombre:    nombre,
+		Rol:       rol,
+		IssuedAt:  ahora.Unix(),
+		ExpiresAt: ahora.Add(m.accessTTL).Unix(),
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + m.firmar(unsigned), nil
+}
+
+// Validar verifica la firma y la expiración del token y retorna sus claims
+func (m *JWTManager) Validar(token string) (*Claims, error) {
+	partes := strings.Split(token, ".")
+	if len(partes) != 3 {
+		return nil, errors.New("token inválido")
+	}
+
+	unsigned := partes[0] + "." + partes[1]
+	if !hmac.Equal([]byte(m.firmar(unsigned)), []byte(partes[2])) {
+		return nil, errors.New("token inválido")
+	}
+
+	var header map[string]string
+	headerBytes, err := base64.RawURLEncoding.DecodeString(partes[0])
+	if err != nil || json.Unmarshal(headerBytes, &header) != nil || header["alg"] != "HS256" {
+		return nil, errors.New("token inválido")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(partes[1])
+	if err != nil {
+		return nil, errors.New("token inválido")
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, errors.New("token inválido")
+	}
+
+	if claims.UsuarioID == "" || time.Now().Unix() >= claims.ExpiresAt {
+		return nil, errors.New("token expirado")
+	}
+
+	return &claims, nil
+}
+
+// firmar calcula la firma HMAC-SHA256 en base64url de la parte sin firmar del token
+func (m *JWTManager) firmar(unsigned string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tasa define una cubeta de tokens: permite Rafaga solicitudes seguidas y recupera Rafaga tokens por cada Periodo
+type Tasa struct {
+	Rafaga  int
+	Periodo time.Duration
+}
+
+// TasaDesdeEnv lee una tasa con el formato "N/duración" (ej. "5/10s", "30/1m") de una variable de entorno
+func TasaDesdeEnv(key string, porDefecto Tasa) (Tasa, error) {
+	valor := os.Getenv(key)
+	if valor == "" {
+		return porDefecto, nil
+	}
+
+	partes := strings.SplitN(valor, "/", 2)
+	if len(partes) != 2 {
+		return Tasa{}, fmt.Errorf("%s inválido: usa el formato N/duración (ej. 5/10s)", key)
+	}
+	rafaga, err := strconv.Atoi(strings.TrimSpace(partes[0]))
+	if err != nil || rafaga <= 0 {
+		return Tasa{}, fmt.Errorf("%s inválido: N debe ser un entero mayor a 0", key)
+	}
+	periodo, err := time.ParseDuration(strings.TrimSpace(partes[1]))
+	if err != nil || periodo <= 0 {
+		return Tasa{}, fmt.Errorf("%s inválido: duración incorrecta", key)
+	}
+
+	return Tasa{Rafaga: rafaga, Periodo: periodo}, nil
+}
+
+// Cubeta es una cubeta de tokens sin sincronización; quien la comparta entre goroutines debe protegerla
+type Cubeta struct {
+	tasa   Tasa
+	tokens float64
+	ultima time.Time
+}
+
+// NewCubeta crea una cubeta llena
+func NewCubeta(tasa Tasa) *Cubeta {
+	return &Cubeta{
+		tasa:   tasa,
+		tokens: float64(tasa.Rafaga),
+		ultima: time.Now(),
+	}
+}
+
+// Tomar consume un token si hay disponible; si no, retorna cuánto falta para el siguiente
+func (c *Cubeta) Tomar(ahora time.Time) (bool, time.Duration) {
+	c.recargar(ahora)
+	if c.tokens >= 1 {
+		c.tokens--
+		return true, 0
+	}
+
+	porToken := c.tasa.Periodo / time.Duration(c.tasa.Rafaga)
+	return false, time.Duration((1 - c.tokens) * float64(porToken))
+}
+
+// llena indica si la cubeta recuperó todos sus tokens (nadie la ha usado en un rato)
+func (c *Cubeta) llena(ahora time.Time) bool {
+	c.recargar(ahora)
+	return c.tokens >= float64(c.tasa.Rafaga)
+}
+
+func (c *Cubeta) recargar(ahora time.Time) {
+	transcurrido := ahora.Sub(c.ultima)
+	if transcurrido <= 0 {
+		return
+	}
+	c.tokens += transcurrido.Seconds() * float64(c.tasa.Rafaga) / c.tasa.Periodo.Seconds()
+	if c.tokens > float64(c.tasa.Rafaga) {
+		c.tokens = float64(c.tasa.Rafaga)
+	}
+	c.ultima = ahora
+}
+
+// intervaloLimpieza es cada cuánto LimitadorPorClave descarta las cubetas llenas
+const intervaloLimpieza = time.Minute
+
+// LimitadorPorClave mantiene una cubeta por clave (ej. usuario) y es seguro entre goroutines
+type LimitadorPorClave struct {
+	tasa    Tasa
+	cubetas map[string]*Cubeta
+	limpio  time.Time
+	mu      sync.Mutex
+}
+
+func NewLimitadorPorClave(tasa Tasa) *LimitadorPorClave {
+	return &LimitadorPorClave{
+		tasa:    tasa,
+		cubetas: make(map[string]*Cubeta),
+		limpio:  time.Now(),
+	}
+}
+
+// Permitir consume un token de la cubeta de la clave; si no hay, retorna cuánto falta para el siguiente
+func (l *LimitadorPorClave) Permitir(clave string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ahora := time.Now()
+	if ahora.Sub(l.limpio) >= intervaloLimpieza {
+		// Una cubeta llena equivale a una nueva, así que se puede descartar sin cambiar el resultado
+		for k, cubeta := range l.cubetas {
+			if cubeta.llena(ahora) {
+				delete(l.cubetas, k)
+			}
+		}
+		l.limpio = ahora
+	}
+
+	cubeta, ok := l.cubetas[clave]
+	if !ok {
+		cubeta = NewCubeta(l.tasa)
+		l.cubetas[clave] = cubeta
+	}
+	return cubeta.Tomar(ahora)
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Correo es un mensaje de texto plano para un destinatario
+type Correo struct {
+	Para   string
+	Asunto string
+	Cuerpo string
+}
+
+// Mailer entrega correos; las implementaciones son SMTP para producción y log/archivo para desarrollo
+type Mailer interface {
+	Enviar(correo Correo) error
+}
+
+// NewMailerDesdeEnv crea el mailer indicado por MAILER: "log" (por defecto), "archivo" o "smtp"
+func NewMailerDesdeEnv() (Mailer, error) {
+	switch os.Getenv("MAILER") {
+	case "", "log":
+		return NewLogMailer(""), nil
+	case "archivo":
+		archivo := os.Getenv("MAILER_ARCHIVO")
+		if archivo == "" {
+			archivo = "correos.log"
+		}
+		return NewLogMailer(archivo), nil
+	case "smtp":
+		config := SMTPConfig{
+			Host:      os.Getenv("SMTP_HOST"),
+			Puerto:    os.Getenv("SMTP_PUERTO"),
+			Usuario:   os.Getenv("SMTP_USUARIO"),
+			Password:  os.Getenv("SMTP_PASSWORD"),
+			Remitente: os.Getenv("SMTP_REMITENTE"),
+		}
+		if config.Puerto == "" {
+			config.Puerto = "587"
+		}
+		if config.Host == "" || config.Remitente == "" {
+			return nil, fmt.Errorf("SMTP_HOST y SMTP_REMITENTE son requeridos con MAILER=smtp")
+		}
+		return NewSMTPMailer(config), nil
+	default:
+		return nil, fmt.Errorf("MAILER inválido: usa log, archivo o smtp")
+	}
+}
+
+// SMTPConfig contiene los datos del servidor SMTP
+type SMTPConfig struct {
+	Host      string
+	Puerto    string
+	Usuario   string
+	Password  string
+	Remitente string
+}
+
+// SMTPMailer envía los correos por SMTP (STARTTLS si el servidor lo ofrece, como hace net/smtp)
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+func NewSMTPMailer(config SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{
+		config: config,
+	}
+}
+
+// Enviar arma el mensaje con sus headers y lo entrega al servidor SMTP
+func (m *SMTPMailer) Enviar(correo Correo) error {
+	var auth smtp.Auth
+	if m.config.Usuario != "" {
+		auth = smtp.PlainAuth("", m.config.Usuario, m.config.Password, m.config.Host)
+	}
+
+	mensaje := strings.Join([]string{
+		"From: " + m.config.Remitente,
+		"To: " + correo.Para,
+		"Subject: " + correo.Asunto,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		correo.Cuerpo,
+	}, "\r\n")
+
+	addr := net.JoinHostPort(m.config.Host, m.config.Puerto)
+	if err := smtp.SendMail(addr, auth, m.config.Remitente, []string{correo.Para}, []byte(mensaje)); err != nil {
+		return fmt.Errorf("error al enviar correo: %w", err)
+	}
+	return nil
+}
+
+// LogMailer no envía nada: escribe los correos en el log o, si tiene archivo, los agrega a ese archivo.
+// Sirve para desarrollo local y pruebas
+type LogMailer struct {
+	archivo string
+	mu      sync.Mutex
+}
+
+func NewLogMailer(archivo string) *LogMailer {
+	return &LogMailer{
+		archivo: archivo,
+	}
+}
+
+// Enviar registra el correo completo
+func (m *LogMailer) Enviar(correo Correo) error {
+	texto := fmt.Sprintf("Para: %s\nAsunto: %s\n\n%s\n", correo.Para, correo.Asunto, correo.Cuerpo)
+	if m.archivo == "" {
+		log.Printf("Correo (MAILER=log)\n%s", texto)
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.archivo, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("error al abrir %s: %w", m.archivo, err)
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintf(f, "--- %s\n%s\n", time.Now().Format(time.RFC3339), texto); err != nil {
+		return fmt.Errorf("error al escribir correo: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// Errores del motivo que deben dar las acciones de moderación y de administración
+var (
+	ErrMotivoRequerido = errors.New("motivo es requerido")
+	ErrMotivoLargo     = errors.New("motivo no puede superar los 255 caracteres")
+)
+
+// LongitudMaximaMotivo es el largo máximo del motivo; se guarda en el detalle de la auditoría
+const LongitudMaximaMotivo = 255
+
+// ValidarMotivo verifica que una acción de moderación o de administración explique su motivo
+func ValidarMotivo(motivo string) error {
+	motivo = strings.TrimSpace(motivo)
+	if motivo == "" {
+		return ErrMotivoRequerido
+	}
+	if utf8.RuneCountInString(motivo) > LongitudMaximaMotivo {
+		return ErrMotivoLargo
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisTimeout = 5 * time.Second // Tiempo máximo para conectar, leer o escribir un comando
+
+// RedisConfig contiene los datos de conexión a Redis
+type RedisConfig struct {
+	Addr     string
+	Password string
+}
+
+// RedisConfigDesdeEnv lee REDIS_ADDR (por defecto localhost:6379) y REDIS_PASSWORD
+func RedisConfigDesdeEnv() RedisConfig {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	return RedisConfig{
+		Addr:     addr,
+		Password: os.Getenv("REDIS_PASSWORD"),
+	}
+}
+
+// NewRedis crea el cliente de Redis y verifica la conexión con un PING. El cliente mantiene su propio
+// pool: reconecta solo y cada comando tiene deadlines de lectura y escritura
+func NewRedis(config RedisConfig) (*redis.Client, error) {
+	cliente := redis.NewClient(&redis.Options{
+		Addr:         config.Addr,
+		Password:     config.Password,
+		DialTimeout:  redisTimeout,
+		ReadTimeout:  redisTimeout,
+		WriteTimeout: redisTimeout,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := cliente.Ping(ctx).Err(); err != nil {
+		cliente.Close()
+		return nil, fmt.Errorf("error al conectar a Redis: %w", err)
+	}
+	return cliente, nil
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Roles de usuario, de menor a mayor permiso: cada rol puede hacer todo lo de los anteriores
+const (
+	RolUsuario   = "usuario"
+	RolModerador = "moderador"
+	RolAdmin     = "admin"
+)
+
+var nivelesRol = map[string]int{
+	RolUsuario:   1,
+	RolModerador: 2,
+	RolAdmin:     3,
+}
+
+// RolValido indica si el rol existe
+func RolValido(rol string) bool {
+	_, ok := nivelesRol[rol]
+	return ok
+}
+
+// NivelRol retorna el nivel de permiso del rol; un rol vacío (tokens anteriores a los roles) es usuario
+func NivelRol(rol string) int {
+	if rol == "" {
+		rol = RolUsuario
+	}
+	return nivelesRol[rol]
+}
+
+// TieneRol indica si el rol alcanza al menos el rol mínimo
+func TieneRol(rol, minimo string) bool {
+	return NivelRol(rol) >= NivelRol(minimo)
+}
+
+// RequiereRol rechaza con 403 las peticiones de usuarios suspendidos o sin al menos el rol indicado.
+// Va después de AuthMiddleware; el rol y el baneo se leen de la base de datos en cada petición y no del
+// token, así que un moderador degradado o baneado pierde el acceso de inmediato
+func RequiereRol(cuentas LectorCuentas, minimo string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := ClaimsDesdeContexto(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"status":  "error",
+				"mensaje": "No tienes permisos para esta acción",
+			})
+			return
+		}
+
+		rol, baneadoHasta, err := cuentas.Cuenta(claims.UsuarioID)
+		if err != nil {
+			if errors.Is(err, ErrCuentaNoEncontrada) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"status":  "error",
+					"mensaje": "Usuario no encontrado",
+				})
+				return
+			}
+			log.Printf("Error al verificar la cuenta %s: %v", claims.UsuarioID, err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"status":  "error",
+				"mensaje": "Error al verificar la cuenta",
+			})
+			return
+		}
+
+		if baneadoHasta != nil && baneadoHasta.After(time.Now()) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"status":  "error",
+				"mensaje": "Tu cuenta está suspendida",
+			})
+			return
+		}
+		if !TieneRol(rol, minimo) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"status":  "error",
+				"mensaje": "No tienes permisos para esta acción",
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,9 @@
+package core
+
+// DesconectadorSesiones cierra las conexiones en tiempo real (WebSockets) de un usuario en todos los
+// nodos de la API. Lo implementa el hub del módulo de retas; el módulo de usuarios lo usa al banear
+type DesconectadorSesiones interface {
+	// DesconectarUsuario envía a cada conexión del usuario un último error con el código y el mensaje
+	// indicados y la cierra con el código 1008 (policy violation)
+	DesconectarUsuario(usuarioID, codigo, mensaje string) error
+}
@@ -0,0 +1,22 @@
+package core
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// GenerarTokenAleatorio genera un token opaco de 32 bytes codificado en hexadecimal
+func GenerarTokenAleatorio() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashToken calcula el SHA-256 de un token opaco; solo el hash se persiste en la base de datos
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
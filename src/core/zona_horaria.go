@@ -0,0 +1,35 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	// Incluye la base de zonas horarias para no depender de la del sistema (ej. imágenes mínimas)
+	_ "time/tzdata"
+)
+
+// ZonaHoraria es la zona en la que se interpretan las fechas y horas que envían los jugadores
+// (fecha_hora de las retas, hora de las series) y en la que MySQL guarda los DATETIME
+var ZonaHoraria = time.Local
+
+// CargarZonaHoraria lee APP_TZ (ej. "America/Mexico_City"); sin valor se usa la zona del servidor.
+// Se llama al arrancar, antes de abrir la base de datos
+func CargarZonaHoraria() error {
+	nombre := os.Getenv("APP_TZ")
+	if nombre == "" {
+		return nil
+	}
+
+	loc, err := time.LoadLocation(nombre)
+	if err != nil {
+		return fmt.Errorf("APP_TZ inválido: %w", err)
+	}
+	ZonaHoraria = loc
+	return nil
+}
+
+// FechaHoraLocal interpreta una fecha con el layout dado en la ZonaHoraria
+func FechaHoraLocal(layout, valor string) (time.Time, error) {
+	return time.ParseInLocation(layout, valor, ZonaHoraria)
+}
@@ -0,0 +1,62 @@
+package application
+
+import (
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+	"log"
+	"time"
+)
+
+type ActualizarEstadosRetasUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewActualizarEstadosRetasUseCase(retaRepo repositories.IRetaRepository) *ActualizarEstadosRetasUseCase {
+	return &ActualizarEstadosRetasUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute avanza a en_juego / finalizada las retas cuya fecha_hora ya llegó y retorna las que cambiaron
+func (uc *ActualizarEstadosRetasUseCase) Execute(ahora time.Time) ([]entities.Reta, error) {
+	retas, err := uc.retaRepo.ObtenerRetasConCambioDeHorario(ahora)
+	if err != nil {
+		return nil, err
+	}
+
+	actualizadas := make([]entities.Reta, 0, len(retas))
+	for _, reta := range retas {
+		cambio := false
+
+		// Una reta atrasada puede pasar de abierta a en_juego y de ahí a finalizada en la misma ejecución
+		for {
+			siguiente := entities.EstadoPorHorario(reta.Estado, reta.FechaHora, reta.Duracion(), ahora)
+			if siguiente == reta.Estado {
+				break
+			}
+
+			anterior := reta.Estado
+			if err := reta.CambiarEstado(siguiente); err != nil {
+				log.Printf("Transición inválida para reta %s: %v", reta.ID, err)
+				break
+			}
+
+			ok, err := uc.retaRepo.ActualizarEstado(reta.ID, anterior, siguiente)
+			if err != nil {
+				return actualizadas, err
+			}
+			if !ok {
+				// Otro proceso cambió el estado (ej. la reta se canceló); se revisa en la siguiente ejecución
+				reta.Estado = anterior
+				break
+			}
+			cambio = true
+		}
+
+		if cambio {
+			actualizadas = append(actualizadas, reta)
+		}
+	}
+
+	return actualizadas, nil
+}
@@ -0,0 +1,24 @@
+package application
+
+import (
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+)
+
+type BuscarRetasCercanasUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewBuscarRetasCercanasUseCase(retaRepo repositories.IRetaRepository) *BuscarRetasCercanasUseCase {
+	return &BuscarRetasCercanasUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute retorna las retas próximas a la posición del usuario, de cualquier zona, ordenadas por distancia
+func (uc *BuscarRetasCercanasUseCase) Execute(busqueda entities.BusquedaCercana) ([]entities.RetaCercana, error) {
+	if err := busqueda.Normalizar(); err != nil {
+		return nil, err
+	}
+	return uc.retaRepo.BuscarRetasCercanas(busqueda)
+}
@@ -0,0 +1,55 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+	"strings"
+)
+
+type CancelarRetaAdminUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewCancelarRetaAdminUseCase(retaRepo repositories.IRetaRepository) *CancelarRetaAdminUseCase {
+	return &CancelarRetaAdminUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute cancela cualquier reta que no haya finalizado, aunque ya esté en juego, y registra
+// en la auditoría quién la canceló y por qué
+func (uc *CancelarRetaAdminUseCase) Execute(retaID, actorID, motivo string) (*entities.Reta, error) {
+	if retaID == "" {
+		return nil, errors.New("reta_id es requerido")
+	}
+	if err := core.ValidarMotivo(motivo); err != nil {
+		return nil, err
+	}
+	motivo = strings.TrimSpace(motivo)
+	if err := exigirModerador(uc.retaRepo, actorID); err != nil {
+		return nil, err
+	}
+
+	reta, err := uc.retaRepo.ObtenerRetaPorID(retaID)
+	if err != nil {
+		return nil, err
+	}
+	if err := entities.ValidarCancelacionForzada(reta.Estado); err != nil {
+		return nil, err
+	}
+
+	err = uc.retaRepo.ForzarCancelacion(retaID, &entities.EventoModeracion{
+		Evento:   entities.EventoCancelarReta,
+		ActorID:  actorID,
+		Objetivo: retaID,
+		Detalle:  motivo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reta.Estado = entities.EstadoCancelada
+	return reta, nil
+}
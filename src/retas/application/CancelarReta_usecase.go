@@ -0,0 +1,44 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+)
+
+type CancelarRetaUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewCancelarRetaUseCase(retaRepo repositories.IRetaRepository) *CancelarRetaUseCase {
+	return &CancelarRetaUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute cancela la reta si el usuario es su creador; la reta se conserva con estado "cancelada"
+func (uc *CancelarRetaUseCase) Execute(retaID, usuarioID string) (*entities.Reta, error) {
+	if retaID == "" {
+		return nil, errors.New("reta_id es requerido")
+	}
+
+	reta, err := uc.retaRepo.ObtenerRetaPorID(retaID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reta.CreadorID != usuarioID {
+		return nil, entities.ErrSoloCreador
+	}
+
+	// Solo las retas que no han comenzado pueden cancelarse
+	if err := reta.CambiarEstado(entities.EstadoCancelada); err != nil {
+		return nil, err
+	}
+
+	if err := uc.retaRepo.CancelarReta(retaID); err != nil {
+		return nil, err
+	}
+
+	return reta, nil
+}
@@ -0,0 +1,67 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+)
+
+type CancelarSerieUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewCancelarSerieUseCase(retaRepo repositories.IRetaRepository) *CancelarSerieUseCase {
+	return &CancelarSerieUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute cancela la serie completa si el usuario es su creador: ya no se crean más retas y se cancelan
+// las que aún no empiezan. Las retas en juego o finalizadas se conservan
+func (uc *CancelarSerieUseCase) Execute(serieID, usuarioID string) (*entities.Serie, *entities.ResultadoSerie, error) {
+	if serieID == "" {
+		return nil, nil, errors.New("serie_id es requerido")
+	}
+
+	serie, err := uc.retaRepo.ObtenerSerie(serieID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if serie.CreadorID != usuarioID {
+		return nil, nil, entities.ErrSoloCreadorSerie
+	}
+	if serie.Estado == entities.SerieCancelada {
+		return nil, nil, entities.ErrSerieCancelada
+	}
+
+	ok, err := uc.retaRepo.CambiarEstadoSerie(serie.ID, serie.Estado, entities.SerieCancelada)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		// Otro proceso cambió el estado entre la consulta y el UPDATE
+		return nil, nil, entities.ErrSerieCancelada
+	}
+	serie.Estado = entities.SerieCancelada
+
+	pendientes, err := uc.retaRepo.ObtenerOcurrenciasPendientes(serie.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resultado := &entities.ResultadoSerie{}
+	for i := range pendientes {
+		reta := &pendientes[i]
+		if err := uc.retaRepo.CancelarReta(reta.ID); err != nil {
+			// ErrRetaCerrada: la reta empezó mientras tanto y se conserva
+			if !errors.Is(err, entities.ErrRetaCerrada) {
+				resultado.Conflictos = append(resultado.Conflictos, entities.NuevoConflicto(reta, err))
+			}
+			continue
+		}
+		reta.Estado = entities.EstadoCancelada
+		resultado.Canceladas = append(resultado.Canceladas, *reta)
+	}
+
+	return serie, resultado, nil
+}
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"errors"
 	"games-football-api/src/retas/domain/entities"
 	"games-football-api/src/retas/domain/repositories"
 )
@@ -15,14 +16,42 @@ func NewCrearRetaUseCase(retaRepo repositories.IRetaRepository) *CrearRetaUseCas
 	}
 }
 
-func (uc *CrearRetaUseCase) Execute(zonaID, titulo, fechaHora string, maxJugadores int, creadorID, creadorNombre string) (*entities.Reta, *entities.Jugador, error) {
+// Execute crea la reta con el creador como primer jugador. La cancha se indica con canchaID (una cancha
+// registrada que se aparta) o con ubicacion (solo la posición); ambas son opcionales. duracionMinutos
+// en 0 usa la duración por defecto
+func (uc *CrearRetaUseCase) Execute(zonaID, titulo, fechaHora string, maxJugadores int, creadorID, creadorNombre string, ubicacion *entities.Ubicacion, canchaID string, duracionMinutos int) (*entities.Reta, *entities.Jugador, error) {
+	if zonaID == "" || titulo == "" || fechaHora == "" || maxJugadores <= 0 {
+		return nil, nil, errors.New("Campos requeridos: zona_id, titulo, fecha_hora, max_jugadores")
+	}
+	if canchaID != "" && ubicacion != nil {
+		return nil, nil, entities.ErrCanchaYUbicacion
+	}
+
+	duracion, err := entities.NormalizarDuracion(duracionMinutos)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := validarCancha(uc.retaRepo, zonaID, ubicacion); err != nil {
+		return nil, nil, err
+	}
+
 	// Crear la entidad Reta
 	reta, err := entities.NewReta(zonaID, titulo, fechaHora, maxJugadores, creadorID, creadorNombre)
 	if err != nil {
 		return nil, nil, err
 	}
+	reta.Ubicacion = ubicacion
+	reta.DuracionMinutos = duracion
+
+	if canchaID != "" {
+		if err := apartarCancha(uc.retaRepo, reta, canchaID); err != nil {
+			return nil, nil, err
+		}
+	}
 
-	// El repositorio crea la reta e inserta al creador como primer jugador
+	// El repositorio crea la reta e inserta al creador como primer jugador; si hay cancha, revisa que
+	// el horario siga libre dentro de la misma transacción
 	retaCreada, primerJugador, err := uc.retaRepo.CrearReta(reta)
 	if err != nil {
 		return nil, nil, err
@@ -30,3 +59,19 @@ func (uc *CrearRetaUseCase) Execute(zonaID, titulo, fechaHora string, maxJugador
 
 	return retaCreada, primerJugador, nil
 }
+
+// apartarCancha valida que la reta quepa en la cancha (zona, capacidad y horario) y le asigna la cancha
+// y su ubicación. El choque con otras retas lo revisa el repositorio con la cancha bloqueada
+func apartarCancha(retaRepo repositories.IRetaRepository, reta *entities.Reta, canchaID string) error {
+	cancha, err := retaRepo.ObtenerCancha(canchaID)
+	if err != nil {
+		return err
+	}
+	if err := entities.ValidarRetaEnCancha(cancha, reta); err != nil {
+		return err
+	}
+
+	reta.CanchaID = cancha.ID
+	reta.Ubicacion = entities.UbicacionDeCancha(cancha)
+	return nil
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+	"time"
+)
+
+type CrearSerieUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewCrearSerieUseCase(retaRepo repositories.IRetaRepository) *CrearSerieUseCase {
+	return &CrearSerieUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute valida y guarda una serie recurrente; sus retas las crea MaterializarSeriesUseCase. Como en
+// CrearReta, la cancha se indica con canchaID o con ubicacion y ambas son opcionales. fecha_inicio vacía
+// empieza hoy
+func (uc *CrearSerieUseCase) Execute(zonaID, titulo string, regla entities.ReglaRecurrencia, maxJugadores int, creadorID, creadorNombre string, ubicacion *entities.Ubicacion, canchaID string, duracionMinutos int) (*entities.Serie, error) {
+	if zonaID == "" || titulo == "" || maxJugadores <= 0 {
+		return nil, errors.New("Campos requeridos: zona_id, titulo, dias_semana, hora, max_jugadores")
+	}
+	if canchaID != "" && ubicacion != nil {
+		return nil, entities.ErrCanchaYUbicacion
+	}
+
+	hoy := time.Now().In(core.ZonaHoraria).Format("2006-01-02")
+	if regla.FechaInicio == "" {
+		regla.FechaInicio = hoy
+	}
+	if err := regla.Normalizar(); err != nil {
+		return nil, err
+	}
+	if regla.FechaInicio < hoy {
+		return nil, entities.ErrSerieFechaInicio
+	}
+
+	// La primera semana siempre tiene alguno de los días elegidos, salvo que hasta la corte antes
+	inicio, _ := core.FechaHoraLocal("2006-01-02", regla.FechaInicio)
+	if len(regla.Fechas(inicio.AddDate(0, 0, 7))) == 0 {
+		return nil, entities.ErrSerieSinOcurrencias
+	}
+
+	duracion, err := entities.NormalizarDuracion(duracionMinutos)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validarCancha(uc.retaRepo, zonaID, ubicacion); err != nil {
+		return nil, err
+	}
+
+	serie := &entities.Serie{
+		ZonaID:           zonaID,
+		Titulo:           titulo,
+		ReglaRecurrencia: regla,
+		MaxJugadores:     maxJugadores,
+		DuracionMinutos:  duracion,
+		Ubicacion:        ubicacion,
+		CreadorID:        creadorID,
+		CreadorNombre:    creadorNombre,
+	}
+
+	// Zona, cupo y horario son iguales en todas las ocurrencias, así que basta validar la primera contra la cancha
+	if canchaID != "" {
+		reta := serie.NuevaOcurrencia(serie.FechaHoraDe(serie.FechaInicio))
+		if err := apartarCancha(uc.retaRepo, reta, canchaID); err != nil {
+			return nil, err
+		}
+		serie.CanchaID = reta.CanchaID
+		serie.Ubicacion = reta.Ubicacion
+	}
+
+	if err := uc.retaRepo.CrearSerie(serie); err != nil {
+		return nil, err
+	}
+
+	return serie, nil
+}
@@ -0,0 +1,40 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+)
+
+type DesuscribirSerieUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewDesuscribirSerieUseCase(retaRepo repositories.IRetaRepository) *DesuscribirSerieUseCase {
+	return &DesuscribirSerieUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute quita al usuario de los jugadores habituales de la serie; sigue inscrito en las retas ya
+// creadas y puede salir de ellas con la acción normal
+func (uc *DesuscribirSerieUseCase) Execute(serieID, usuarioID string) error {
+	if serieID == "" {
+		return errors.New("serie_id es requerido")
+	}
+
+	serie, err := uc.retaRepo.ObtenerSerie(serieID)
+	if err != nil {
+		return err
+	}
+
+	ok, err := uc.retaRepo.DesuscribirDeSerie(serie.ID, usuarioID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return entities.ErrSinSuscripcion
+	}
+
+	return nil
+}
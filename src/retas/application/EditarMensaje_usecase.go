@@ -0,0 +1,52 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+	"time"
+)
+
+type EditarMensajeUseCase struct {
+	retaRepo   repositories.IRetaRepository
+	moderacion *CadenaModeracion
+}
+
+func NewEditarMensajeUseCase(retaRepo repositories.IRetaRepository, moderacion *CadenaModeracion) *EditarMensajeUseCase {
+	return &EditarMensajeUseCase{
+		retaRepo:   retaRepo,
+		moderacion: moderacion,
+	}
+}
+
+// Execute corrige el texto de un mensaje; solo el autor puede hacerlo y solo dentro de VentanaEdicionMensaje
+func (uc *EditarMensajeUseCase) Execute(retaID, mensajeID, usuarioID, texto string) (*entities.Mensaje, error) {
+	if retaID == "" || mensajeID == "" || texto == "" {
+		return nil, errors.New("reta_id, mensaje_id y texto son requeridos")
+	}
+
+	mensaje, err := uc.retaRepo.ObtenerMensaje(retaID, mensajeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mensaje.UsuarioID != usuarioID {
+		return nil, entities.ErrSoloAutor
+	}
+	if mensaje.Eliminado {
+		return nil, entities.ErrMensajeEliminado
+	}
+	if !mensaje.Editable(time.Now()) {
+		return nil, entities.ErrVentanaEdicion
+	}
+
+	// El texto corregido pasa por las mismas restricciones que un mensaje nuevo
+	if err := verificarPuedeEscribir(uc.retaRepo, retaID, usuarioID); err != nil {
+		return nil, err
+	}
+	if err := uc.moderacion.Revisar(retaID, usuarioID, texto); err != nil {
+		return nil, err
+	}
+
+	return uc.retaRepo.EditarMensaje(mensajeID, texto)
+}
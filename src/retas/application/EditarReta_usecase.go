@@ -0,0 +1,98 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+)
+
+type EditarRetaUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewEditarRetaUseCase(retaRepo repositories.IRetaRepository) *EditarRetaUseCase {
+	return &EditarRetaUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute aplica los cambios enviados (los vacíos se ignoran) y retorna la reta actualizada
+// junto con el estado de jugadores y lista de espera. Si la reta tiene cancha, el nuevo horario y cupo
+// deben caber en ella y la ubicación no se puede cambiar. En una reta de una serie solo cambia esa
+// ocurrencia
+func (uc *EditarRetaUseCase) Execute(retaID, usuarioID, titulo, fechaHora string, maxJugadores int, ubicacion *entities.Ubicacion, duracionMinutos int) (*entities.Reta, *entities.MovimientoReta, error) {
+	if retaID == "" {
+		return nil, nil, errors.New("reta_id es requerido")
+	}
+	if titulo == "" && fechaHora == "" && maxJugadores == 0 && ubicacion == nil && duracionMinutos == 0 {
+		return nil, nil, errors.New("envía al menos uno de: titulo, fecha_hora, max_jugadores, ubicacion, duracion_minutos")
+	}
+
+	reta, err := uc.retaRepo.ObtenerRetaPorID(retaID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if reta.CreadorID != usuarioID {
+		return nil, nil, entities.ErrSoloCreador
+	}
+	if err := entities.ValidarCambioDeJugadores(reta.Estado); err != nil {
+		return nil, nil, err
+	}
+
+	if titulo != "" {
+		reta.Titulo = titulo
+	}
+	if fechaHora != "" {
+		nuevaFecha, err := core.FechaHoraLocal("2006-01-02 15:04:05", fechaHora)
+		if err != nil {
+			return nil, nil, entities.ErrFormatoFecha
+		}
+		if err := entities.ValidarFechaFutura(nuevaFecha); err != nil {
+			return nil, nil, err
+		}
+		reta.FechaHora = nuevaFecha
+	}
+	if maxJugadores < 0 {
+		return nil, nil, errors.New("max_jugadores debe ser mayor a 0")
+	}
+	if maxJugadores > 0 {
+		reta.MaxJugadores = maxJugadores
+	}
+	if duracionMinutos != 0 {
+		duracion, err := entities.NormalizarDuracion(duracionMinutos)
+		if err != nil {
+			return nil, nil, err
+		}
+		reta.DuracionMinutos = duracion
+	}
+	if ubicacion != nil {
+		if reta.CanchaID != "" {
+			return nil, nil, entities.ErrCanchaYUbicacion
+		}
+		if err := validarCancha(uc.retaRepo, reta.ZonaID, ubicacion); err != nil {
+			return nil, nil, err
+		}
+		reta.Ubicacion = ubicacion
+	}
+	if reta.CanchaID != "" {
+		if err := apartarCancha(uc.retaRepo, reta, reta.CanchaID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Una reta de una serie editada por separado ya no recibe los cambios de la serie completa
+	if reta.SerieID != "" {
+		reta.OcurrenciaEditada = true
+	}
+
+	// El repositorio valida el cupo contra los inscritos dentro de la transacción
+	movimiento, err := uc.retaRepo.EditarReta(reta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reta.JugadoresActuales = movimiento.JugadoresActuales
+	return reta, movimiento, nil
+}
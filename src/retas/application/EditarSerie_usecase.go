@@ -0,0 +1,160 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+	"time"
+)
+
+type EditarSerieUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewEditarSerieUseCase(retaRepo repositories.IRetaRepository) *EditarSerieUseCase {
+	return &EditarSerieUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute aplica los cambios a la serie completa y a sus retas ya creadas que aún no empiezan: las que
+// caen en días que la regla ya no incluye se cancelan y el resto toma el nuevo título, cupo, duración y
+// hora, salvo las que se editaron por separado. Las retas que no admiten el cambio (ej. el nuevo cupo es
+// menor a sus inscritos) se quedan como estaban y se reportan como conflictos
+func (uc *EditarSerieUseCase) Execute(serieID, usuarioID string, cambios entities.CambiosSerie) (*entities.Serie, *entities.ResultadoSerie, error) {
+	if serieID == "" {
+		return nil, nil, errors.New("serie_id es requerido")
+	}
+	if cambios.Vacio() {
+		return nil, nil, entities.ErrSerieSinCambios
+	}
+
+	serie, err := uc.retaRepo.ObtenerSerie(serieID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if serie.CreadorID != usuarioID {
+		return nil, nil, entities.ErrSoloCreadorSerie
+	}
+	if serie.Estado == entities.SerieCancelada {
+		return nil, nil, entities.ErrSerieCancelada
+	}
+
+	regla := serie.ReglaRecurrencia
+	if len(cambios.DiasSemana) > 0 {
+		regla.DiasSemana = cambios.DiasSemana
+	}
+	if cambios.Hora != "" {
+		regla.Hora = cambios.Hora
+	}
+	// Hasta y ocurrencias se excluyen: enviar uno reemplaza al otro
+	if cambios.Hasta != nil {
+		regla.Hasta = *cambios.Hasta
+		if cambios.Ocurrencias == nil {
+			regla.Ocurrencias = 0
+		}
+	}
+	if cambios.Ocurrencias != nil {
+		regla.Ocurrencias = *cambios.Ocurrencias
+		if cambios.Hasta == nil {
+			regla.Hasta = ""
+		}
+	}
+	if err := regla.Normalizar(); err != nil {
+		return nil, nil, err
+	}
+	serie.ReglaRecurrencia = regla
+
+	if cambios.Titulo != "" {
+		serie.Titulo = cambios.Titulo
+	}
+	if cambios.MaxJugadores < 0 {
+		return nil, nil, errors.New("max_jugadores debe ser mayor a 0")
+	}
+	if cambios.MaxJugadores > 0 {
+		serie.MaxJugadores = cambios.MaxJugadores
+	}
+	if cambios.DuracionMinutos != 0 {
+		duracion, err := entities.NormalizarDuracion(cambios.DuracionMinutos)
+		if err != nil {
+			return nil, nil, err
+		}
+		serie.DuracionMinutos = duracion
+	}
+
+	// El nuevo horario y cupo deben caber en la cancha de la serie
+	if serie.CanchaID != "" {
+		reta := serie.NuevaOcurrencia(serie.FechaHoraDe(serie.FechaInicio))
+		if err := apartarCancha(uc.retaRepo, reta, serie.CanchaID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Una serie terminada vuelve a activarse; si su nuevo fin no deja días por crear, el scheduler la termina otra vez
+	serie.Estado = entities.SerieActiva
+	if err := uc.retaRepo.ActualizarSerie(serie); err != nil {
+		return nil, nil, err
+	}
+
+	pendientes, err := uc.retaRepo.ObtenerOcurrenciasPendientes(serie.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ahora := time.Now()
+	resultado := &entities.ResultadoSerie{}
+	for i := range pendientes {
+		reta := &pendientes[i]
+		if !reta.FechaHora.After(ahora) {
+			// Ya empezó; el scheduler de estados la avanza
+			continue
+		}
+
+		if !serie.Incluye(reta.Ocurrencia) {
+			if err := uc.retaRepo.CancelarReta(reta.ID); err != nil {
+				if !errors.Is(err, entities.ErrRetaCerrada) {
+					resultado.Conflictos = append(resultado.Conflictos, entities.NuevoConflicto(reta, err))
+				}
+				continue
+			}
+			reta.Estado = entities.EstadoCancelada
+			resultado.Canceladas = append(resultado.Canceladas, *reta)
+			continue
+		}
+
+		if reta.OcurrenciaEditada {
+			continue
+		}
+
+		original := *reta
+		reta.Titulo = serie.Titulo
+		reta.MaxJugadores = serie.MaxJugadores
+		reta.DuracionMinutos = serie.DuracionMinutos
+		reta.FechaHora = serie.FechaHoraDe(reta.Ocurrencia)
+		if reta.Titulo == original.Titulo && reta.MaxJugadores == original.MaxJugadores &&
+			reta.DuracionMinutos == original.DuracionMinutos && reta.FechaHora.Equal(original.FechaHora) {
+			continue
+		}
+		if !reta.FechaHora.After(ahora) {
+			// La nueva hora de hoy ya pasó; esta reta conserva la anterior
+			continue
+		}
+
+		if reta.CanchaID != "" {
+			if err := apartarCancha(uc.retaRepo, reta, reta.CanchaID); err != nil {
+				resultado.Conflictos = append(resultado.Conflictos, entities.NuevoConflicto(&original, err))
+				continue
+			}
+		}
+
+		movimiento, err := uc.retaRepo.EditarReta(reta)
+		if err != nil {
+			resultado.Conflictos = append(resultado.Conflictos, entities.NuevoConflicto(&original, err))
+			continue
+		}
+		reta.JugadoresActuales = movimiento.JugadoresActuales
+		resultado.Actualizadas = append(resultado.Actualizadas, entities.OcurrenciaActualizada{Reta: reta, Movimiento: movimiento})
+	}
+
+	return serie, resultado, nil
+}
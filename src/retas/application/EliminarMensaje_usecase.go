@@ -0,0 +1,67 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"games-football-api/src/core"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+)
+
+type EliminarMensajeUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewEliminarMensajeUseCase(retaRepo repositories.IRetaRepository) *EliminarMensajeUseCase {
+	return &EliminarMensajeUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute elimina un mensaje dejando un marcador en el historial. El autor puede eliminarlo
+// en cualquier momento, el creador de la reta puede eliminar cualquier mensaje de su chat y los
+// moderadores cualquier mensaje de cualquier chat; esto último queda en la auditoría. El rol se lee de
+// la base de datos y no del token, para que un moderador degradado pierda el permiso de inmediato
+func (uc *EliminarMensajeUseCase) Execute(retaID, mensajeID, usuarioID string) (*entities.Mensaje, error) {
+	if retaID == "" || mensajeID == "" {
+		return nil, errors.New("reta_id y mensaje_id son requeridos")
+	}
+
+	mensaje, err := uc.retaRepo.ObtenerMensaje(retaID, mensajeID)
+	if err != nil {
+		return nil, err
+	}
+	if mensaje.Eliminado {
+		return nil, entities.ErrMensajeEliminado
+	}
+
+	// Solo la eliminación por moderación queda en la auditoría
+	var auditoria *entities.EventoModeracion
+	if mensaje.UsuarioID != usuarioID {
+		reta, err := uc.retaRepo.ObtenerRetaPorID(retaID)
+		if err != nil {
+			return nil, err
+		}
+		if reta.CreadorID != usuarioID {
+			cuenta, err := uc.retaRepo.ObtenerCuenta(usuarioID)
+			if err != nil {
+				return nil, err
+			}
+			if !core.TieneRol(cuenta.Rol, core.RolModerador) {
+				return nil, entities.ErrSinPermisoEliminar
+			}
+			auditoria = &entities.EventoModeracion{
+				Evento:   entities.EventoEliminarMensaje,
+				ActorID:  usuarioID,
+				Objetivo: mensajeID,
+				Detalle:  fmt.Sprintf("mensaje de %s en la reta %s", mensaje.UsuarioID, retaID),
+			}
+		}
+	}
+
+	eliminado, err := uc.retaRepo.EliminarMensaje(mensajeID, usuarioID, auditoria)
+	if err != nil {
+		return nil, err
+	}
+	return eliminado, nil
+}
@@ -7,12 +7,14 @@ import (
 )
 
 type EnviarMensajeUseCase struct {
-	retaRepo repositories.IRetaRepository
+	retaRepo   repositories.IRetaRepository
+	moderacion *CadenaModeracion
 }
 
-func NewEnviarMensajeUseCase(retaRepo repositories.IRetaRepository) *EnviarMensajeUseCase {
+func NewEnviarMensajeUseCase(retaRepo repositories.IRetaRepository, moderacion *CadenaModeracion) *EnviarMensajeUseCase {
 	return &EnviarMensajeUseCase{
-		retaRepo: retaRepo,
+		retaRepo:   retaRepo,
+		moderacion: moderacion,
 	}
 }
 
@@ -22,6 +24,20 @@ func (uc *EnviarMensajeUseCase) Execute(retaID, usuarioID, texto string) (*entit
 		return nil, errors.New("reta_id, usuario_id y texto son requeridos")
 	}
 
+	// Solo escriben los participantes de la reta (y los moderadores); un usuario silenciado o
+	// expulsado del chat tampoco puede escribir
+	if err := verificarAccesoChat(uc.retaRepo, retaID, usuarioID); err != nil {
+		return nil, err
+	}
+	if err := verificarPuedeEscribir(uc.retaRepo, retaID, usuarioID); err != nil {
+		return nil, err
+	}
+
+	// La cadena de moderación rechaza el mensaje antes de guardarlo
+	if err := uc.moderacion.Revisar(retaID, usuarioID, texto); err != nil {
+		return nil, err
+	}
+
 	mensaje := *entities.NewMensaje(retaID, usuarioID, texto)
 
 	// El repositorio guarda el mensaje y hace JOIN con usuarios para obtener el nombre real
@@ -0,0 +1,30 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+)
+
+type MarcarLeidoUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewMarcarLeidoUseCase(retaRepo repositories.IRetaRepository) *MarcarLeidoUseCase {
+	return &MarcarLeidoUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute guarda mensajeID como el último mensaje leído por el usuario en el chat de la reta
+func (uc *MarcarLeidoUseCase) Execute(retaID, usuarioID, mensajeID string) (*entities.LecturaChat, error) {
+	if retaID == "" || usuarioID == "" || mensajeID == "" {
+		return nil, errors.New("reta_id, usuario_id y mensaje_id son requeridos")
+	}
+
+	if err := verificarAccesoChat(uc.retaRepo, retaID, usuarioID); err != nil {
+		return nil, err
+	}
+
+	return uc.retaRepo.MarcarLeido(retaID, usuarioID, mensajeID)
+}
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+	"log"
+	"time"
+)
+
+type MaterializarSeriesUseCase struct {
+	retaRepo  repositories.IRetaRepository
+	horizonte time.Duration
+}
+
+// NewMaterializarSeriesUseCase recibe el horizonte: qué tan adelante se crean las retas de cada serie
+func NewMaterializarSeriesUseCase(retaRepo repositories.IRetaRepository, horizonte time.Duration) *MaterializarSeriesUseCase {
+	return &MaterializarSeriesUseCase{
+		retaRepo:  retaRepo,
+		horizonte: horizonte,
+	}
+}
+
+// Execute crea las retas que les faltan a todas las series activas dentro del horizonte
+func (uc *MaterializarSeriesUseCase) Execute(ahora time.Time) (*entities.ResultadoSerie, error) {
+	series, err := uc.retaRepo.ObtenerSeriesActivas()
+	if err != nil {
+		return nil, err
+	}
+
+	resultado := &entities.ResultadoSerie{}
+	for i := range series {
+		parcial, err := uc.ExecuteSerie(&series[i], ahora)
+		// Las retas creadas antes de un error ya quedaron guardadas y hay que notificarlas
+		if parcial != nil {
+			resultado.Creadas = append(resultado.Creadas, parcial.Creadas...)
+			resultado.Conflictos = append(resultado.Conflictos, parcial.Conflictos...)
+		}
+		if err != nil {
+			// Una serie con problemas no detiene a las demás; se reintenta en la siguiente ejecución
+			log.Printf("Error al materializar la serie %s: %v", series[i].ID, err)
+		}
+	}
+
+	return resultado, nil
+}
+
+// ExecuteSerie crea las retas de la serie que aún no empiezan y caen dentro del horizonte, con sus
+// suscriptores inscritos, y marca la serie como terminada cuando ya no le quedan días por crear.
+// Los días que ya tienen reta (aunque se haya cancelado) no se vuelven a crear. Si falla a la mitad
+// retorna también el resultado con las retas que sí se crearon
+func (uc *MaterializarSeriesUseCase) ExecuteSerie(serie *entities.Serie, ahora time.Time) (*entities.ResultadoSerie, error) {
+	resultado := &entities.ResultadoSerie{}
+	if serie.Estado != entities.SerieActiva {
+		return resultado, nil
+	}
+
+	existentes, err := uc.retaRepo.ObtenerOcurrencias(serie.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	limite := ahora.Add(uc.horizonte)
+	for _, fechaHora := range serie.Fechas(limite) {
+		if existentes[fechaHora.Format("2006-01-02")] || !fechaHora.After(ahora) {
+			continue
+		}
+
+		reta := serie.NuevaOcurrencia(fechaHora)
+
+		// La cancha pudo cambiar de horario o capacidad desde que se creó la serie
+		if reta.CanchaID != "" {
+			if err := apartarCancha(uc.retaRepo, reta, reta.CanchaID); err != nil {
+				resultado.Conflictos = append(resultado.Conflictos, entities.NuevoConflicto(reta, err))
+				continue
+			}
+		}
+
+		movimiento, err := uc.retaRepo.CrearOcurrencia(reta, serie.Suscriptores)
+		switch {
+		case errors.Is(err, entities.ErrOcurrenciaExistente):
+			// Otra réplica la creó primero
+			continue
+		case errors.Is(err, entities.ErrSerieInactiva):
+			// La serie se canceló mientras se materializaba
+			return resultado, nil
+		case errors.Is(err, entities.ErrCanchaOcupada):
+			reta.ID = ""
+			resultado.Conflictos = append(resultado.Conflictos, entities.NuevoConflicto(reta, err))
+			continue
+		case err != nil:
+			return resultado, err
+		}
+
+		resultado.Creadas = append(resultado.Creadas, entities.OcurrenciaActualizada{Reta: reta, Movimiento: movimiento})
+	}
+
+	// Con conflictos pendientes la serie sigue activa para reintentar esos días
+	if len(resultado.Conflictos) == 0 && serie.Agotada(limite) {
+		if _, err := uc.retaRepo.CambiarEstadoSerie(serie.ID, entities.SerieActiva, entities.SerieTerminada); err != nil {
+			return resultado, err
+		}
+		serie.Estado = entities.SerieTerminada
+	}
+
+	return resultado, nil
+}
@@ -16,11 +16,37 @@ func NewObtenerHistorialChatUseCase(retaRepo repositories.IRetaRepository) *Obte
 	}
 }
 
-// Execute obtiene el historial de mensajes de una reta
-func (uc *ObtenerHistorialChatUseCase) Execute(retaID string) ([]entities.Mensaje, error) {
+// Execute obtiene una página del historial de mensajes de una reta para el usuario; sin cursor retorna
+// los más recientes. Solo los participantes de la reta y los moderadores pueden leerlo
+func (uc *ObtenerHistorialChatUseCase) Execute(retaID, usuarioID string, consulta entities.ConsultaMensajes) (*entities.PaginaMensajes, error) {
 	if retaID == "" {
 		return nil, errors.New("reta_id es requerido")
 	}
 
-	return uc.retaRepo.ObtenerMensajesDeReta(retaID)
+	cursores := 0
+	for _, presente := range []bool{consulta.Antes != "", consulta.Despues != "", consulta.AntesDe != nil, consulta.DespuesDe != nil} {
+		if presente {
+			cursores++
+		}
+	}
+	if cursores > 1 {
+		return nil, entities.ErrCursorInvalido
+	}
+
+	if consulta.Limite < 0 {
+		return nil, errors.New("limite debe ser mayor a 0")
+	}
+	if consulta.Limite == 0 {
+		consulta.Limite = entities.LimiteMensajesPorDefecto
+	}
+	if consulta.Limite > entities.LimiteMensajesMaximo {
+		consulta.Limite = entities.LimiteMensajesMaximo
+	}
+
+	// Validar que la reta exista y que el usuario pueda leer su chat
+	if err := verificarAccesoChat(uc.retaRepo, retaID, usuarioID); err != nil {
+		return nil, err
+	}
+
+	return uc.retaRepo.ObtenerMensajesDeReta(retaID, consulta)
 }
@@ -0,0 +1,68 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+)
+
+type ObtenerRetaUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewObtenerRetaUseCase(retaRepo repositories.IRetaRepository) *ObtenerRetaUseCase {
+	return &ObtenerRetaUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute obtiene una reta con sus jugadores, su lista de espera y, si el usuario puede leer el chat,
+// sus últimos mensajes
+func (uc *ObtenerRetaUseCase) Execute(retaID, usuarioID string) (*entities.RetaInfo, error) {
+	if retaID == "" {
+		return nil, errors.New("reta_id es requerido")
+	}
+
+	reta, err := uc.retaRepo.ObtenerRetaPorID(retaID)
+	if err != nil {
+		return nil, err
+	}
+
+	jugadores, err := uc.retaRepo.ObtenerJugadoresDeReta(retaID)
+	if err != nil {
+		return nil, err
+	}
+
+	espera, err := uc.retaRepo.ObtenerListaEspera(retaID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Quien no participa en la reta la ve sin su chat, salvo los moderadores
+	info := entities.NewRetaInfo(reta, jugadores, espera)
+	info.OcultarChat()
+	if !info.Participa(usuarioID) {
+		moderador, err := esModerador(uc.retaRepo, usuarioID)
+		if err != nil {
+			return nil, err
+		}
+		if !moderador {
+			return info, nil
+		}
+	}
+
+	// El resto del historial se pide paginado en GET /api/retas/:id/mensajes
+	pagina, err := uc.retaRepo.ObtenerMensajesDeReta(retaID, entities.ConsultaMensajes{Limite: entities.MensajesEnSnapshot})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := uc.retaRepo.ContarMensajesDeReta(retaID)
+	if err != nil {
+		return nil, err
+	}
+
+	info.HistorialChat = pagina.Mensajes
+	info.TotalMensajes = total
+	return info, nil
+}
@@ -15,6 +15,19 @@ func NewObtenerRetasPorZonaUseCase(retaRepo repositories.IRetaRepository) *Obten
 	}
 }
 
-func (uc *ObtenerRetasPorZonaUseCase) Execute(zonaID string) ([]entities.RetaInfo, error) {
-	return uc.retaRepo.ObtenerRetasPorZona(zonaID)
+// Execute lista las retas de la zona; los últimos mensajes del chat solo vienen en las retas en las que
+// participa filtro.UsuarioID
+func (uc *ObtenerRetasPorZonaUseCase) Execute(zonaID string, filtro entities.FiltroRetas) ([]entities.RetaInfo, error) {
+	if err := validarZona(uc.retaRepo, zonaID); err != nil {
+		return nil, err
+	}
+
+	retas, err := uc.retaRepo.ObtenerRetasPorZona(zonaID, filtro)
+	if err != nil {
+		return nil, err
+	}
+	if err := ocultarChatsAjenos(uc.retaRepo, filtro.UsuarioID, retas); err != nil {
+		return nil, err
+	}
+	return retas, nil
 }
@@ -0,0 +1,30 @@
+package application
+
+import (
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+	"time"
+)
+
+type ObtenerSancionesUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewObtenerSancionesUseCase(retaRepo repositories.IRetaRepository) *ObtenerSancionesUseCase {
+	return &ObtenerSancionesUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute lista las sanciones vigentes del chat de una reta; solo el creador puede consultarlas
+func (uc *ObtenerSancionesUseCase) Execute(retaID, creadorID string) ([]entities.Sancion, error) {
+	reta, err := uc.retaRepo.ObtenerRetaPorID(retaID)
+	if err != nil {
+		return nil, err
+	}
+	if reta.CreadorID != creadorID {
+		return nil, entities.ErrSoloCreador
+	}
+
+	return uc.retaRepo.ObtenerSancionesActivas(retaID, time.Now())
+}
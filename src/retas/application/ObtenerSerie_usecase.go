@@ -0,0 +1,36 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+)
+
+type ObtenerSerieUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewObtenerSerieUseCase(retaRepo repositories.IRetaRepository) *ObtenerSerieUseCase {
+	return &ObtenerSerieUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute retorna la serie con sus suscriptores y sus retas ya creadas que siguen abiertas o llenas
+func (uc *ObtenerSerieUseCase) Execute(serieID string) (*entities.Serie, []entities.Reta, error) {
+	if serieID == "" {
+		return nil, nil, errors.New("serie_id es requerido")
+	}
+
+	serie, err := uc.retaRepo.ObtenerSerie(serieID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proximas, err := uc.retaRepo.ObtenerOcurrenciasPendientes(serie.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return serie, proximas, nil
+}
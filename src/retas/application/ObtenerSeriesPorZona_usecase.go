@@ -0,0 +1,24 @@
+package application
+
+import (
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+)
+
+type ObtenerSeriesPorZonaUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewObtenerSeriesPorZonaUseCase(retaRepo repositories.IRetaRepository) *ObtenerSeriesPorZonaUseCase {
+	return &ObtenerSeriesPorZonaUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute lista las series activas de una zona registrada
+func (uc *ObtenerSeriesPorZonaUseCase) Execute(zonaID string) ([]entities.Serie, error) {
+	if err := validarZona(uc.retaRepo, zonaID); err != nil {
+		return nil, err
+	}
+	return uc.retaRepo.ObtenerSeriesPorZona(zonaID)
+}
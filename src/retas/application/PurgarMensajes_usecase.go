@@ -0,0 +1,39 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"games-football-api/src/retas/domain/repositories"
+	"strings"
+)
+
+type PurgarMensajesUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewPurgarMensajesUseCase(retaRepo repositories.IRetaRepository) *PurgarMensajesUseCase {
+	return &PurgarMensajesUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute elimina todos los mensajes del chat de la reta, o solo los de usuarioID si no está vacío,
+// dejando marcadores en el historial. Retorna cuántos mensajes se eliminaron
+func (uc *PurgarMensajesUseCase) Execute(retaID, usuarioID, actorID, motivo string) (int, error) {
+	if retaID == "" {
+		return 0, errors.New("reta_id es requerido")
+	}
+	if err := core.ValidarMotivo(motivo); err != nil {
+		return 0, err
+	}
+	motivo = strings.TrimSpace(motivo)
+	if err := exigirModerador(uc.retaRepo, actorID); err != nil {
+		return 0, err
+	}
+
+	if _, err := uc.retaRepo.ObtenerRetaPorID(retaID); err != nil {
+		return 0, err
+	}
+
+	return uc.retaRepo.PurgarMensajes(retaID, usuarioID, actorID, motivo)
+}
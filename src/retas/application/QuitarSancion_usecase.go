@@ -0,0 +1,41 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+)
+
+type QuitarSancionUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewQuitarSancionUseCase(retaRepo repositories.IRetaRepository) *QuitarSancionUseCase {
+	return &QuitarSancionUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute levanta el silencio o la expulsión de un usuario; solo el creador de la reta puede hacerlo
+func (uc *QuitarSancionUseCase) Execute(retaID, creadorID, usuarioID string) error {
+	if retaID == "" || usuarioID == "" {
+		return errors.New("reta_id y usuario_id son requeridos")
+	}
+
+	reta, err := uc.retaRepo.ObtenerRetaPorID(retaID)
+	if err != nil {
+		return err
+	}
+	if reta.CreadorID != creadorID {
+		return entities.ErrSoloCreador
+	}
+
+	habia, err := uc.retaRepo.QuitarSancion(retaID, usuarioID)
+	if err != nil {
+		return err
+	}
+	if !habia {
+		return entities.ErrSinSancion
+	}
+	return nil
+}
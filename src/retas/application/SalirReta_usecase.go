@@ -0,0 +1,42 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+)
+
+type SalirRetaUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewSalirRetaUseCase(retaRepo repositories.IRetaRepository) *SalirRetaUseCase {
+	return &SalirRetaUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute saca al usuario de la reta (o de su lista de espera) y retorna el estado actualizado
+func (uc *SalirRetaUseCase) Execute(retaID, usuarioID string) (*entities.MovimientoReta, error) {
+	if retaID == "" || usuarioID == "" {
+		return nil, errors.New("reta_id y usuario_id son requeridos")
+	}
+
+	reta, err := uc.retaRepo.ObtenerRetaPorID(retaID)
+	if err != nil {
+		return nil, err
+	}
+
+	// El creador es el primer jugador y responsable de la reta, no puede abandonarla
+	if reta.CreadorID == usuarioID {
+		return nil, errors.New("el creador no puede salir de su propia reta")
+	}
+
+	// El repositorio maneja la transacción con SELECT FOR UPDATE y la promoción desde la lista de espera
+	movimiento, err := uc.retaRepo.SalirReta(retaID, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+
+	return movimiento, nil
+}
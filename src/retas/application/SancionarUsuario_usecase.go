@@ -0,0 +1,66 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+	"time"
+)
+
+type SancionarUsuarioUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewSancionarUsuarioUseCase(retaRepo repositories.IRetaRepository) *SancionarUsuarioUseCase {
+	return &SancionarUsuarioUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute silencia (por minutos, 15 por defecto) o expulsa a un usuario del chat de la reta;
+// solo el creador de la reta puede hacerlo
+func (uc *SancionarUsuarioUseCase) Execute(retaID, creadorID, usuarioID, tipo string, minutos int, motivo string) (*entities.Sancion, error) {
+	if retaID == "" || usuarioID == "" {
+		return nil, errors.New("reta_id y usuario_id son requeridos")
+	}
+	if tipo != entities.TipoSilencio && tipo != entities.TipoExpulsion {
+		return nil, entities.ErrSancionInvalida
+	}
+	if minutos < 0 {
+		return nil, errors.New("minutos debe ser mayor a 0")
+	}
+	if usuarioID == creadorID {
+		return nil, entities.ErrAutoSancion
+	}
+
+	reta, err := uc.retaRepo.ObtenerRetaPorID(retaID)
+	if err != nil {
+		return nil, err
+	}
+	if reta.CreadorID != creadorID {
+		return nil, entities.ErrSoloCreador
+	}
+
+	ahora := time.Now()
+	sancion := &entities.Sancion{
+		RetaID:    retaID,
+		UsuarioID: usuarioID,
+		Tipo:      tipo,
+		Motivo:    motivo,
+		CreadoPor: creadorID,
+		CreadoEn:  ahora,
+	}
+	if tipo == entities.TipoSilencio {
+		duracion := entities.DuracionSilencioPorDefecto
+		if minutos > 0 {
+			duracion = time.Duration(minutos) * time.Minute
+		}
+		hasta := ahora.Add(duracion)
+		sancion.Hasta = &hasta
+	}
+
+	if err := uc.retaRepo.GuardarSancion(sancion); err != nil {
+		return nil, err
+	}
+	return sancion, nil
+}
@@ -0,0 +1,46 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+	"time"
+)
+
+type SuscribirSerieUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewSuscribirSerieUseCase(retaRepo repositories.IRetaRepository) *SuscribirSerieUseCase {
+	return &SuscribirSerieUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute agrega al usuario a los jugadores habituales de la serie: queda inscrito (o en la lista de
+// espera) en las retas de la serie que ya se crearon y aún no empiezan, salvo las editadas por separado,
+// y en cada una que el scheduler cree de aquí en adelante. Retorna un movimiento por cada reta ya creada
+// en la que quedó
+func (uc *SuscribirSerieUseCase) Execute(serieID, usuarioID, nombre string) (*entities.Serie, []entities.MovimientoReta, error) {
+	if serieID == "" {
+		return nil, nil, errors.New("serie_id es requerido")
+	}
+
+	serie, err := uc.retaRepo.ObtenerSerie(serieID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if serie.Estado != entities.SerieActiva {
+		return nil, nil, entities.ErrSerieInactiva
+	}
+	if serie.CreadorID == usuarioID {
+		return nil, nil, entities.ErrCreadorSuscrito
+	}
+
+	movimientos, err := uc.retaRepo.SuscribirASerie(serie.ID, usuarioID, nombre, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return serie, movimientos, nil
+}
@@ -15,12 +15,13 @@ func NewUnirseRetaUseCase(retaRepo repositories.IRetaRepository) *UnirseRetaUseC
 	}
 }
 
-func (uc *UnirseRetaUseCase) Execute(retaID, usuarioID, nombreJugador string) (int, []entities.Jugador, error) {
+// Execute inscribe al usuario en la reta o lo agrega a la lista de espera si está llena
+func (uc *UnirseRetaUseCase) Execute(retaID, usuarioID, nombreJugador string) (*entities.MovimientoReta, error) {
 	// El repositorio maneja la transacción con SELECT FOR UPDATE y toda la lógica
-	jugadoresActuales, listaJugadores, err := uc.retaRepo.UnirseReta(retaID, usuarioID, nombreJugador)
+	movimiento, err := uc.retaRepo.UnirseReta(retaID, usuarioID, nombreJugador)
 	if err != nil {
-		return 0, nil, err
+		return nil, err
 	}
 
-	return jugadoresActuales, listaJugadores, nil
+	return movimiento, nil
 }
@@ -0,0 +1,39 @@
+package application
+
+import (
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+)
+
+type ValidarZonaUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewValidarZonaUseCase(retaRepo repositories.IRetaRepository) *ValidarZonaUseCase {
+	return &ValidarZonaUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute retorna ErrZonaNoEncontrada si la zona no está registrada. El WebSocket lo usa antes de
+// suscribir a una conexión a la zona, para no abrir topics de zonas que no existen
+func (uc *ValidarZonaUseCase) Execute(zonaID string) error {
+	return validarZona(uc.retaRepo, zonaID)
+}
+
+func validarZona(retaRepo repositories.IRetaRepository, zonaID string) error {
+	_, err := retaRepo.ObtenerZona(zonaID)
+	return err
+}
+
+// validarCancha revisa que la cancha de una reta esté dentro del radio de su zona; nil significa sin cancha
+func validarCancha(retaRepo repositories.IRetaRepository, zonaID string, ubicacion *entities.Ubicacion) error {
+	zona, err := retaRepo.ObtenerZona(zonaID)
+	if err != nil {
+		return err
+	}
+	if ubicacion == nil {
+		return nil
+	}
+	return zona.ValidarCancha(*ubicacion)
+}
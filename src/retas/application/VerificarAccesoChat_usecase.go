@@ -0,0 +1,77 @@
+package application
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+)
+
+type VerificarAccesoChatUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewVerificarAccesoChatUseCase(retaRepo repositories.IRetaRepository) *VerificarAccesoChatUseCase {
+	return &VerificarAccesoChatUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute retorna ErrSinAccesoChat si el usuario no puede seguir el chat de la reta. El WebSocket lo
+// revisa antes de suscribir la conexión para que no reciba ni un mensaje de un chat ajeno
+func (uc *VerificarAccesoChatUseCase) Execute(retaID, usuarioID string) error {
+	return verificarAccesoChat(uc.retaRepo, retaID, usuarioID)
+}
+
+// verificarAccesoChat permite el chat de una reta a su creador, a sus jugadores y a su lista de espera,
+// y a los moderadores, que lo necesitan para moderarlo; ErrRetaNoEncontrada si la reta no existe
+func verificarAccesoChat(retaRepo repositories.IRetaRepository, retaID, usuarioID string) error {
+	participa, err := retaRepo.EsParticipante(retaID, usuarioID)
+	if err != nil {
+		return err
+	}
+	if participa {
+		return nil
+	}
+
+	moderador, err := esModerador(retaRepo, usuarioID)
+	if err != nil {
+		return err
+	}
+	if !moderador {
+		return entities.ErrSinAccesoChat
+	}
+	return nil
+}
+
+// ocultarChatsAjenos quita la vista previa del chat de las retas en las que el usuario no participa;
+// a los moderadores se les muestran todas
+func ocultarChatsAjenos(retaRepo repositories.IRetaRepository, usuarioID string, retas []entities.RetaInfo) error {
+	var moderador, consultado bool
+	for i := range retas {
+		if retas[i].Participa(usuarioID) {
+			continue
+		}
+
+		// La cuenta se consulta solo si hay alguna reta ajena
+		if !consultado {
+			var err error
+			if moderador, err = esModerador(retaRepo, usuarioID); err != nil {
+				return err
+			}
+			consultado = true
+		}
+		if !moderador {
+			retas[i].OcultarChat()
+		}
+	}
+	return nil
+}
+
+// esModerador lee el rol de la base de datos; el del token no cambia hasta el siguiente refresh
+func esModerador(retaRepo repositories.IRetaRepository, usuarioID string) (bool, error) {
+	cuenta, err := retaRepo.ObtenerCuenta(usuarioID)
+	if err != nil {
+		return false, err
+	}
+	return core.TieneRol(cuenta.Rol, core.RolModerador), nil
+}
@@ -0,0 +1,44 @@
+package application
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+	"time"
+)
+
+type VerificarCuentaUseCase struct {
+	retaRepo repositories.IRetaRepository
+}
+
+func NewVerificarCuentaUseCase(retaRepo repositories.IRetaRepository) *VerificarCuentaUseCase {
+	return &VerificarCuentaUseCase{
+		retaRepo: retaRepo,
+	}
+}
+
+// Execute retorna la cuenta del usuario o ErrCuentaSuspendida si tiene un baneo vigente. El WebSocket
+// lo usa en el handshake: el access token de un usuario baneado sigue siendo válido hasta que expire
+func (uc *VerificarCuentaUseCase) Execute(usuarioID string) (*entities.CuentaUsuario, error) {
+	cuenta, err := uc.retaRepo.ObtenerCuenta(usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if cuenta.Suspendida(time.Now()) {
+		return nil, entities.ErrCuentaSuspendida
+	}
+	return cuenta, nil
+}
+
+// exigirModerador verifica en la base de datos que el actor de una acción de moderación siga siendo
+// moderador o admin; el rol del token no cambia hasta el siguiente refresh
+func exigirModerador(retaRepo repositories.IRetaRepository, actorID string) error {
+	cuenta, err := retaRepo.ObtenerCuenta(actorID)
+	if err != nil {
+		return err
+	}
+	if !core.TieneRol(cuenta.Rol, core.RolModerador) {
+		return entities.ErrRolInsuficiente
+	}
+	return nil
+}
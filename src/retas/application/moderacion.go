@@ -0,0 +1,215 @@
+package application
+
+import (
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ReglaModeracion revisa el texto de un mensaje antes de guardarlo; retorna un error de dominio si lo rechaza
+type ReglaModeracion interface {
+	Revisar(retaID, usuarioID, texto string) error
+}
+
+// CadenaModeracion aplica sus reglas en orden y se detiene en la primera que rechaza el mensaje
+type CadenaModeracion struct {
+	reglas []ReglaModeracion
+}
+
+func NewCadenaModeracion(reglas ...ReglaModeracion) *CadenaModeracion {
+	return &CadenaModeracion{
+		reglas: reglas,
+	}
+}
+
+func (c *CadenaModeracion) Revisar(retaID, usuarioID, texto string) error {
+	for _, regla := range c.reglas {
+		if err := regla.Revisar(retaID, usuarioID, texto); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReglaLongitud rechaza los mensajes con más caracteres de los que caben en la columna texto
+type ReglaLongitud struct {
+	maximo int
+}
+
+func NewReglaLongitud(maximo int) *ReglaLongitud {
+	return &ReglaLongitud{
+		maximo: maximo,
+	}
+}
+
+func (r *ReglaLongitud) Revisar(retaID, usuarioID, texto string) error {
+	if utf8.RuneCountInString(texto) > r.maximo {
+		return entities.ErrMensajeLargo
+	}
+	return nil
+}
+
+// PalabrasOfensivasPorDefecto es la lista base del filtro; un "*" final hace que la entrada cubra
+// todas las palabras que empiezan así (conjugaciones, plurales, diminutivos)
+var PalabrasOfensivasPorDefecto = []string{
+	"puto*", "puta*", "pendej*", "verga*", "ching*", "culer*", "cabron*", "mamon*",
+	"joto*", "marica*", "maricon*", "mierda*", "zorra*", "ojete*", "panocha*",
+	// Abreviaturas comunes en el chat
+	"ptm", "alv", "hdp", "ctm", "vrg", "pndj", "pndjo", "mmv", "mmvo",
+}
+
+// ReglaPalabras rechaza los mensajes con palabras de la lista. Texto y lista se normalizan igual:
+// minúsculas, sin acentos, números y símbolos usados como letras ("p3nd3j0"), letras repetidas
+// ("puuuto") y letras separadas por espacios o puntos ("p u t o")
+type ReglaPalabras struct {
+	exactas  map[string]bool
+	prefijos []string
+}
+
+func NewReglaPalabras(palabras []string) *ReglaPalabras {
+	regla := &ReglaPalabras{
+		exactas: make(map[string]bool),
+	}
+	for _, palabra := range palabras {
+		palabra = strings.TrimSpace(palabra)
+		if palabra == "" {
+			continue
+		}
+		if strings.HasSuffix(palabra, "*") {
+			regla.prefijos = append(regla.prefijos, normalizarPalabra(strings.TrimSuffix(palabra, "*")))
+		} else {
+			regla.exactas[normalizarPalabra(palabra)] = true
+		}
+	}
+	return regla
+}
+
+func (r *ReglaPalabras) Revisar(retaID, usuarioID, texto string) error {
+	for _, palabra := range palabrasDelTexto(texto) {
+		if r.exactas[palabra] {
+			return entities.ErrLenguajeOfensivo
+		}
+		for _, prefijo := range r.prefijos {
+			if strings.HasPrefix(palabra, prefijo) {
+				return entities.ErrLenguajeOfensivo
+			}
+		}
+	}
+	return nil
+}
+
+// letrasSustitutas son los caracteres que se usan en lugar de letras para evadir el filtro
+var letrasSustitutas = map[rune]rune{
+	'á': 'a', 'à': 'a', 'ä': 'a', 'é': 'e', 'è': 'e', 'ë': 'e', 'í': 'i', 'ì': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ö': 'o', 'ú': 'u', 'ù': 'u', 'ü': 'u', 'ñ': 'n',
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '@': 'a', '$': 's',
+}
+
+// normalizarPalabra pasa a minúsculas, sustituye acentos y números/símbolos por letras
+// y colapsa las letras repetidas seguidas
+func normalizarPalabra(palabra string) string {
+	var b strings.Builder
+	var anterior rune
+	for _, c := range strings.ToLower(palabra) {
+		if sustituta, ok := letrasSustitutas[c]; ok {
+			c = sustituta
+		}
+		if !unicode.IsLetter(c) || c == anterior {
+			continue
+		}
+		b.WriteRune(c)
+		anterior = c
+	}
+	return b.String()
+}
+
+// palabrasDelTexto separa el texto en palabras normalizadas y une las letras sueltas consecutivas
+func palabrasDelTexto(texto string) []string {
+	separadas := strings.FieldsFunc(strings.ToLower(texto), func(c rune) bool {
+		_, sustituta := letrasSustitutas[c]
+		return !sustituta && !unicode.IsLetter(c)
+	})
+
+	palabras := make([]string, 0, len(separadas))
+	sueltas := ""
+	for _, cruda := range separadas {
+		if utf8.RuneCountInString(cruda) == 1 {
+			sueltas += cruda
+			continue
+		}
+		if sueltas != "" {
+			palabras = append(palabras, normalizarPalabra(sueltas))
+			sueltas = ""
+		}
+		palabras = append(palabras, normalizarPalabra(cruda))
+	}
+	if sueltas != "" {
+		palabras = append(palabras, normalizarPalabra(sueltas))
+	}
+	return palabras
+}
+
+// patronEnlace detecta URLs, "www." y dominios con las terminaciones más usadas para spam
+var patronEnlace = regexp.MustCompile(`(?i)(https?://|www\.|\b[a-z0-9-]+\.(com|net|org|mx|io|ly|gg|me|co|app|link|xyz|info)\b)`)
+
+// ReglaEnlaces rechaza los mensajes que contienen enlaces
+type ReglaEnlaces struct{}
+
+func NewReglaEnlaces() *ReglaEnlaces {
+	return &ReglaEnlaces{}
+}
+
+func (r *ReglaEnlaces) Revisar(retaID, usuarioID, texto string) error {
+	if patronEnlace.MatchString(texto) {
+		return entities.ErrEnlaceNoPermitido
+	}
+	return nil
+}
+
+// ReglaRepetidos rechaza un mensaje igual (sin distinguir mayúsculas ni espacios) a otro que el mismo
+// usuario envió a la reta dentro de la ventana. Consulta la BD para funcionar con varias réplicas
+type ReglaRepetidos struct {
+	retaRepo repositories.IRetaRepository
+	ventana  time.Duration
+}
+
+func NewReglaRepetidos(retaRepo repositories.IRetaRepository, ventana time.Duration) *ReglaRepetidos {
+	return &ReglaRepetidos{
+		retaRepo: retaRepo,
+		ventana:  ventana,
+	}
+}
+
+func (r *ReglaRepetidos) Revisar(retaID, usuarioID, texto string) error {
+	recientes, err := r.retaRepo.ObtenerTextosRecientes(retaID, usuarioID, time.Now().Add(-r.ventana))
+	if err != nil {
+		return err
+	}
+
+	nuevo := strings.Join(strings.Fields(strings.ToLower(texto)), " ")
+	for _, reciente := range recientes {
+		if strings.Join(strings.Fields(strings.ToLower(reciente)), " ") == nuevo {
+			return entities.ErrMensajeRepetido
+		}
+	}
+	return nil
+}
+
+// verificarPuedeEscribir retorna ErrSilenciado o ErrExpulsadoDelChat si el usuario tiene una sanción vigente
+func verificarPuedeEscribir(retaRepo repositories.IRetaRepository, retaID, usuarioID string) error {
+	sancion, err := retaRepo.ObtenerSancion(retaID, usuarioID)
+	if err != nil {
+		return err
+	}
+	if sancion == nil || !sancion.Activa(time.Now()) {
+		return nil
+	}
+	if sancion.Tipo == entities.TipoExpulsion {
+		return entities.ErrExpulsadoDelChat
+	}
+	return entities.ErrSilenciado
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Errores de la cuenta del usuario autenticado; el token puede seguir vigente después de un baneo
+var (
+	ErrUsuarioNoEncontrado = errors.New("usuario no encontrado")
+	ErrCuentaSuspendida    = errors.New("tu cuenta está suspendida")
+	ErrRolInsuficiente     = errors.New("no tienes permisos para esta acción")
+)
+
+// CuentaUsuario es el rol y el baneo vigentes del usuario según la base de datos; el WebSocket la
+// consulta en lugar de confiar en los claims del token, que no cambian hasta el siguiente refresh
+type CuentaUsuario struct {
+	UsuarioID    string
+	Rol          string
+	BaneadoHasta *time.Time
+}
+
+// Suspendida indica si la cuenta tiene un baneo vigente
+func (c *CuentaUsuario) Suspendida(ahora time.Time) bool {
+	return c.BaneadoHasta != nil && c.BaneadoHasta.After(ahora)
+}
+
+// Eventos de auditoría de las acciones de moderación sobre retas y chats; se guardan en la
+// misma tabla auditoria que los eventos del módulo de usuarios
+const (
+	EventoEliminarMensaje = "eliminar_mensaje"
+	EventoCancelarReta    = "cancelar_reta"
+	EventoPurgarMensajes  = "purgar_mensajes"
+)
+
+// EventoModeracion es el registro de auditoría de una acción de moderación; el repositorio lo guarda
+// en la misma transacción que la acción, así que no hay acción sin registro ni registro sin acción
+type EventoModeracion struct {
+	Evento   string
+	ActorID  string
+	Objetivo string
+	Detalle  string
+}
+
+// DetallePurga describe para la auditoría cuántos mensajes se purgaron, de quién y por qué
+func DetallePurga(eliminados int, usuarioID, motivo string) string {
+	if usuarioID != "" {
+		return fmt.Sprintf("%d mensajes de %s: %s", eliminados, usuarioID, motivo)
+	}
+	return fmt.Sprintf("%d mensajes: %s", eliminados, motivo)
+}
+
+// ValidarCancelacionForzada verifica que un moderador pueda cancelar la reta: a diferencia del
+// creador, también puede cancelar una reta en juego
+func ValidarCancelacionForzada(estado string) error {
+	switch estado {
+	case EstadoCancelada:
+		return ErrRetaCancelada
+	case EstadoFinalizada:
+		return ErrRetaCerrada
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"games-football-api/src/core"
+	"time"
+)
+
+// Errores de las canchas y de la duración de las retas; ErrCanchaNoEncontrada está en core porque
+// también lo usa el módulo de zonas
+var (
+	ErrCanchaDeOtraZona     = errors.New("la cancha no pertenece a la zona de la reta")
+	ErrCanchaYUbicacion     = errors.New("envía cancha_id o ubicacion, no ambos: la ubicación de la reta es la de su cancha")
+	ErrCanchaFueraDeHorario = errors.New("la reta queda fuera del horario de la cancha")
+	ErrCanchaCapacidad      = errors.New("max_jugadores supera la capacidad de la cancha")
+	ErrCanchaOcupada        = errors.New("la cancha ya está apartada en ese horario")
+	ErrDuracionInvalida     = errors.New("duracion_minutos debe estar entre 30 y 300")
+)
+
+// Límites de la duración de una reta en minutos; 0 significa la duración por defecto
+const (
+	DuracionMinimaMinutos = 30
+	DuracionMaximaMinutos = 300
+)
+
+// NormalizarDuracion aplica la duración por defecto y valida el rango
+func NormalizarDuracion(minutos int) (int, error) {
+	if minutos == 0 {
+		return int(DuracionRetaPorDefecto / time.Minute), nil
+	}
+	if minutos < DuracionMinimaMinutos || minutos > DuracionMaximaMinutos {
+		return 0, ErrDuracionInvalida
+	}
+	return minutos, nil
+}
+
+// ValidarRetaEnCancha revisa que la reta quepa en la cancha: misma zona, cupo dentro de la capacidad y
+// horario completo entre la apertura y el cierre del mismo día
+func ValidarRetaEnCancha(cancha *core.Cancha, reta *Reta) error {
+	if reta.ZonaID != cancha.ZonaID {
+		return ErrCanchaDeOtraZona
+	}
+	if reta.MaxJugadores > cancha.Capacidad {
+		return fmt.Errorf("%w (%d)", ErrCanchaCapacidad, cancha.Capacidad)
+	}
+
+	apertura, cierre := cancha.Horario()
+	inicio := reta.FechaHora.Hour()*60 + reta.FechaHora.Minute()
+	fin := inicio + int(reta.Duracion()/time.Minute)
+	if inicio < apertura || fin > cierre {
+		return fmt.Errorf("%w (%s a %s)", ErrCanchaFueraDeHorario, cancha.HoraApertura, cancha.HoraCierre)
+	}
+	return nil
+}
+
+// UbicacionDeCancha retorna la ubicación que toma una reta que aparta la cancha (nil si no tiene)
+func UbicacionDeCancha(cancha *core.Cancha) *Ubicacion {
+	if cancha.Latitud == nil || cancha.Longitud == nil {
+		return nil
+	}
+	return &Ubicacion{Latitud: *cancha.Latitud, Longitud: *cancha.Longitud}
+}
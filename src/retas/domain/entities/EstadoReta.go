@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Estados del ciclo de vida de una reta
+const (
+	EstadoAbierta    = "abierta"
+	EstadoLlena      = "llena"
+	EstadoEnJuego    = "en_juego"
+	EstadoFinalizada = "finalizada"
+	EstadoCancelada  = "cancelada"
+)
+
+// DuracionRetaPorDefecto es el tiempo tras el cual una reta en juego se considera finalizada si no
+// indicó su duración
+const DuracionRetaPorDefecto = 2 * time.Hour
+
+// transicionesReta define los cambios de estado permitidos; finalizada y cancelada son terminales
+var transicionesReta = map[string][]string{
+	EstadoAbierta: {EstadoLlena, EstadoEnJuego, EstadoCancelada},
+	EstadoLlena:   {EstadoAbierta, EstadoEnJuego, EstadoCancelada},
+	EstadoEnJuego: {EstadoFinalizada},
+}
+
+// ValidarTransicion verifica que una reta pueda pasar del estado `desde` al estado `hacia`
+func ValidarTransicion(desde, hacia string) error {
+	if desde == hacia {
+		return nil
+	}
+	for _, permitido := range transicionesReta[desde] {
+		if permitido == hacia {
+			return nil
+		}
+	}
+
+	switch desde {
+	case EstadoCancelada:
+		return ErrRetaCancelada
+	case EstadoEnJuego, EstadoFinalizada:
+		return ErrRetaCerrada
+	default:
+		return fmt.Errorf("transición de estado inválida: %s → %s", desde, hacia)
+	}
+}
+
+// AceptaCambiosDeJugadores indica si en el estado dado los jugadores aún pueden unirse o salir
+func AceptaCambiosDeJugadores(estado string) bool {
+	return estado == EstadoAbierta || estado == EstadoLlena
+}
+
+// EstadoPorCupo calcula si una reta que acepta jugadores queda abierta o llena según su cupo
+func EstadoPorCupo(jugadoresActuales, maxJugadores int) string {
+	if jugadoresActuales >= maxJugadores {
+		return EstadoLlena
+	}
+	return EstadoAbierta
+}
+
+// EstadoPorHorario calcula el estado que le corresponde a una reta según su fecha_hora y su duración;
+// retorna el estado actual si no hay cambio pendiente
+func EstadoPorHorario(estado string, fechaHora time.Time, duracion time.Duration, ahora time.Time) string {
+	switch {
+	case AceptaCambiosDeJugadores(estado) && !ahora.Before(fechaHora):
+		return EstadoEnJuego
+	case estado == EstadoEnJuego && !ahora.Before(fechaHora.Add(duracion)):
+		return EstadoFinalizada
+	default:
+		return estado
+	}
+}
+
+// CambiarEstado aplica una transición validada a la reta
+func (r *Reta) CambiarEstado(nuevo string) error {
+	if err := ValidarTransicion(r.Estado, nuevo); err != nil {
+		return err
+	}
+	r.Estado = nuevo
+	return nil
+}
+
+// ValidarCambioDeJugadores retorna el error de dominio si en el estado dado no se puede unir, salir ni editar el cupo
+func ValidarCambioDeJugadores(estado string) error {
+	if AceptaCambiosDeJugadores(estado) {
+		return nil
+	}
+	if estado == EstadoCancelada {
+		return ErrRetaCancelada
+	}
+	return ErrRetaCerrada
+}
+
+// EstadoValido indica si el valor corresponde a uno de los estados del ciclo de vida
+func EstadoValido(estado string) bool {
+	switch estado {
+	case EstadoAbierta, EstadoLlena, EstadoEnJuego, EstadoFinalizada, EstadoCancelada:
+		return true
+	default:
+		return false
+	}
+}
@@ -5,6 +5,7 @@ type Jugador struct {
 	Nombre    string `json:"nombre"`
 	RetaID    string `json:"reta_id,omitempty"`
 	UsuarioID string `json:"usuario_id,omitempty"`
+	Posicion  int    `json:"posicion,omitempty"` // Solo para la lista de espera
 }
 
 func NewJugador(usuarioID, nombre string) *Jugador {
@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+// LecturaChat es el último mensaje que un usuario leyó en el chat de una reta (confirmación de lectura)
+type LecturaChat struct {
+	RetaID      string    `json:"reta_id"`
+	UsuarioID   string    `json:"usuario_id"`
+	UltimoLeido string    `json:"ultimo_leido"`
+	LeidoEn     time.Time `json:"leido_en"`
+
+	// NoLeidos son los mensajes de otros usuarios posteriores a UltimoLeido
+	NoLeidos int `json:"no_leidos"`
+}
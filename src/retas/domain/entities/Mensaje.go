@@ -2,6 +2,12 @@ package entities
 
 import "time"
 
+// VentanaEdicionMensaje es el tiempo durante el que el autor puede corregir un mensaje
+const VentanaEdicionMensaje = 15 * time.Minute
+
+// LongitudMaximaMensaje coincide con la columna texto VARCHAR(500) de mensajes_reta (en caracteres)
+const LongitudMaximaMensaje = 500
+
 // Mensaje representa un mensaje del chat en vivo de una reta
 type Mensaje struct {
 	ID            string    `json:"id"`
@@ -10,6 +16,13 @@ type Mensaje struct {
 	NombreUsuario string    `json:"nombre"`
 	Texto         string    `json:"texto"`
 	Timestamp     time.Time `json:"timestamp"`
+
+	// EditadoEn se llena cuando el autor corrige el texto
+	EditadoEn *time.Time `json:"editado_en,omitempty"`
+
+	// Un mensaje eliminado queda en el historial como marcador sin texto para no romper la paginación
+	Eliminado   bool       `json:"eliminado,omitempty"`
+	EliminadoEn *time.Time `json:"eliminado_en,omitempty"`
 }
 
 func NewMensaje(retaID, usuarioID, texto string) *Mensaje {
@@ -20,3 +33,8 @@ func NewMensaje(retaID, usuarioID, texto string) *Mensaje {
 		Timestamp: time.Now(),
 	}
 }
+
+// Editable indica si el mensaje sigue dentro de la ventana de edición
+func (m *Mensaje) Editable(ahora time.Time) bool {
+	return !m.Eliminado && ahora.Sub(m.Timestamp) <= VentanaEdicionMensaje
+}
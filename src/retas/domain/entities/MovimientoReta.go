@@ -0,0 +1,17 @@
+package entities
+
+// MovimientoReta resume el estado de jugadores de una reta después de que alguien se une o sale
+type MovimientoReta struct {
+	RetaID            string
+	ZonaID            string
+	Estado            string
+	JugadoresActuales int
+	ListaJugadores    []Jugador
+	ListaEspera       []Jugador
+
+	// EnEspera es true cuando el usuario quedó en la lista de espera en lugar de inscrito
+	EnEspera bool
+
+	// Promovidos son los jugadores que pasaron de la lista de espera a la reta en la misma transacción
+	Promovidos []Jugador
+}
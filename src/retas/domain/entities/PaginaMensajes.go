@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+// Límites de la paginación del historial de chat
+const (
+	LimiteMensajesPorDefecto = 50 // Mensajes por página si el cliente no envía limite
+	LimiteMensajesMaximo     = 100
+	MensajesEnSnapshot       = 10 // Últimos mensajes que se incluyen por reta en retas_zona
+)
+
+// ConsultaMensajes define la página del historial que se pide. Sin cursor se retornan los más recientes;
+// los cursores Antes/Despues son ids de mensaje y AntesDe/DespuesDe son fechas de creado_en
+type ConsultaMensajes struct {
+	Antes     string
+	Despues   string
+	AntesDe   *time.Time
+	DespuesDe *time.Time
+	Limite    int
+}
+
+// HaciaAdelante indica si la consulta pide mensajes más nuevos que el cursor
+func (c ConsultaMensajes) HaciaAdelante() bool {
+	return c.Despues != "" || c.DespuesDe != nil
+}
+
+// PaginaMensajes es una página del historial ordenada del más antiguo al más reciente
+type PaginaMensajes struct {
+	Mensajes []Mensaje `json:"mensajes"`
+
+	// HayMas indica si quedan mensajes en la dirección pedida (anteriores por defecto, posteriores con despues)
+	HayMas bool `json:"hay_mas"`
+}
@@ -0,0 +1,10 @@
+package entities
+
+// UsuarioPresente es un usuario con al menos una conexión abierta en una zona o en el chat de una reta
+type UsuarioPresente struct {
+	UsuarioID string `json:"usuario_id"`
+	Nombre    string `json:"nombre"`
+
+	// Conexiones cuenta las pestañas o dispositivos del mismo usuario; el usuario aparece una sola vez
+	Conexiones int `json:"conexiones,omitempty"`
+}
@@ -1,6 +1,9 @@
 package entities
 
-import "time"
+import (
+	"games-football-api/src/core"
+	"time"
+)
 
 type Reta struct {
 	ID                string    `json:"id"`
@@ -11,13 +14,48 @@ type Reta struct {
 	JugadoresActuales int       `json:"jugadores_actuales"`
 	CreadorID         string    `json:"creador_id"`
 	CreadorNombre     string    `json:"creador_nombre"`
+	Estado            string    `json:"estado"`
 	CreatedAt         time.Time `json:"created_at"`
 	HistorialChat     []Mensaje `json:"historial_chat,omitempty"`
+
+	// Ubicacion es la cancha; nil significa que solo se conoce la zona
+	Ubicacion *Ubicacion `json:"ubicacion,omitempty"`
+
+	// CanchaID es la cancha apartada (vacío si no se indicó) y DuracionMinutos el tiempo que se aparta
+	CanchaID        string `json:"cancha_id,omitempty"`
+	DuracionMinutos int    `json:"duracion_minutos"`
+
+	// SerieID es la serie recurrente que generó la reta (vacío si se creó a mano), Ocurrencia el día
+	// (YYYY-MM-DD) que le corresponde en la serie y OcurrenciaEditada indica que se editó por separado,
+	// así los cambios a la serie completa ya no la tocan
+	SerieID           string `json:"serie_id,omitempty"`
+	Ocurrencia        string `json:"ocurrencia,omitempty"`
+	OcurrenciaEditada bool   `json:"ocurrencia_editada,omitempty"`
+}
+
+// Duracion retorna cuánto dura la reta; las retas sin duración usan DuracionRetaPorDefecto
+func (r *Reta) Duracion() time.Duration {
+	if r.DuracionMinutos <= 0 {
+		return DuracionRetaPorDefecto
+	}
+	return time.Duration(r.DuracionMinutos) * time.Minute
+}
+
+// ValidarFechaFutura rechaza una fecha_hora que ya pasó
+func ValidarFechaFutura(fechaHora time.Time) error {
+	if !fechaHora.After(time.Now()) {
+		return ErrFechaPasada
+	}
+	return nil
 }
 
 func NewReta(zonaID, titulo, fechaHoraStr string, maxJugadores int, creadorID, creadorNombre string) (*Reta, error) {
-	fechaHora, err := time.Parse("2006-01-02 15:04:05", fechaHoraStr)
+	// fecha_hora es la hora local de la zona, no UTC
+	fechaHora, err := core.FechaHoraLocal("2006-01-02 15:04:05", fechaHoraStr)
 	if err != nil {
+		return nil, ErrFormatoFecha
+	}
+	if err := ValidarFechaFutura(fechaHora); err != nil {
 		return nil, err
 	}
 
@@ -29,6 +67,26 @@ func NewReta(zonaID, titulo, fechaHoraStr string, maxJugadores int, creadorID, c
 		JugadoresActuales: 0,
 		CreadorID:         creadorID,
 		CreadorNombre:     creadorNombre,
+		Estado:            EstadoAbierta,
 		CreatedAt:         time.Now(),
 	}, nil
 }
+
+// FiltroRetas define qué retas se listan de una zona
+type FiltroRetas struct {
+	// IncluirCerradas agrega las retas en juego, finalizadas y canceladas; por defecto solo se listan abiertas y llenas
+	IncluirCerradas bool
+
+	// Estado limita el listado a un estado específico (ignora IncluirCerradas)
+	Estado string
+
+	// Desde y Hasta limitan el rango de fecha_hora (Hasta es exclusivo); nil significa sin límite
+	Desde *time.Time
+	Hasta *time.Time
+
+	// ConLugares deja solo las retas que aún tienen cupo disponible
+	ConLugares bool
+
+	// UsuarioID, si se envía, llena NoLeidos de cada reta con los mensajes que ese usuario no ha leído
+	UsuarioID string
+}
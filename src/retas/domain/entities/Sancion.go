@@ -0,0 +1,31 @@
+package entities
+
+import "time"
+
+// Tipos de sanción que el creador de una reta puede aplicar en su chat
+const (
+	// TipoSilencio impide escribir en el chat hasta que vence
+	TipoSilencio = "silencio"
+
+	// TipoExpulsion impide escribir en el chat hasta que el creador la quite
+	TipoExpulsion = "expulsion"
+)
+
+// DuracionSilencioPorDefecto se usa cuando no se indican minutos al silenciar
+const DuracionSilencioPorDefecto = 15 * time.Minute
+
+// Sancion restringe a un usuario en el chat de una reta; hay a lo más una activa por usuario y reta
+type Sancion struct {
+	RetaID    string     `json:"reta_id"`
+	UsuarioID string     `json:"usuario_id"`
+	Tipo      string     `json:"tipo"`
+	Motivo    string     `json:"motivo,omitempty"`
+	Hasta     *time.Time `json:"hasta,omitempty"` // nil en las expulsiones
+	CreadoPor string     `json:"creado_por"`
+	CreadoEn  time.Time  `json:"creado_en"`
+}
+
+// Activa indica si la sanción sigue vigente en el momento dado
+func (s *Sancion) Activa(ahora time.Time) bool {
+	return s.Hasta == nil || ahora.Before(*s.Hasta)
+}
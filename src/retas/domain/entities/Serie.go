@@ -0,0 +1,281 @@
+package entities
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"strings"
+	"time"
+)
+
+// Errores de las series de retas recurrentes
+var (
+	ErrSerieNoEncontrada   = errors.New("serie no encontrada")
+	ErrSerieCancelada      = errors.New("la serie está cancelada")
+	ErrSerieInactiva       = errors.New("la serie ya terminó o está cancelada")
+	ErrSoloCreadorSerie    = errors.New("solo el creador puede modificar la serie")
+	ErrSerieDias           = errors.New("dias_semana debe tener al menos uno de: lunes, martes, miercoles, jueves, viernes, sabado, domingo")
+	ErrSerieHora           = errors.New("hora debe tener el formato HH:MM")
+	ErrSerieFechaInicio    = errors.New("fecha_inicio debe tener el formato YYYY-MM-DD y no puede estar en el pasado")
+	ErrSerieFin            = errors.New("envía solo uno de: hasta, ocurrencias")
+	ErrSerieHasta          = errors.New("hasta debe tener el formato YYYY-MM-DD y no puede ser anterior a fecha_inicio")
+	ErrSerieOcurrencias    = errors.New("ocurrencias debe estar entre 1 y 104")
+	ErrSerieSinOcurrencias = errors.New("la regla no tiene ninguna ocurrencia: revisa dias_semana, fecha_inicio y hasta")
+	ErrSerieSinCambios     = errors.New("envía al menos uno de: titulo, max_jugadores, duracion_minutos, dias_semana, hora, hasta, ocurrencias")
+	ErrYaSuscrito          = errors.New("ya estás suscrito a esta serie")
+	ErrCreadorSuscrito     = errors.New("el creador ya juega en todas las retas de la serie")
+	ErrSinSuscripcion      = errors.New("no estás suscrito a esta serie")
+
+	// ErrOcurrenciaExistente indica que otro proceso ya creó la reta de ese día de la serie
+	ErrOcurrenciaExistente = errors.New("la reta de ese día de la serie ya existe")
+)
+
+// Estados de una serie
+const (
+	SerieActiva    = "activa"
+	SerieTerminada = "terminada" // Ya se crearon todas sus ocurrencias
+	SerieCancelada = "cancelada"
+)
+
+// MaxOcurrenciasSerie limita las series por número de ocurrencias (dos años de un día por semana)
+const MaxOcurrenciasSerie = 104
+
+// diasSemana son los nombres aceptados en dias_semana, en el orden en que se guardan
+var diasSemana = []struct {
+	nombre string
+	dia    time.Weekday
+}{
+	{"lunes", time.Monday},
+	{"martes", time.Tuesday},
+	{"miercoles", time.Wednesday},
+	{"jueves", time.Thursday},
+	{"viernes", time.Friday},
+	{"sabado", time.Saturday},
+	{"domingo", time.Sunday},
+}
+
+// ReglaRecurrencia dice qué días y a qué hora se juega una serie y cuándo termina: en la fecha
+// Hasta, después de Ocurrencias retas o nunca (si ambos van vacíos)
+type ReglaRecurrencia struct {
+	DiasSemana  []string `json:"dias_semana"`
+	Hora        string   `json:"hora"`
+	FechaInicio string   `json:"fecha_inicio"`
+	Hasta       string   `json:"hasta,omitempty"`
+	Ocurrencias int      `json:"ocurrencias,omitempty"`
+}
+
+// Normalizar valida la regla y deja los días sin acentos, sin repetir y de lunes a domingo
+func (r *ReglaRecurrencia) Normalizar() error {
+	elegidos := make(map[string]bool)
+	for _, dia := range r.DiasSemana {
+		nombre := strings.ToLower(strings.TrimSpace(dia))
+		nombre = strings.NewReplacer("é", "e", "á", "a").Replace(nombre)
+		elegidos[nombre] = true
+	}
+
+	dias := make([]string, 0, len(elegidos))
+	for _, d := range diasSemana {
+		if elegidos[d.nombre] {
+			dias = append(dias, d.nombre)
+			delete(elegidos, d.nombre)
+		}
+	}
+	if len(dias) == 0 || len(elegidos) > 0 {
+		return ErrSerieDias
+	}
+	r.DiasSemana = dias
+
+	hora, err := time.Parse("15:04", r.Hora)
+	if err != nil {
+		return ErrSerieHora
+	}
+	r.Hora = hora.Format("15:04")
+
+	inicio, err := core.FechaHoraLocal("2006-01-02", r.FechaInicio)
+	if err != nil {
+		return ErrSerieFechaInicio
+	}
+
+	if r.Hasta != "" && r.Ocurrencias != 0 {
+		return ErrSerieFin
+	}
+	if r.Hasta != "" {
+		hasta, err := core.FechaHoraLocal("2006-01-02", r.Hasta)
+		if err != nil || hasta.Before(inicio) {
+			return ErrSerieHasta
+		}
+	}
+	if r.Ocurrencias < 0 || r.Ocurrencias > MaxOcurrenciasSerie {
+		return ErrSerieOcurrencias
+	}
+	return nil
+}
+
+// Fechas retorna en orden la fecha y hora (en la zona horaria de la API) de cada ocurrencia de la regla hasta limite (inclusive).
+// Las ocurrencias se cuentan desde fecha_inicio, así que Ocurrencias incluye las que ya pasaron
+func (r ReglaRecurrencia) Fechas(limite time.Time) []time.Time {
+	inicio, _ := core.FechaHoraLocal("2006-01-02", r.FechaInicio)
+	hora, _ := time.Parse("15:04", r.Hora)
+
+	dias := make(map[time.Weekday]bool)
+	for _, d := range diasSemana {
+		for _, nombre := range r.DiasSemana {
+			if nombre == d.nombre {
+				dias[d.dia] = true
+			}
+		}
+	}
+
+	var hasta time.Time
+	if r.Hasta != "" {
+		hasta, _ = core.FechaHoraLocal("2006-01-02", r.Hasta)
+	}
+
+	fechas := make([]time.Time, 0)
+	for dia := inicio; ; dia = dia.AddDate(0, 0, 1) {
+		if !hasta.IsZero() && dia.After(hasta) {
+			break
+		}
+		if r.Ocurrencias > 0 && len(fechas) >= r.Ocurrencias {
+			break
+		}
+
+		fechaHora := time.Date(dia.Year(), dia.Month(), dia.Day(), hora.Hour(), hora.Minute(), 0, 0, core.ZonaHoraria)
+		if fechaHora.After(limite) {
+			break
+		}
+		if dias[dia.Weekday()] {
+			fechas = append(fechas, fechaHora)
+		}
+	}
+	return fechas
+}
+
+// Incluye indica si el día (YYYY-MM-DD) es una ocurrencia de la regla
+func (r ReglaRecurrencia) Incluye(dia string) bool {
+	fecha, err := core.FechaHoraLocal("2006-01-02", dia)
+	if err != nil {
+		return false
+	}
+
+	fechas := r.Fechas(fecha.AddDate(0, 0, 1))
+	return len(fechas) > 0 && fechas[len(fechas)-1].Format("2006-01-02") == dia
+}
+
+// Agotada indica si la regla tiene fin y ya no hay ocurrencias después de limite
+func (r ReglaRecurrencia) Agotada(limite time.Time) bool {
+	var fin time.Time
+	switch {
+	case r.Hasta != "":
+		fin, _ = core.FechaHoraLocal("2006-01-02", r.Hasta)
+		fin = fin.AddDate(0, 0, 1)
+	case r.Ocurrencias > 0:
+		// Cada semana tiene al menos una ocurrencia, así que en Ocurrencias semanas caben todas
+		fin, _ = core.FechaHoraLocal("2006-01-02", r.FechaInicio)
+		fin = fin.AddDate(0, 0, 7*r.Ocurrencias)
+	default:
+		return false
+	}
+	return len(r.Fechas(limite)) == len(r.Fechas(fin))
+}
+
+// Serie es una reta que se repite cada semana; el scheduler crea una Reta por cada ocurrencia
+// con anticipación y los suscriptores quedan inscritos en ellas automáticamente
+type Serie struct {
+	ID     string `json:"id"`
+	ZonaID string `json:"zona_id"`
+	Titulo string `json:"titulo"`
+	ReglaRecurrencia
+	MaxJugadores    int        `json:"max_jugadores"`
+	DuracionMinutos int        `json:"duracion_minutos"`
+	CanchaID        string     `json:"cancha_id,omitempty"`
+	Ubicacion       *Ubicacion `json:"ubicacion,omitempty"`
+	CreadorID       string     `json:"creador_id"`
+	CreadorNombre   string     `json:"creador_nombre"`
+	Estado          string     `json:"estado"`
+	CreadoEn        time.Time  `json:"creado_en"`
+
+	// Suscriptores son los jugadores que se inscriben solos en cada ocurrencia nueva (además del creador)
+	Suscriptores []Suscriptor `json:"suscriptores"`
+}
+
+// Suscriptor es un jugador habitual de una serie
+type Suscriptor struct {
+	UsuarioID  string    `json:"usuario_id"`
+	Nombre     string    `json:"nombre"`
+	SuscritoEn time.Time `json:"suscrito_en"`
+}
+
+// NuevaOcurrencia arma la reta de la serie para la fecha y hora dadas
+func (s *Serie) NuevaOcurrencia(fechaHora time.Time) *Reta {
+	return &Reta{
+		ZonaID:          s.ZonaID,
+		Titulo:          s.Titulo,
+		FechaHora:       fechaHora,
+		MaxJugadores:    s.MaxJugadores,
+		CreadorID:       s.CreadorID,
+		CreadorNombre:   s.CreadorNombre,
+		Estado:          EstadoAbierta,
+		CreatedAt:       time.Now(),
+		Ubicacion:       s.Ubicacion,
+		CanchaID:        s.CanchaID,
+		DuracionMinutos: s.DuracionMinutos,
+		SerieID:         s.ID,
+		Ocurrencia:      fechaHora.Format("2006-01-02"),
+	}
+}
+
+// FechaHoraDe retorna la fecha y hora que la regla le da al día (YYYY-MM-DD) de una ocurrencia
+func (s *Serie) FechaHoraDe(dia string) time.Time {
+	fecha, _ := core.FechaHoraLocal("2006-01-02", dia)
+	hora, _ := time.Parse("15:04", s.Hora)
+	return time.Date(fecha.Year(), fecha.Month(), fecha.Day(), hora.Hour(), hora.Minute(), 0, 0, core.ZonaHoraria)
+}
+
+// CambiosSerie son los campos que el creador envía al editar la serie completa; los vacíos se ignoran.
+// Hasta y Ocurrencias reemplazan el fin de la serie: "" y 0 la dejan sin fin
+type CambiosSerie struct {
+	Titulo          string
+	MaxJugadores    int
+	DuracionMinutos int
+	DiasSemana      []string
+	Hora            string
+	Hasta           *string
+	Ocurrencias     *int
+}
+
+// Vacio indica que no se envió ningún cambio
+func (c CambiosSerie) Vacio() bool {
+	return c.Titulo == "" && c.MaxJugadores == 0 && c.DuracionMinutos == 0 && len(c.DiasSemana) == 0 &&
+		c.Hora == "" && c.Hasta == nil && c.Ocurrencias == nil
+}
+
+// OcurrenciaActualizada es una reta de la serie que se creó o cambió, con sus listas de jugadores
+type OcurrenciaActualizada struct {
+	Reta       *Reta
+	Movimiento *MovimientoReta
+}
+
+// ConflictoOcurrencia es una ocurrencia que no se pudo crear o actualizar con la serie
+// (ej. su horario choca con otra reta en la cancha); RetaID va vacío si la reta no llegó a crearse
+type ConflictoOcurrencia struct {
+	RetaID    string `json:"reta_id,omitempty"`
+	FechaHora string `json:"fecha_hora"`
+	Mensaje   string `json:"mensaje"`
+}
+
+// ResultadoSerie resume qué pasó con las ocurrencias de una serie al materializarla, editarla o cancelarla
+type ResultadoSerie struct {
+	Creadas      []OcurrenciaActualizada
+	Actualizadas []OcurrenciaActualizada
+	Canceladas   []Reta
+	Conflictos   []ConflictoOcurrencia
+}
+
+// NuevoConflicto arma el conflicto de una ocurrencia a partir del error
+func NuevoConflicto(reta *Reta, err error) ConflictoOcurrencia {
+	return ConflictoOcurrencia{
+		RetaID:    reta.ID,
+		FechaHora: reta.FechaHora.Format("2006-01-02 15:04:05"),
+		Mensaje:   err.Error(),
+	}
+}
@@ -0,0 +1,157 @@
+package entities
+
+import (
+	"errors"
+	"math"
+)
+
+// Errores de las ubicaciones y la búsqueda de retas cercanas
+var (
+	ErrUbicacionInvalida    = errors.New("ubicación inválida: latitud entre -90 y 90, longitud entre -180 y 180")
+	ErrUbicacionFueraDeZona = errors.New("la ubicación de la cancha está fuera del radio de la zona")
+	ErrRadioBusqueda        = errors.New("radio_km debe estar entre 0.1 y 50")
+)
+
+// RadioTierraKm es el radio medio de la Tierra que usa la fórmula del haversine
+const RadioTierraKm = 6371.0
+
+// Límites de la búsqueda de retas cercanas
+const (
+	RadioBusquedaPorDefectoKm = 10
+	RadioBusquedaMinimoKm     = 0.1
+	RadioBusquedaMaximoKm     = 50
+	LimiteCercanasPorDefecto  = 20
+	LimiteCercanasMaximo      = 50
+)
+
+// Ubicacion es un punto geográfico en grados decimales
+type Ubicacion struct {
+	Latitud  float64 `json:"latitud"`
+	Longitud float64 `json:"longitud"`
+}
+
+// Validar revisa que las coordenadas estén en rango
+func (u Ubicacion) Validar() error {
+	if math.IsNaN(u.Latitud) || math.IsNaN(u.Longitud) ||
+		u.Latitud < -90 || u.Latitud > 90 || u.Longitud < -180 || u.Longitud > 180 {
+		return ErrUbicacionInvalida
+	}
+	return nil
+}
+
+// DistanciaKm calcula la distancia sobre la superficie terrestre entre dos puntos (haversine); es la
+// misma fórmula que usa la consulta de retas cercanas
+func DistanciaKm(a, b Ubicacion) float64 {
+	lat1 := a.Latitud * math.Pi / 180
+	lat2 := b.Latitud * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (b.Longitud - a.Longitud) * math.Pi / 180
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * RadioTierraKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Zona son los datos de una zona que necesita el módulo de retas: su centro y su radio
+type Zona struct {
+	ID      string
+	Nombre  string
+	Centro  Ubicacion
+	RadioKm float64
+}
+
+// ValidarCancha revisa que la ubicación de una cancha sea válida y esté dentro del radio de la zona
+func (z *Zona) ValidarCancha(ubicacion Ubicacion) error {
+	if err := ubicacion.Validar(); err != nil {
+		return err
+	}
+	if DistanciaKm(z.Centro, ubicacion) > z.RadioKm {
+		return ErrUbicacionFueraDeZona
+	}
+	return nil
+}
+
+// BusquedaCercana son los parámetros de "retas cerca de mí"; la búsqueda ignora los límites de las zonas
+type BusquedaCercana struct {
+	Posicion   Ubicacion
+	RadioKm    float64
+	Limite     int
+	ConLugares bool
+}
+
+// Normalizar valida la posición y aplica los valores por defecto y los máximos de radio y límite
+func (b *BusquedaCercana) Normalizar() error {
+	if err := b.Posicion.Validar(); err != nil {
+		return err
+	}
+	if b.RadioKm == 0 {
+		b.RadioKm = RadioBusquedaPorDefectoKm
+	}
+	if b.RadioKm < RadioBusquedaMinimoKm || b.RadioKm > RadioBusquedaMaximoKm {
+		return ErrRadioBusqueda
+	}
+	if b.Limite <= 0 {
+		b.Limite = LimiteCercanasPorDefecto
+	}
+	if b.Limite > LimiteCercanasMaximo {
+		b.Limite = LimiteCercanasMaximo
+	}
+	return nil
+}
+
+// Caja retorna el rectángulo de coordenadas que contiene el círculo de búsqueda; la consulta lo usa
+// como prefiltro barato antes de calcular la distancia exacta
+func (b BusquedaCercana) Caja() (latMin, latMax, lngMin, lngMax float64) {
+	deltaLat := b.RadioKm / RadioTierraKm * 180 / math.Pi
+	latMin = math.Max(-90, b.Posicion.Latitud-deltaLat)
+	latMax = math.Min(90, b.Posicion.Latitud+deltaLat)
+
+	// Cerca de los polos el círculo abarca todas las longitudes
+	cosLat := math.Cos(b.Posicion.Latitud * math.Pi / 180)
+	if cosLat < 1e-6 {
+		return latMin, latMax, -180, 180
+	}
+	deltaLng := deltaLat / cosLat
+	lngMin = math.Max(-180, b.Posicion.Longitud-deltaLng)
+	lngMax = math.Min(180, b.Posicion.Longitud+deltaLng)
+	return latMin, latMax, lngMin, lngMax
+}
+
+// RetaCercana es una reta próxima en el resultado de la búsqueda por distancia
+type RetaCercana struct {
+	ID                string    `json:"id"`
+	ZonaID            string    `json:"zona_id"`
+	ZonaNombre        string    `json:"zona_nombre"`
+	Titulo            string    `json:"titulo"`
+	FechaHora         string    `json:"fecha_hora"`
+	MaxJugadores      int       `json:"max_jugadores"`
+	JugadoresActuales int       `json:"jugadores_actuales"`
+	Estado            string    `json:"estado"`
+	DuracionMinutos   int       `json:"duracion_minutos"`
+	CanchaID          string    `json:"cancha_id,omitempty"`
+	CanchaNombre      string    `json:"cancha_nombre,omitempty"`
+	Ubicacion         Ubicacion `json:"ubicacion"`
+
+	// UbicacionAproximada indica que la reta no tiene cancha y se ubicó en el centro de su zona
+	UbicacionAproximada bool    `json:"ubicacion_aproximada"`
+	DistanciaKm         float64 `json:"distancia_km"`
+}
+
+// NewRetaCercana arma el resultado a partir de los datos de la consulta
+func NewRetaCercana(reta *Reta, zonaNombre, canchaNombre string, ubicacion Ubicacion, aproximada bool, distanciaKm float64) RetaCercana {
+	return RetaCercana{
+		ID:                  reta.ID,
+		ZonaID:              reta.ZonaID,
+		ZonaNombre:          zonaNombre,
+		Titulo:              reta.Titulo,
+		FechaHora:           reta.FechaHora.Format("2006-01-02 15:04:05"),
+		MaxJugadores:        reta.MaxJugadores,
+		JugadoresActuales:   reta.JugadoresActuales,
+		Estado:              reta.Estado,
+		DuracionMinutos:     reta.DuracionMinutos,
+		CanchaID:            reta.CanchaID,
+		CanchaNombre:        canchaNombre,
+		Ubicacion:           ubicacion,
+		UbicacionAproximada: aproximada,
+		DistanciaKm:         math.Round(distanciaKm*100) / 100,
+	}
+}
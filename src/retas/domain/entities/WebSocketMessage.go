@@ -2,42 +2,165 @@ package entities
 
 // WebSocketMessage representa el mensaje que se recibe del cliente
 type WebSocketMessage struct {
-	Accion    string `json:"accion"` // "unirse", "crear" o "enviar_mensaje"
+	Accion string `json:"accion"` // "unirse", "salir", "crear", "editar_reta", "cancelar_reta", "enviar_mensaje", "suscribir_chat", "desuscribir_chat", "cargar_mas", "reanudar", "presencia", "escribiendo", "marcar_leido", "editar_mensaje", "eliminar_mensaje", "silenciar", "expulsar", "quitar_sancion" o "cercanas"
+
+	// La identidad sale del token del handshake; si se envía usuario_id/creador_id debe coincidir con él
 	UsuarioID string `json:"usuario_id,omitempty"`
 	Nombre    string `json:"nombre,omitempty"`
 	RetaID    string `json:"reta_id,omitempty"`
 	ZonaID    string `json:"zona_id"`
 
-	// Campos específicos para "crear"
+	// Filtro del snapshot "retas_zona": incluir retas en juego, finalizadas y canceladas
+	IncluirCerradas bool `json:"incluir_cerradas,omitempty"`
+
+	// Campos específicos para "crear" (y opcionales para "editar_reta")
 	Titulo        string `json:"titulo,omitempty"`
 	FechaHora     string `json:"fecha_hora,omitempty"`
 	MaxJugadores  int    `json:"max_jugadores,omitempty"`
 	CreadorID     string `json:"creador_id,omitempty"`
 	CreadorNombre string `json:"creador_nombre,omitempty"`
 
-	// Campos específicos para "enviar_mensaje"
+	// Cancha en "crear" y "editar_reta"; posición del usuario en "cercanas"
+	Ubicacion *Ubicacion `json:"ubicacion,omitempty"`
+
+	// Cancha registrada que se aparta en "crear" y minutos que dura la reta ("crear" y "editar_reta")
+	CanchaID        string `json:"cancha_id,omitempty"`
+	DuracionMinutos int    `json:"duracion_minutos,omitempty"`
+
+	// Campos específicos para "cercanas": radio de búsqueda en km y solo retas con cupo
+	RadioKm    float64 `json:"radio_km,omitempty"`
+	ConLugares bool    `json:"con_lugares,omitempty"`
+
+	// Campos específicos para "enviar_mensaje" (y nuevo texto para "editar_mensaje")
 	Texto string `json:"texto,omitempty"`
+
+	// Campos específicos para "cargar_mas": cursor (id de mensaje) y tamaño de página
+	Antes   string `json:"antes,omitempty"`
+	Despues string `json:"despues,omitempty"`
+	Limite  int    `json:"limite,omitempty"`
+
+	// Mensaje del chat de reta_id: el último leído en "marcar_leido" o el que se edita o elimina
+	MensajeID string `json:"mensaje_id,omitempty"`
+
+	// Campos específicos para "silenciar", "expulsar" y "quitar_sancion" (solo el creador de la reta)
+	SancionadoID string `json:"sancionado_id,omitempty"`
+	Minutos      int    `json:"minutos,omitempty"`
+	Motivo       string `json:"motivo,omitempty"`
+
+	// Campos específicos para "reanudar": epoch recibido en "conectado" y último seq visto por topic
+	Epoch      string            `json:"epoch,omitempty"`
+	Secuencias map[string]uint64 `json:"secuencias,omitempty"`
 }
 
 // BroadcastMessage representa los mensajes de broadcast
 type BroadcastMessage struct {
-	Status            string     `json:"status"`
+	// Seq y Topic los agrega el hub a cada broadcast; en los snapshots indican desde dónde continuar
+	Seq   uint64 `json:"seq,omitempty"`
+	Topic string `json:"topic,omitempty"`
+	Epoch string `json:"epoch,omitempty"`
+
+	Status string `json:"status"`
+
+	// En los errores de límite: "limite_excedido" o "desconectado_por_abuso", la acción rechazada
+	// y los segundos que conviene esperar
+	Codigo       string `json:"codigo,omitempty"`
+	Accion       string `json:"accion,omitempty"`
+	ReintentarEn int    `json:"reintentar_en,omitempty"`
+
 	RetaID            string     `json:"reta_id,omitempty"`
+	Estado            string     `json:"estado,omitempty"`
 	JugadoresActuales int        `json:"jugadores_actuales,omitempty"`
 	ListaJugadores    []Jugador  `json:"lista_jugadores,omitempty"`
+	ListaEspera       []Jugador  `json:"lista_espera,omitempty"`
 	Mensaje           string     `json:"mensaje,omitempty"`
 	Reta              *RetaInfo  `json:"reta,omitempty"`
 	Retas             []RetaInfo `json:"retas,omitempty"`
 	MensajeChat       *Mensaje   `json:"mensaje_chat,omitempty"`
+	Mensajes          []Mensaje  `json:"mensajes,omitempty"`
+	HayMas            bool       `json:"hay_mas,omitempty"`
+
+	// Resultado de "cercanas", ordenado por distancia
+	Cercanas []RetaCercana `json:"cercanas,omitempty"`
+
+	// Presencia: usuario que se conectó o desconectó, o la lista de usuarios en línea
+	Usuario  *UsuarioPresente  `json:"usuario,omitempty"`
+	Usuarios []UsuarioPresente `json:"usuarios,omitempty"`
+
+	// Confirmaciones de lectura: "mensajes_leidos" (al chat, con el lector en Usuario) y "no_leidos"
+	// (a las conexiones del lector)
+	UltimoLeido string       `json:"ultimo_leido,omitempty"`
+	Lectura     *LecturaChat `json:"lectura,omitempty"`
+
+	// Sanción aplicada o retirada en el chat de la reta
+	Sancion *Sancion `json:"sancion,omitempty"`
+
+	// Purga de moderación: cuántos mensajes quedaron como marcadores y de qué usuario (vacío = todos)
+	Purgados  int    `json:"purgados,omitempty"`
+	UsuarioID string `json:"usuario_id,omitempty"`
 }
 
 // RetaInfo para el mensaje de nueva reta
 type RetaInfo struct {
-	ID                string    `json:"id"`
-	Titulo            string    `json:"titulo"`
-	FechaHora         string    `json:"fecha_hora"`
-	MaxJugadores      int       `json:"max_jugadores"`
-	JugadoresActuales int       `json:"jugadores_actuales"`
-	ListaJugadores    []Jugador `json:"lista_jugadores"`
-	HistorialChat     []Mensaje `json:"historial_chat"`
+	ID                string     `json:"id"`
+	ZonaID            string     `json:"zona_id,omitempty"`
+	Titulo            string     `json:"titulo"`
+	FechaHora         string     `json:"fecha_hora"`
+	MaxJugadores      int        `json:"max_jugadores"`
+	JugadoresActuales int        `json:"jugadores_actuales"`
+	CreadorID         string     `json:"creador_id"`
+	Estado            string     `json:"estado"`
+	ListaJugadores    []Jugador  `json:"lista_jugadores"`
+	ListaEspera       []Jugador  `json:"lista_espera"`
+	HistorialChat     []Mensaje  `json:"historial_chat"` // Solo los últimos mensajes; el resto se pagina
+	TotalMensajes     int        `json:"total_mensajes"`
+	NoLeidos          int        `json:"no_leidos"` // Solo en el snapshot de cada usuario
+	Ubicacion         *Ubicacion `json:"ubicacion,omitempty"`
+	CanchaID          string     `json:"cancha_id,omitempty"`
+	DuracionMinutos   int        `json:"duracion_minutos"`
+	SerieID           string     `json:"serie_id,omitempty"` // Serie recurrente que generó la reta
+}
+
+// NewRetaInfo arma el RetaInfo que se envía a los clientes a partir de la entidad
+func NewRetaInfo(reta *Reta, listaJugadores, listaEspera []Jugador) *RetaInfo {
+	return &RetaInfo{
+		ID:                reta.ID,
+		ZonaID:            reta.ZonaID,
+		Titulo:            reta.Titulo,
+		FechaHora:         reta.FechaHora.Format("2006-01-02 15:04:05"),
+		MaxJugadores:      reta.MaxJugadores,
+		JugadoresActuales: reta.JugadoresActuales,
+		CreadorID:         reta.CreadorID,
+		Estado:            reta.Estado,
+		ListaJugadores:    listaJugadores,
+		ListaEspera:       listaEspera,
+		Ubicacion:         reta.Ubicacion,
+		CanchaID:          reta.CanchaID,
+		DuracionMinutos:   reta.DuracionMinutos,
+		SerieID:           reta.SerieID,
+	}
+}
+
+// Participa indica si el usuario creó la reta, juega en ella o está en su lista de espera
+func (r *RetaInfo) Participa(usuarioID string) bool {
+	if r.CreadorID == usuarioID {
+		return true
+	}
+	for _, jugador := range r.ListaJugadores {
+		if jugador.UsuarioID == usuarioID {
+			return true
+		}
+	}
+	for _, jugador := range r.ListaEspera {
+		if jugador.UsuarioID == usuarioID {
+			return true
+		}
+	}
+	return false
+}
+
+// OcultarChat quita los últimos mensajes y los contadores del chat para quien no puede leerlo
+func (r *RetaInfo) OcultarChat() {
+	r.HistorialChat = []Mensaje{}
+	r.TotalMensajes = 0
+	r.NoLeidos = 0
 }
@@ -0,0 +1,34 @@
+package entities
+
+import "errors"
+
+// Errores de dominio que los controllers REST traducen a códigos HTTP específicos
+var (
+	ErrRetaNoEncontrada    = errors.New("reta no encontrada")
+	ErrZonaNoEncontrada    = errors.New("zona no encontrada")
+	ErrSoloCreador         = errors.New("solo el creador puede modificar la reta")
+	ErrRetaCancelada       = errors.New("la reta está cancelada")
+	ErrRetaCerrada         = errors.New("la reta ya comenzó o finalizó")
+	ErrFormatoFecha        = errors.New("fecha_hora debe tener el formato YYYY-MM-DD HH:MM:SS")
+	ErrFechaPasada         = errors.New("fecha_hora no puede estar en el pasado")
+	ErrMensajeNoEncontrado = errors.New("mensaje no encontrado en esta reta")
+	ErrCursorInvalido      = errors.New("envía solo uno de: antes, despues, antes_de, despues_de")
+	ErrSoloAutor           = errors.New("solo el autor puede editar el mensaje")
+	ErrSinPermisoEliminar  = errors.New("solo el autor, el creador de la reta o un moderador pueden eliminar el mensaje")
+	ErrVentanaEdicion      = errors.New("el mensaje ya no se puede editar: pasaron más de 15 minutos")
+	ErrMensajeEliminado    = errors.New("el mensaje fue eliminado")
+	ErrSancionInvalida     = errors.New("tipo de sanción inválido: usa silencio o expulsion")
+	ErrAutoSancion         = errors.New("no puedes sancionarte a ti mismo")
+	ErrSinSancion          = errors.New("el usuario no tiene una sanción activa en esta reta")
+	ErrSilenciado          = errors.New("estás silenciado en el chat de esta reta")
+	ErrExpulsadoDelChat    = errors.New("fuiste expulsado del chat de esta reta")
+	ErrSinAccesoChat       = errors.New("solo los jugadores, la lista de espera y el creador de la reta pueden ver su chat")
+)
+
+// Errores de la cadena de moderación: el mensaje no se guarda ni se difunde
+var (
+	ErrMensajeLargo      = errors.New("el mensaje supera los 500 caracteres")
+	ErrLenguajeOfensivo  = errors.New("el mensaje contiene lenguaje no permitido")
+	ErrEnlaceNoPermitido = errors.New("no se permiten enlaces en el chat")
+	ErrMensajeRepetido   = errors.New("ya enviaste ese mensaje hace un momento")
+)
@@ -1,26 +1,152 @@
 package repositories
 
 import (
+	"games-football-api/src/core"
 	"games-football-api/src/retas/domain/entities"
+	"time"
 )
 
 // IRetaRepository define la interfaz para operaciones de retas
 type IRetaRepository interface {
-	// UnirseReta realiza la lógica de unirse a una reta con transacción y bloqueo
-	UnirseReta(retaID, usuarioID, nombreJugador string) (jugadoresActuales int, listaJugadores []entities.Jugador, err error)
+	// UnirseReta realiza la lógica de unirse a una reta con transacción y bloqueo;
+	// si la reta está llena el usuario queda en la lista de espera
+	UnirseReta(retaID, usuarioID, nombreJugador string) (*entities.MovimientoReta, error)
 
-	// CrearReta crea una nueva reta e inserta al creador como primer jugador
+	// SalirReta elimina al jugador (o lo saca de la lista de espera) con transacción y bloqueo,
+	// promoviendo al primero en espera cuando se libera un lugar
+	SalirReta(retaID, usuarioID string) (*entities.MovimientoReta, error)
+
+	// ObtenerListaEspera obtiene la lista de espera de una reta en orden de llegada
+	ObtenerListaEspera(retaID string) ([]entities.Jugador, error)
+
+	// EditarReta actualiza título, fecha, cupo, duración y ubicación de una reta con transacción y bloqueo;
+	// si el cupo aumenta, promueve jugadores de la lista de espera en la misma transacción. Si la reta
+	// tiene cancha, falla con ErrCanchaOcupada cuando el nuevo horario choca con otra reta
+	EditarReta(reta *entities.Reta) (*entities.MovimientoReta, error)
+
+	// CancelarReta marca la reta como cancelada (soft delete)
+	CancelarReta(retaID string) error
+
+	// ObtenerRetasConCambioDeHorario obtiene las retas cuyo estado debe avanzar por su fecha_hora
+	ObtenerRetasConCambioDeHorario(ahora time.Time) ([]entities.Reta, error)
+
+	// ActualizarEstado cambia el estado solo si la reta sigue en el estado `desde`; retorna si hubo cambio
+	ActualizarEstado(retaID, desde, hacia string) (bool, error)
+
+	// ObtenerRetaPorID obtiene los datos de una reta
+	ObtenerRetaPorID(retaID string) (*entities.Reta, error)
+
+	// EsParticipante indica si el usuario es el creador de la reta, uno de sus jugadores o está en su
+	// lista de espera; ErrRetaNoEncontrada si la reta no existe
+	EsParticipante(retaID, usuarioID string) (bool, error)
+
+	// CrearReta crea una nueva reta e inserta al creador como primer jugador. Si aparta una cancha,
+	// falla con ErrCanchaOcupada cuando otra reta no cancelada la ocupa en ese horario
 	CrearReta(reta *entities.Reta) (retaCreada *entities.Reta, primerJugador *entities.Jugador, err error)
 
 	// ObtenerJugadoresDeReta obtiene la lista de jugadores confirmados de una reta
 	ObtenerJugadoresDeReta(retaID string) ([]entities.Jugador, error)
 
-	// ObtenerRetasPorZona obtiene todas las retas activas de una zona con sus jugadores
-	ObtenerRetasPorZona(zonaID string) ([]entities.RetaInfo, error)
+	// ObtenerRetasPorZona obtiene las retas de una zona con sus jugadores según el filtro
+	ObtenerRetasPorZona(zonaID string, filtro entities.FiltroRetas) ([]entities.RetaInfo, error)
 
 	// GuardarMensaje persiste un mensaje de chat y retorna el mensaje enriquecido con nombre de usuario
 	GuardarMensaje(mensaje entities.Mensaje) (*entities.Mensaje, error)
 
-	// ObtenerMensajesDeReta obtiene el historial de mensajes de una reta
-	ObtenerMensajesDeReta(retaID string) ([]entities.Mensaje, error)
+	// ObtenerMensajesDeReta obtiene una página del historial de mensajes de una reta
+	ObtenerMensajesDeReta(retaID string, consulta entities.ConsultaMensajes) (*entities.PaginaMensajes, error)
+
+	// ContarMensajesDeReta retorna cuántos mensajes tiene el chat de una reta
+	ContarMensajesDeReta(retaID string) (int, error)
+
+	// ObtenerMensaje obtiene un mensaje del chat de una reta (incluso si fue eliminado)
+	ObtenerMensaje(retaID, mensajeID string) (*entities.Mensaje, error)
+
+	// EditarMensaje reemplaza el texto de un mensaje no eliminado y registra editado_en
+	EditarMensaje(mensajeID, texto string) (*entities.Mensaje, error)
+
+	// EliminarMensaje deja el mensaje como marcador: borra el texto y registra quién y cuándo lo eliminó.
+	// Si auditoria no es nil (eliminación por moderación) se guarda en la misma transacción
+	EliminarMensaje(mensajeID, eliminadoPor string, auditoria *entities.EventoModeracion) (*entities.Mensaje, error)
+
+	// ObtenerTextosRecientes retorna los textos que el usuario envió al chat desde la fecha dada (sin eliminados)
+	ObtenerTextosRecientes(retaID, usuarioID string, desde time.Time) ([]string, error)
+
+	// GuardarSancion aplica una sanción reemplazando la que el usuario tuviera en la reta
+	GuardarSancion(sancion *entities.Sancion) error
+
+	// ObtenerSancion obtiene la sanción del usuario en la reta (vigente o vencida); nil si no tiene
+	ObtenerSancion(retaID, usuarioID string) (*entities.Sancion, error)
+
+	// ObtenerSancionesActivas obtiene las sanciones vigentes del chat de una reta
+	ObtenerSancionesActivas(retaID string, ahora time.Time) ([]entities.Sancion, error)
+
+	// QuitarSancion elimina la sanción del usuario; retorna si había una
+	QuitarSancion(retaID, usuarioID string) (bool, error)
+
+	// MarcarLeido avanza el último mensaje leído del usuario en el chat (nunca retrocede) y retorna
+	// la lectura resultante con sus mensajes no leídos
+	MarcarLeido(retaID, usuarioID, mensajeID string) (*entities.LecturaChat, error)
+
+	// ForzarCancelacion cancela la reta aunque esté en juego (acción de moderación) y guarda el evento
+	// de auditoría en la misma transacción
+	ForzarCancelacion(retaID string, auditoria *entities.EventoModeracion) error
+
+	// PurgarMensajes elimina de golpe los mensajes del chat (solo los de usuarioID si no está vacío)
+	// dejando marcadores, guarda en la misma transacción el evento de auditoría con cuántos se
+	// eliminaron y el motivo, y retorna cuántos se eliminaron
+	PurgarMensajes(retaID, usuarioID, eliminadoPor, motivo string) (int, error)
+
+	// ObtenerZona obtiene el centro y el radio de una zona; ErrZonaNoEncontrada si no está registrada
+	ObtenerZona(zonaID string) (*entities.Zona, error)
+
+	// ObtenerCancha obtiene la zona, la capacidad, la ubicación y el horario de una cancha;
+	// ErrCanchaNoEncontrada si no existe
+	ObtenerCancha(canchaID string) (*core.Cancha, error)
+
+	// BuscarRetasCercanas obtiene las retas abiertas o llenas que aún no empiezan dentro del radio de
+	// búsqueda, de cualquier zona, ordenadas por distancia
+	BuscarRetasCercanas(busqueda entities.BusquedaCercana) ([]entities.RetaCercana, error)
+
+	// ObtenerCuenta obtiene el rol y el baneo vigentes del usuario; ErrUsuarioNoEncontrado si no existe
+	ObtenerCuenta(usuarioID string) (*entities.CuentaUsuario, error)
+
+	// CrearSerie guarda una serie recurrente nueva en estado activa
+	CrearSerie(serie *entities.Serie) error
+
+	// ObtenerSerie obtiene una serie con sus suscriptores; ErrSerieNoEncontrada si no existe
+	ObtenerSerie(serieID string) (*entities.Serie, error)
+
+	// ObtenerSeriesPorZona obtiene las series activas de una zona con sus suscriptores
+	ObtenerSeriesPorZona(zonaID string) ([]entities.Serie, error)
+
+	// ObtenerSeriesActivas obtiene todas las series activas con sus suscriptores (las que materializa el scheduler)
+	ObtenerSeriesActivas() ([]entities.Serie, error)
+
+	// ActualizarSerie guarda el título, el cupo, la duración, la regla y el estado de la serie
+	ActualizarSerie(serie *entities.Serie) error
+
+	// CambiarEstadoSerie cambia el estado solo si la serie sigue en el estado `desde`; retorna si hubo cambio
+	CambiarEstadoSerie(serieID, desde, hacia string) (bool, error)
+
+	// ObtenerOcurrencias retorna los días (YYYY-MM-DD) de la serie que ya tienen reta, incluidas las canceladas
+	ObtenerOcurrencias(serieID string) (map[string]bool, error)
+
+	// ObtenerOcurrenciasPendientes obtiene las retas abiertas o llenas de la serie ordenadas por fecha_hora
+	ObtenerOcurrenciasPendientes(serieID string) ([]entities.Reta, error)
+
+	// CrearOcurrencia crea la reta de un día de la serie con el creador como primer jugador e inscribe a los
+	// suscriptores (los que no caben quedan en la lista de espera). Falla con ErrOcurrenciaExistente si el día
+	// ya tiene reta, con ErrSerieInactiva si la serie dejó de estar activa y con ErrCanchaOcupada si la
+	// cancha ya está apartada en ese horario
+	CrearOcurrencia(reta *entities.Reta, suscriptores []entities.Suscriptor) (*entities.MovimientoReta, error)
+
+	// SuscribirASerie agrega al usuario a los jugadores habituales de la serie y en la misma transacción lo
+	// inscribe (o lo deja en espera) en las retas pendientes de la serie posteriores a desde que no se
+	// editaron por separado; retorna un movimiento por reta. ErrYaSuscrito si ya lo estaba y
+	// ErrSerieInactiva si la serie dejó de estar activa
+	SuscribirASerie(serieID, usuarioID, nombre string, desde time.Time) ([]entities.MovimientoReta, error)
+
+	// DesuscribirDeSerie quita al usuario de los jugadores habituales; retorna si estaba suscrito
+	DesuscribirDeSerie(serieID, usuarioID string) (bool, error)
 }
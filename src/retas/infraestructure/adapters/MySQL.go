@@ -4,7 +4,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"games-football-api/src/core"
 	"games-football-api/src/retas/domain/entities"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,12 +22,13 @@ func NewMySQLRetaRepository(db *sql.DB) *MySQLRetaRepository {
 	}
 }
 
-// UnirseReta implementa la lógica de unirse a una reta con transacción y bloqueo
-func (repo *MySQLRetaRepository) UnirseReta(retaID, usuarioID, nombreJugador string) (int, []entities.Jugador, error) {
+// UnirseReta implementa la lógica de unirse a una reta con transacción y bloqueo.
+// Si la reta está llena, el usuario se agrega al final de la lista de espera.
+func (repo *MySQLRetaRepository) UnirseReta(retaID, usuarioID, nombreJugador string) (*entities.MovimientoReta, error) {
 	// Iniciar transacción
 	tx, err := repo.db.Begin()
 	if err != nil {
-		return 0, nil, fmt.Errorf("error al iniciar transacción: %w", err)
+		return nil, fmt.Errorf("error al iniciar transacción: %w", err)
 	}
 
 	defer func() {
@@ -35,20 +38,20 @@ func (repo *MySQLRetaRepository) UnirseReta(retaID, usuarioID, nombreJugador str
 	}()
 
 	// SELECT FOR UPDATE para bloquear la fila
+	var zonaID, estado string
 	var jugadoresActuales, maxJugadores int
-	query := "SELECT jugadores_actuales, max_jugadores FROM retas WHERE id = ? FOR UPDATE"
-	err = tx.QueryRow(query, retaID).Scan(&jugadoresActuales, &maxJugadores)
+	query := "SELECT zona_id, jugadores_actuales, max_jugadores, estado FROM retas WHERE id = ? FOR UPDATE"
+	err = tx.QueryRow(query, retaID).Scan(&zonaID, &jugadoresActuales, &maxJugadores, &estado)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return 0, nil, errors.New("reta no encontrada")
+			return nil, entities.ErrRetaNoEncontrada
 		}
-		return 0, nil, fmt.Errorf("error al consultar reta: %w", err)
+		return nil, fmt.Errorf("error al consultar reta: %w", err)
 	}
 
-	// Verificar si la reta está llena
-	if jugadoresActuales >= maxJugadores {
+	if err := entities.ValidarCambioDeJugadores(estado); err != nil {
 		tx.Rollback()
-		return 0, nil, errors.New("reta llena")
+		return nil, err
 	}
 
 	// Validar que el usuario_id exista en la tabla usuarios
@@ -56,11 +59,11 @@ func (repo *MySQLRetaRepository) UnirseReta(retaID, usuarioID, nombreJugador str
 	checkUsuarioQuery := "SELECT COUNT(*) FROM usuarios WHERE id = ?"
 	err = tx.QueryRow(checkUsuarioQuery, usuarioID).Scan(&existeUsuario)
 	if err != nil {
-		return 0, nil, fmt.Errorf("error al verificar usuario: %w", err)
+		return nil, fmt.Errorf("error al verificar usuario: %w", err)
 	}
 	if existeUsuario == 0 {
 		tx.Rollback()
-		return 0, nil, errors.New("el usuario no existe")
+		return nil, errors.New("el usuario no existe")
 	}
 
 	// Verificar si el usuario ya está inscrito en esta reta
@@ -68,50 +71,90 @@ func (repo *MySQLRetaRepository) UnirseReta(retaID, usuarioID, nombreJugador str
 	checkQuery := "SELECT COUNT(*) FROM reta_jugadores WHERE reta_id = ? AND usuario_id = ?"
 	err = tx.QueryRow(checkQuery, retaID, usuarioID).Scan(&existeJugador)
 	if err != nil {
-		return 0, nil, fmt.Errorf("error al verificar jugador: %w", err)
+		return nil, fmt.Errorf("error al verificar jugador: %w", err)
 	}
 
 	if existeJugador > 0 {
 		tx.Rollback()
-		return 0, nil, errors.New("el usuario ya está inscrito en esta reta")
+		return nil, errors.New("el usuario ya está inscrito en esta reta")
 	}
 
-	// Incrementar el contador de jugadores
-	updateQuery := "UPDATE retas SET jugadores_actuales = jugadores_actuales + 1 WHERE id = ?"
-	_, err = tx.Exec(updateQuery, retaID)
+	// Verificar si el usuario ya está en la lista de espera
+	var enEspera int
+	checkEsperaQuery := "SELECT COUNT(*) FROM reta_lista_espera WHERE reta_id = ? AND usuario_id = ?"
+	err = tx.QueryRow(checkEsperaQuery, retaID, usuarioID).Scan(&enEspera)
 	if err != nil {
-		return 0, nil, fmt.Errorf("error al actualizar contador: %w", err)
+		return nil, fmt.Errorf("error al verificar lista de espera: %w", err)
 	}
 
-	// Insertar al jugador
-	jugadorID := uuid.New().String()
-	insertQuery := "INSERT INTO reta_jugadores (id, reta_id, usuario_id, nombre_jugador) VALUES (?, ?, ?, ?)"
-	_, err = tx.Exec(insertQuery, jugadorID, retaID, usuarioID, nombreJugador)
-	if err != nil {
-		return 0, nil, fmt.Errorf("error al insertar jugador: %w", err)
+	if enEspera > 0 {
+		tx.Rollback()
+		return nil, errors.New("el usuario ya está en la lista de espera")
+	}
+
+	movimiento := &entities.MovimientoReta{
+		RetaID:            retaID,
+		ZonaID:            zonaID,
+		Estado:            estado,
+		JugadoresActuales: jugadoresActuales,
+	}
+
+	if jugadoresActuales >= maxJugadores {
+		// Reta llena: agregar al final de la lista de espera (la fila de la reta sigue bloqueada)
+		esperaID := uuid.New().String()
+		insertEsperaQuery := `
+			INSERT INTO reta_lista_espera (id, reta_id, usuario_id, nombre_jugador, posicion)
+			SELECT ?, ?, ?, ?, COALESCE(MAX(posicion), 0) + 1 FROM reta_lista_espera WHERE reta_id = ?
+		`
+		_, err = tx.Exec(insertEsperaQuery, esperaID, retaID, usuarioID, nombreJugador, retaID)
+		if err != nil {
+			return nil, fmt.Errorf("error al agregar a la lista de espera: %w", err)
+		}
+		movimiento.EnEspera = true
+	} else {
+		// Incrementar el contador de jugadores
+		updateQuery := "UPDATE retas SET jugadores_actuales = jugadores_actuales + 1 WHERE id = ?"
+		_, err = tx.Exec(updateQuery, retaID)
+		if err != nil {
+			return nil, fmt.Errorf("error al actualizar contador: %w", err)
+		}
+
+		// Insertar al jugador
+		jugadorID := uuid.New().String()
+		insertQuery := "INSERT INTO reta_jugadores (id, reta_id, usuario_id, nombre_jugador) VALUES (?, ?, ?, ?)"
+		_, err = tx.Exec(insertQuery, jugadorID, retaID, usuarioID, nombreJugador)
+		if err != nil {
+			return nil, fmt.Errorf("error al insertar jugador: %w", err)
+		}
+		movimiento.JugadoresActuales++
+
+		// Si con este jugador se completa el cupo la reta pasa a "llena"
+		movimiento.Estado, err = repo.actualizarEstadoPorCupo(tx, retaID, estado, movimiento.JugadoresActuales, maxJugadores)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Commit de la transacción
 	err = tx.Commit()
 	if err != nil {
-		return 0, nil, fmt.Errorf("error al hacer commit: %w", err)
+		return nil, fmt.Errorf("error al hacer commit: %w", err)
 	}
 
-	// Obtener la lista actualizada de jugadores
-	listaJugadores, err := repo.ObtenerJugadoresDeReta(retaID)
-	if err != nil {
-		return 0, nil, fmt.Errorf("error al obtener lista de jugadores: %w", err)
+	if err := repo.completarListas(movimiento); err != nil {
+		return nil, err
 	}
 
-	return jugadoresActuales + 1, listaJugadores, nil
+	return movimiento, nil
 }
 
-// CrearReta crea una nueva reta e inserta al creador como primer jugador
-func (repo *MySQLRetaRepository) CrearReta(reta *entities.Reta) (*entities.Reta, *entities.Jugador, error) {
+// SalirReta elimina al jugador de la reta y decrementa el contador con transacción y bloqueo.
+// Si el usuario solo estaba en la lista de espera, lo saca de ella sin tocar el contador.
+func (repo *MySQLRetaRepository) SalirReta(retaID, usuarioID string) (*entities.MovimientoReta, error) {
 	// Iniciar transacción
 	tx, err := repo.db.Begin()
 	if err != nil {
-		return nil, nil, fmt.Errorf("error al iniciar transacción: %w", err)
+		return nil, fmt.Errorf("error al iniciar transacción: %w", err)
 	}
 
 	defer func() {
@@ -120,194 +163,1475 @@ func (repo *MySQLRetaRepository) CrearReta(reta *entities.Reta) (*entities.Reta,
 		}
 	}()
 
-	// Generar UUID para la reta
-	retaID := uuid.New().String()
-	reta.ID = retaID
+	// SELECT FOR UPDATE para bloquear la fila
+	var zonaID, estado string
+	var jugadoresActuales, maxJugadores int
+	query := "SELECT zona_id, jugadores_actuales, max_jugadores, estado FROM retas WHERE id = ? FOR UPDATE"
+	err = tx.QueryRow(query, retaID).Scan(&zonaID, &jugadoresActuales, &maxJugadores, &estado)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrRetaNoEncontrada
+		}
+		return nil, fmt.Errorf("error al consultar reta: %w", err)
+	}
 
-	// Insertar la reta
-	insertRetaQuery := `
-		INSERT INTO retas (id, zona_id, titulo, fecha_hora, max_jugadores, jugadores_actuales, creador_id, creador_nombre, created_at)
-		VALUES (?, ?, ?, ?, ?, 1, ?, ?, NOW())
+	if err := entities.ValidarCambioDeJugadores(estado); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	movimiento := &entities.MovimientoReta{
+		RetaID:            retaID,
+		ZonaID:            zonaID,
+		Estado:            estado,
+		JugadoresActuales: jugadoresActuales,
+	}
+
+	// Registrar la baja (con la fecha en que se había unido) para las estadísticas del usuario
+	bajaQuery := `
+		INSERT INTO reta_bajas (reta_id, usuario_id, unido_en)
+		SELECT reta_id, usuario_id, created_at FROM reta_jugadores WHERE reta_id = ? AND usuario_id = ?
 	`
-	_, err = tx.Exec(insertRetaQuery, reta.ID, reta.ZonaID, reta.Titulo, reta.FechaHora, reta.MaxJugadores, reta.CreadorID, reta.CreadorNombre)
+	_, err = tx.Exec(bajaQuery, retaID, usuarioID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error al insertar reta: %w", err)
+		return nil, fmt.Errorf("error al registrar baja: %w", err)
 	}
 
-	// Insertar al creador como primer jugador
-	jugadorID := uuid.New().String()
-	insertJugadorQuery := "INSERT INTO reta_jugadores (id, reta_id, usuario_id, nombre_jugador) VALUES (?, ?, ?, ?)"
-	_, err = tx.Exec(insertJugadorQuery, jugadorID, retaID, reta.CreadorID, reta.CreadorNombre)
+	// Eliminar al jugador
+	deleteQuery := "DELETE FROM reta_jugadores WHERE reta_id = ? AND usuario_id = ?"
+	result, err := tx.Exec(deleteQuery, retaID, usuarioID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error al insertar primer jugador: %w", err)
+		return nil, fmt.Errorf("error al eliminar jugador: %w", err)
 	}
 
-	// Commit
-	err = tx.Commit()
+	eliminados, err := result.RowsAffected()
 	if err != nil {
-		return nil, nil, fmt.Errorf("error al hacer commit: %w", err)
+		return nil, fmt.Errorf("error al eliminar jugador: %w", err)
 	}
 
-	reta.JugadoresActuales = 1
+	if eliminados > 0 {
+		// Decrementar el contador de jugadores
+		updateQuery := "UPDATE retas SET jugadores_actuales = jugadores_actuales - 1 WHERE id = ?"
+		_, err = tx.Exec(updateQuery, retaID)
+		if err != nil {
+			return nil, fmt.Errorf("error al actualizar contador: %w", err)
+		}
+		movimiento.JugadoresActuales--
 
-	primerJugador := &entities.Jugador{
-		ID:        jugadorID,
-		UsuarioID: reta.CreadorID,
-		Nombre:    reta.CreadorNombre,
-		RetaID:    retaID,
+		// Ocupar el lugar liberado con el primero de la lista de espera
+		var promovidos []entities.Jugador
+		promovidos, err = repo.promoverDesdeEspera(tx, retaID, maxJugadores-movimiento.JugadoresActuales)
+		if err != nil {
+			return nil, err
+		}
+		movimiento.Promovidos = promovidos
+		movimiento.JugadoresActuales += len(promovidos)
+
+		// Si nadie ocupó el lugar liberado la reta vuelve a "abierta"
+		movimiento.Estado, err = repo.actualizarEstadoPorCupo(tx, retaID, estado, movimiento.JugadoresActuales, maxJugadores)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// No estaba inscrito: intentar sacarlo de la lista de espera
+		deleteEsperaQuery := "DELETE FROM reta_lista_espera WHERE reta_id = ? AND usuario_id = ?"
+		result, err = tx.Exec(deleteEsperaQuery, retaID, usuarioID)
+		if err != nil {
+			return nil, fmt.Errorf("error al salir de la lista de espera: %w", err)
+		}
+
+		eliminados, err = result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("error al salir de la lista de espera: %w", err)
+		}
+		if eliminados == 0 {
+			tx.Rollback()
+			return nil, errors.New("el usuario no está inscrito en esta reta")
+		}
 	}
 
-	return reta, primerJugador, nil
+	// Commit de la transacción
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("error al hacer commit: %w", err)
+	}
+
+	if err := repo.completarListas(movimiento); err != nil {
+		return nil, err
+	}
+
+	return movimiento, nil
 }
 
-// ObtenerRetasPorZona obtiene todas las retas de una zona con sus jugadores
-func (repo *MySQLRetaRepository) ObtenerRetasPorZona(zonaID string) ([]entities.RetaInfo, error) {
-	query := `
-		SELECT r.id, r.titulo, r.fecha_hora, r.max_jugadores, r.jugadores_actuales,
-		       rj.id as jugador_id, rj.usuario_id, u.nombre
-		FROM retas r
-		LEFT JOIN reta_jugadores rj ON r.id = rj.reta_id
-		LEFT JOIN usuarios u ON rj.usuario_id = u.id
-		WHERE r.zona_id = ?
-		ORDER BY r.created_at DESC, rj.created_at ASC
+// promoverDesdeEspera mueve hasta `cupos` jugadores de la lista de espera a la reta dentro de la
+// transacción recibida (la fila de la reta ya debe estar bloqueada con FOR UPDATE)
+func (repo *MySQLRetaRepository) promoverDesdeEspera(tx *sql.Tx, retaID string, cupos int) ([]entities.Jugador, error) {
+	promovidos := make([]entities.Jugador, 0)
+	if cupos <= 0 {
+		return promovidos, nil
+	}
+
+	selectQuery := `
+		SELECT id, usuario_id, nombre_jugador
+		FROM reta_lista_espera
+		WHERE reta_id = ?
+		ORDER BY posicion ASC
+		LIMIT ?
+		FOR UPDATE
 	`
-	rows, err := repo.db.Query(query, zonaID)
+	rows, err := tx.Query(selectQuery, retaID, cupos)
 	if err != nil {
-		return nil, fmt.Errorf("error al consultar retas: %w", err)
+		return nil, fmt.Errorf("error al consultar lista de espera: %w", err)
 	}
-	defer rows.Close()
-
-	retasMap := make(map[string]*entities.RetaInfo)
-	orden := []string{}
 
+	esperaIDs := make([]string, 0, cupos)
 	for rows.Next() {
-		var retaID, titulo string
-		var fechaHora time.Time
-		var maxJugadores, jugadoresActuales int
-		var jugadorID, usuarioID, nombreJugador sql.NullString
-
-		err := rows.Scan(&retaID, &titulo, &fechaHora, &maxJugadores, &jugadoresActuales,
-			&jugadorID, &usuarioID, &nombreJugador)
-		if err != nil {
-			return nil, fmt.Errorf("error al escanear reta: %w", err)
+		var esperaID string
+		var jugador entities.Jugador
+		if err := rows.Scan(&esperaID, &jugador.UsuarioID, &jugador.Nombre); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("error al escanear lista de espera: %w", err)
 		}
+		jugador.RetaID = retaID
+		esperaIDs = append(esperaIDs, esperaID)
+		promovidos = append(promovidos, jugador)
+	}
+	rows.Close()
 
-		if _, exists := retasMap[retaID]; !exists {
-			retasMap[retaID] = &entities.RetaInfo{
-				ID:                retaID,
-				Titulo:            titulo,
-				FechaHora:         fechaHora.Format("2006-01-02 15:04:05"),
-				MaxJugadores:      maxJugadores,
-				JugadoresActuales: jugadoresActuales,
-				ListaJugadores:    []entities.Jugador{},
-			}
-			orden = append(orden, retaID)
+	for i := range promovidos {
+		promovidos[i].ID = uuid.New().String()
+		insertQuery := "INSERT INTO reta_jugadores (id, reta_id, usuario_id, nombre_jugador) VALUES (?, ?, ?, ?)"
+		if _, err := tx.Exec(insertQuery, promovidos[i].ID, retaID, promovidos[i].UsuarioID, promovidos[i].Nombre); err != nil {
+			return nil, fmt.Errorf("error al promover jugador: %w", err)
 		}
 
-		if jugadorID.Valid {
-			retasMap[retaID].ListaJugadores = append(retasMap[retaID].ListaJugadores, entities.Jugador{
-				ID:        jugadorID.String,
-				UsuarioID: usuarioID.String,
-				Nombre:    nombreJugador.String,
-				RetaID:    retaID,
-			})
+		if _, err := tx.Exec("DELETE FROM reta_lista_espera WHERE id = ?", esperaIDs[i]); err != nil {
+			return nil, fmt.Errorf("error al actualizar lista de espera: %w", err)
 		}
 	}
 
-	result := make([]entities.RetaInfo, 0, len(orden))
-	for _, id := range orden {
-		// Obtener historial de chat para cada reta
-		mensajes, err := repo.ObtenerMensajesDeReta(id)
-		if err != nil {
-			mensajes = []entities.Mensaje{}
+	if len(promovidos) > 0 {
+		updateQuery := "UPDATE retas SET jugadores_actuales = jugadores_actuales + ? WHERE id = ?"
+		if _, err := tx.Exec(updateQuery, len(promovidos), retaID); err != nil {
+			return nil, fmt.Errorf("error al actualizar contador: %w", err)
 		}
-		retasMap[id].HistorialChat = mensajes
-		result = append(result, *retasMap[id])
 	}
-	return result, nil
+
+	return promovidos, nil
 }
 
-// ObtenerJugadoresDeReta obtiene la lista de jugadores confirmados con nombre real de usuarios
-func (repo *MySQLRetaRepository) ObtenerJugadoresDeReta(retaID string) ([]entities.Jugador, error) {
+// completarListas carga la lista de jugadores y la lista de espera actualizadas del movimiento
+func (repo *MySQLRetaRepository) completarListas(movimiento *entities.MovimientoReta) error {
+	listaJugadores, err := repo.ObtenerJugadoresDeReta(movimiento.RetaID)
+	if err != nil {
+		return fmt.Errorf("error al obtener lista de jugadores: %w", err)
+	}
+
+	listaEspera, err := repo.ObtenerListaEspera(movimiento.RetaID)
+	if err != nil {
+		return fmt.Errorf("error al obtener lista de espera: %w", err)
+	}
+
+	movimiento.ListaJugadores = listaJugadores
+	movimiento.ListaEspera = listaEspera
+	return nil
+}
+
+// ObtenerListaEspera obtiene la lista de espera de una reta en orden de llegada
+func (repo *MySQLRetaRepository) ObtenerListaEspera(retaID string) ([]entities.Jugador, error) {
 	query := `
-		SELECT rj.id, rj.usuario_id, u.nombre
-		FROM reta_jugadores rj
-		INNER JOIN usuarios u ON rj.usuario_id = u.id
-		WHERE rj.reta_id = ?
-		ORDER BY rj.created_at ASC
+		SELECT le.id, le.usuario_id, u.nombre, le.posicion
+		FROM reta_lista_espera le
+		INNER JOIN usuarios u ON le.usuario_id = u.id
+		WHERE le.reta_id = ?
+		ORDER BY le.posicion ASC
 	`
 	rows, err := repo.db.Query(query, retaID)
 	if err != nil {
-		return nil, fmt.Errorf("error al consultar jugadores: %w", err)
+		return nil, fmt.Errorf("error al consultar lista de espera: %w", err)
 	}
 	defer rows.Close()
 
-	jugadores := make([]entities.Jugador, 0)
+	espera := make([]entities.Jugador, 0)
+	posicion := 0
 	for rows.Next() {
 		var jugador entities.Jugador
-		err := rows.Scan(&jugador.ID, &jugador.UsuarioID, &jugador.Nombre)
+		var posicionAbsoluta int
+		err := rows.Scan(&jugador.ID, &jugador.UsuarioID, &jugador.Nombre, &posicionAbsoluta)
 		if err != nil {
-			return nil, fmt.Errorf("error al escanear jugador: %w", err)
+			return nil, fmt.Errorf("error al escanear lista de espera: %w", err)
 		}
-		jugadores = append(jugadores, jugador)
+		// La posición que se expone es relativa (1 = el siguiente en entrar)
+		posicion++
+		jugador.Posicion = posicion
+		jugador.RetaID = retaID
+		espera = append(espera, jugador)
 	}
 
-	return jugadores, nil
+	return espera, nil
 }
 
-// GuardarMensaje inserta un mensaje de chat y retorna el mensaje con el nombre real del usuario (JOIN)
-func (repo *MySQLRetaRepository) GuardarMensaje(mensaje entities.Mensaje) (*entities.Mensaje, error) {
-	mensajeID := uuid.New().String()
+// EditarReta actualiza título, fecha y cupo de una reta con transacción y bloqueo.
+// Si el nuevo cupo es mayor, promueve jugadores de la lista de espera en la misma transacción.
+func (repo *MySQLRetaRepository) EditarReta(reta *entities.Reta) (*entities.MovimientoReta, error) {
+	// Iniciar transacción
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error al iniciar transacción: %w", err)
+	}
 
-	insertQuery := "INSERT INTO mensajes_reta (id, reta_id, usuario_id, texto) VALUES (?, ?, ?, ?)"
-	_, err := repo.db.Exec(insertQuery, mensajeID, mensaje.RetaID, mensaje.UsuarioID, mensaje.Texto)
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// SELECT FOR UPDATE para bloquear la fila mientras se valida el nuevo cupo
+	var jugadoresActuales int
+	var estado string
+	query := "SELECT jugadores_actuales, estado FROM retas WHERE id = ? FOR UPDATE"
+	err = tx.QueryRow(query, reta.ID).Scan(&jugadoresActuales, &estado)
 	if err != nil {
-		return nil, fmt.Errorf("error al guardar mensaje: %w", err)
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrRetaNoEncontrada
+		}
+		return nil, fmt.Errorf("error al consultar reta: %w", err)
 	}
 
-	// Recuperar el mensaje con JOIN a usuarios para obtener el nombre real
-	selectQuery := `
-		SELECT m.id, m.reta_id, m.usuario_id, u.nombre, m.texto, m.creado_en
-		FROM mensajes_reta m
-		INNER JOIN usuarios u ON m.usuario_id = u.id
-		WHERE m.id = ?
-	`
-	var resultado entities.Mensaje
-	err = repo.db.QueryRow(selectQuery, mensajeID).Scan(
-		&resultado.ID, &resultado.RetaID, &resultado.UsuarioID,
-		&resultado.NombreUsuario, &resultado.Texto, &resultado.Timestamp,
-	)
+	if err := entities.ValidarCambioDeJugadores(estado); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Nunca reducir el cupo por debajo de los jugadores ya inscritos
+	if reta.MaxJugadores < jugadoresActuales {
+		tx.Rollback()
+		return nil, fmt.Errorf("max_jugadores no puede ser menor a los jugadores inscritos (%d)", jugadoresActuales)
+	}
+
+	// El nuevo horario no puede chocar con otra reta en la misma cancha
+	if err := repo.verificarCanchaLibre(tx, reta); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	latitud, longitud := columnasUbicacion(reta.Ubicacion)
+	updateQuery := "UPDATE retas SET titulo = ?, fecha_hora = ?, max_jugadores = ?, duracion_minutos = ?, latitud = ?, longitud = ?, serie_editada = ? WHERE id = ?"
+	_, err = tx.Exec(updateQuery, reta.Titulo, reta.FechaHora, reta.MaxJugadores, reta.DuracionMinutos, latitud, longitud, reta.OcurrenciaEditada, reta.ID)
 	if err != nil {
-		return nil, fmt.Errorf("error al recuperar mensaje enriquecido: %w", err)
+		return nil, fmt.Errorf("error al actualizar reta: %w", err)
+	}
+
+	// Ocupar los lugares nuevos con la lista de espera
+	var promovidos []entities.Jugador
+	promovidos, err = repo.promoverDesdeEspera(tx, reta.ID, reta.MaxJugadores-jugadoresActuales)
+	if err != nil {
+		return nil, err
+	}
+
+	// El nuevo cupo puede abrir o llenar la reta
+	var nuevoEstado string
+	nuevoEstado, err = repo.actualizarEstadoPorCupo(tx, reta.ID, estado, jugadoresActuales+len(promovidos), reta.MaxJugadores)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit de la transacción
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("error al hacer commit: %w", err)
+	}
+
+	reta.Estado = nuevoEstado
+	movimiento := &entities.MovimientoReta{
+		RetaID:            reta.ID,
+		ZonaID:            reta.ZonaID,
+		Estado:            nuevoEstado,
+		JugadoresActuales: jugadoresActuales + len(promovidos),
+		Promovidos:        promovidos,
+	}
+	if err := repo.completarListas(movimiento); err != nil {
+		return nil, err
+	}
+
+	return movimiento, nil
+}
+
+// CancelarReta marca la reta como cancelada; la fila y su historial se conservan
+func (repo *MySQLRetaRepository) CancelarReta(retaID string) error {
+	query := "UPDATE retas SET estado = ? WHERE id = ? AND estado IN (?, ?)"
+	result, err := repo.db.Exec(query, entities.EstadoCancelada, retaID, entities.EstadoAbierta, entities.EstadoLlena)
+	if err != nil {
+		return fmt.Errorf("error al cancelar reta: %w", err)
+	}
+
+	afectadas, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al cancelar reta: %w", err)
+	}
+	if afectadas == 0 {
+		// Otro proceso cambió el estado entre la validación y el UPDATE
+		return entities.ErrRetaCerrada
 	}
 
-	return &resultado, nil
+	return nil
 }
 
-// ObtenerMensajesDeReta obtiene el historial completo de mensajes de una reta
-func (repo *MySQLRetaRepository) ObtenerMensajesDeReta(retaID string) ([]entities.Mensaje, error) {
+// actualizarEstadoPorCupo mueve la reta entre "abierta" y "llena" según su cupo dentro de la
+// transacción recibida y retorna el estado resultante
+func (repo *MySQLRetaRepository) actualizarEstadoPorCupo(tx *sql.Tx, retaID, estadoActual string, jugadoresActuales, maxJugadores int) (string, error) {
+	nuevoEstado := entities.EstadoPorCupo(jugadoresActuales, maxJugadores)
+	if nuevoEstado == estadoActual {
+		return estadoActual, nil
+	}
+
+	if err := entities.ValidarTransicion(estadoActual, nuevoEstado); err != nil {
+		return "", err
+	}
+
+	if _, err := tx.Exec("UPDATE retas SET estado = ? WHERE id = ?", nuevoEstado, retaID); err != nil {
+		return "", fmt.Errorf("error al actualizar estado: %w", err)
+	}
+
+	return nuevoEstado, nil
+}
+
+// ObtenerRetasConCambioDeHorario obtiene las retas que ya llegaron a su fecha_hora sin haber comenzado
+// y las que están en juego y ya superaron la duración del partido
+func (repo *MySQLRetaRepository) ObtenerRetasConCambioDeHorario(ahora time.Time) ([]entities.Reta, error) {
 	query := `
-		SELECT m.id, m.reta_id, m.usuario_id, u.nombre, m.texto, m.creado_en
-		FROM mensajes_reta m
-		INNER JOIN usuarios u ON m.usuario_id = u.id
-		WHERE m.reta_id = ?
-		ORDER BY m.creado_en ASC
+		SELECT id, zona_id, titulo, fecha_hora, max_jugadores, jugadores_actuales, creador_id, creador_nombre, estado, created_at,
+		       latitud, longitud, cancha_id, duracion_minutos
+		FROM retas
+		WHERE (estado IN (?, ?) AND fecha_hora <= ?)
+		   OR (estado = ? AND DATE_ADD(fecha_hora, INTERVAL duracion_minutos MINUTE) <= ?)
 	`
-	rows, err := repo.db.Query(query, retaID)
+	rows, err := repo.db.Query(query,
+		entities.EstadoAbierta, entities.EstadoLlena, ahora,
+		entities.EstadoEnJuego, ahora,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("error al consultar mensajes: %w", err)
+		return nil, fmt.Errorf("error al consultar retas por horario: %w", err)
 	}
 	defer rows.Close()
 
-	mensajes := make([]entities.Mensaje, 0)
+	retas := make([]entities.Reta, 0)
 	for rows.Next() {
-		var msg entities.Mensaje
-		err := rows.Scan(&msg.ID, &msg.RetaID, &msg.UsuarioID, &msg.NombreUsuario, &msg.Texto, &msg.Timestamp)
+		var reta entities.Reta
+		var latitud, longitud sql.NullFloat64
+		var canchaID sql.NullString
+		err := rows.Scan(&reta.ID, &reta.ZonaID, &reta.Titulo, &reta.FechaHora, &reta.MaxJugadores,
+			&reta.JugadoresActuales, &reta.CreadorID, &reta.CreadorNombre, &reta.Estado, &reta.CreatedAt,
+			&latitud, &longitud, &canchaID, &reta.DuracionMinutos)
 		if err != nil {
-			return nil, fmt.Errorf("error al escanear mensaje: %w", err)
+			return nil, fmt.Errorf("error al escanear reta: %w", err)
 		}
-		mensajes = append(mensajes, msg)
+		reta.Ubicacion = ubicacionDesdeColumnas(latitud, longitud)
+		reta.CanchaID = canchaID.String
+		retas = append(retas, reta)
+	}
+
+	return retas, nil
+}
+
+// ActualizarEstado cambia el estado de la reta solo si sigue en `desde` (evita pisar cambios concurrentes)
+func (repo *MySQLRetaRepository) ActualizarEstado(retaID, desde, hacia string) (bool, error) {
+	result, err := repo.db.Exec("UPDATE retas SET estado = ? WHERE id = ? AND estado = ?", hacia, retaID, desde)
+	if err != nil {
+		return false, fmt.Errorf("error al actualizar estado: %w", err)
+	}
+
+	afectadas, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error al actualizar estado: %w", err)
+	}
+
+	return afectadas > 0, nil
+}
+
+// ObtenerRetaPorID obtiene los datos de una reta
+func (repo *MySQLRetaRepository) ObtenerRetaPorID(retaID string) (*entities.Reta, error) {
+	query := `
+		SELECT id, zona_id, titulo, fecha_hora, max_jugadores, jugadores_actuales, creador_id, creador_nombre, estado, created_at,
+		       latitud, longitud, cancha_id, duracion_minutos, serie_id, serie_fecha, serie_editada
+		FROM retas
+		WHERE id = ?
+	`
+	reta, err := escanearRetaDeSerie(repo.db.QueryRow(query, retaID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrRetaNoEncontrada
+		}
+		return nil, fmt.Errorf("error al consultar reta: %w", err)
+	}
+
+	return &reta, nil
+}
+
+// EsParticipante revisa si el usuario creó la reta, juega en ella o está en su lista de espera
+func (repo *MySQLRetaRepository) EsParticipante(retaID, usuarioID string) (bool, error) {
+	query := `
+		SELECT creador_id = ?
+		    OR EXISTS (SELECT 1 FROM reta_jugadores WHERE reta_id = retas.id AND usuario_id = ?)
+		    OR EXISTS (SELECT 1 FROM reta_lista_espera WHERE reta_id = retas.id AND usuario_id = ?)
+		FROM retas
+		WHERE id = ?
+	`
+	var participa bool
+	err := repo.db.QueryRow(query, usuarioID, usuarioID, usuarioID, retaID).Scan(&participa)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, entities.ErrRetaNoEncontrada
+		}
+		return false, fmt.Errorf("error al verificar participación: %w", err)
+	}
+	return participa, nil
+}
+
+// escanearRetaDeSerie lee una fila con las columnas de ObtenerRetaPorID, incluidas las de la serie
+func escanearRetaDeSerie(fila filaEscaneable) (entities.Reta, error) {
+	var reta entities.Reta
+	var latitud, longitud sql.NullFloat64
+	var canchaID, serieID sql.NullString
+	var serieFecha sql.NullTime
+	err := fila.Scan(
+		&reta.ID, &reta.ZonaID, &reta.Titulo, &reta.FechaHora, &reta.MaxJugadores,
+		&reta.JugadoresActuales, &reta.CreadorID, &reta.CreadorNombre, &reta.Estado, &reta.CreatedAt,
+		&latitud, &longitud, &canchaID, &reta.DuracionMinutos, &serieID, &serieFecha, &reta.OcurrenciaEditada,
+	)
+	if err != nil {
+		return reta, err
+	}
+	reta.Ubicacion = ubicacionDesdeColumnas(latitud, longitud)
+	reta.CanchaID = canchaID.String
+	reta.SerieID = serieID.String
+	if serieFecha.Valid {
+		reta.Ocurrencia = serieFecha.Time.Format("2006-01-02")
 	}
 
-	return mensajes, nil
+	return reta, nil
+}
+
+// ubicacionDesdeColumnas arma la ubicación de la cancha; las retas sin cancha guardan NULL
+func ubicacionDesdeColumnas(latitud, longitud sql.NullFloat64) *entities.Ubicacion {
+	if !latitud.Valid || !longitud.Valid {
+		return nil
+	}
+	return &entities.Ubicacion{Latitud: latitud.Float64, Longitud: longitud.Float64}
+}
+
+// columnaCancha retorna el cancha_id a guardar (NULL si la reta no aparta cancha)
+func columnaCancha(canchaID string) interface{} {
+	if canchaID == "" {
+		return nil
+	}
+	return canchaID
+}
+
+// verificarCanchaLibre bloquea la cancha de la reta y revisa que ninguna otra reta no cancelada la
+// ocupe en el horario [fecha_hora, fecha_hora + duración). El bloqueo de la fila de la cancha
+// serializa las transacciones que la apartan, así dos retas no pueden tomar el mismo horario a la vez
+func (repo *MySQLRetaRepository) verificarCanchaLibre(tx *sql.Tx, reta *entities.Reta) error {
+	if reta.CanchaID == "" {
+		return nil
+	}
+
+	var canchaID string
+	if err := tx.QueryRow("SELECT id FROM canchas WHERE id = ? FOR UPDATE", reta.CanchaID).Scan(&canchaID); err != nil {
+		if err == sql.ErrNoRows {
+			return core.ErrCanchaNoEncontrada
+		}
+		return fmt.Errorf("error al bloquear cancha: %w", err)
+	}
+
+	query := `
+		SELECT titulo, fecha_hora, duracion_minutos FROM retas
+		WHERE cancha_id = ? AND id <> ? AND estado <> ?
+		  AND fecha_hora < ? AND DATE_ADD(fecha_hora, INTERVAL duracion_minutos MINUTE) > ?
+		ORDER BY fecha_hora
+		LIMIT 1
+	`
+	var titulo string
+	var inicio time.Time
+	var duracion int
+	err := tx.QueryRow(query, reta.CanchaID, reta.ID, entities.EstadoCancelada, reta.FechaHora.Add(reta.Duracion()), reta.FechaHora).
+		Scan(&titulo, &inicio, &duracion)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error al revisar horario de la cancha: %w", err)
+	}
+
+	fin := inicio.Add(time.Duration(duracion) * time.Minute)
+	return fmt.Errorf("%w: \"%s\" de %s a %s", entities.ErrCanchaOcupada, titulo, inicio.Format("2006-01-02 15:04"), fin.Format("15:04"))
+}
+
+// columnasUbicacion retorna los valores de latitud y longitud a guardar (NULL si no hay cancha)
+func columnasUbicacion(ubicacion *entities.Ubicacion) (interface{}, interface{}) {
+	if ubicacion == nil {
+		return nil, nil
+	}
+	return ubicacion.Latitud, ubicacion.Longitud
+}
+
+// CrearReta crea una nueva reta e inserta al creador como primer jugador
+func (repo *MySQLRetaRepository) CrearReta(reta *entities.Reta) (*entities.Reta, *entities.Jugador, error) {
+	// Iniciar transacción
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Generar UUID para la reta
+	retaID := uuid.New().String()
+	reta.ID = retaID
+
+	// Apartar la cancha: el horario se revisa con la cancha bloqueada hasta el commit
+	if err := repo.verificarCanchaLibre(tx, reta); err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+
+	// Insertar la reta con el creador como primer jugador
+	var primerJugador *entities.Jugador
+	primerJugador, err = repo.insertarReta(tx, reta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Commit
+	err = tx.Commit()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error al hacer commit: %w", err)
+	}
+
+	reta.JugadoresActuales = 1
+
+	return reta, primerJugador, nil
+}
+
+// insertarReta inserta la reta (con su serie, si la tiene) y al creador como primer jugador dentro
+// de la transacción recibida
+func (repo *MySQLRetaRepository) insertarReta(tx *sql.Tx, reta *entities.Reta) (*entities.Jugador, error) {
+	insertRetaQuery := `
+		INSERT INTO retas (id, zona_id, titulo, fecha_hora, max_jugadores, jugadores_actuales, creador_id, creador_nombre, estado,
+		                   latitud, longitud, cancha_id, duracion_minutos, serie_id, serie_fecha, created_at)
+		VALUES (?, ?, ?, ?, ?, 1, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())
+	`
+	// El creador ocupa el primer lugar, así que una reta de un solo jugador nace llena
+	reta.Estado = entities.EstadoPorCupo(1, reta.MaxJugadores)
+	latitud, longitud := columnasUbicacion(reta.Ubicacion)
+	var serieID, serieFecha interface{}
+	if reta.SerieID != "" {
+		serieID, serieFecha = reta.SerieID, reta.Ocurrencia
+	}
+	_, err := tx.Exec(insertRetaQuery, reta.ID, reta.ZonaID, reta.Titulo, reta.FechaHora, reta.MaxJugadores, reta.CreadorID, reta.CreadorNombre, reta.Estado,
+		latitud, longitud, columnaCancha(reta.CanchaID), reta.DuracionMinutos, serieID, serieFecha)
+	if err != nil {
+		return nil, fmt.Errorf("error al insertar reta: %w", err)
+	}
+
+	jugadorID := uuid.New().String()
+	insertJugadorQuery := "INSERT INTO reta_jugadores (id, reta_id, usuario_id, nombre_jugador) VALUES (?, ?, ?, ?)"
+	_, err = tx.Exec(insertJugadorQuery, jugadorID, reta.ID, reta.CreadorID, reta.CreadorNombre)
+	if err != nil {
+		return nil, fmt.Errorf("error al insertar primer jugador: %w", err)
+	}
+
+	return &entities.Jugador{
+		ID:        jugadorID,
+		UsuarioID: reta.CreadorID,
+		Nombre:    reta.CreadorNombre,
+		RetaID:    reta.ID,
+	}, nil
+}
+
+// ObtenerRetasPorZona obtiene las retas de una zona con sus jugadores; por defecto solo las abiertas y llenas
+func (repo *MySQLRetaRepository) ObtenerRetasPorZona(zonaID string, filtro entities.FiltroRetas) ([]entities.RetaInfo, error) {
+	condiciones, args := condicionesFiltro(filtro)
+	query := `
+		SELECT r.id, r.titulo, r.fecha_hora, r.max_jugadores, r.jugadores_actuales, r.creador_id, r.estado,
+		       r.latitud, r.longitud, r.cancha_id, r.duracion_minutos, r.serie_id, rj.id as jugador_id, rj.usuario_id, u.nombre
+		FROM retas r
+		LEFT JOIN reta_jugadores rj ON r.id = rj.reta_id
+		LEFT JOIN usuarios u ON rj.usuario_id = u.id
+		WHERE r.zona_id = ?` + condiciones + `
+		ORDER BY r.created_at DESC, rj.created_at ASC
+	`
+	rows, err := repo.db.Query(query, append([]interface{}{zonaID}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar retas: %w", err)
+	}
+	defer rows.Close()
+
+	retasMap := make(map[string]*entities.RetaInfo)
+	orden := []string{}
+
+	for rows.Next() {
+		var retaID, titulo, creadorID, estado string
+		var fechaHora time.Time
+		var maxJugadores, jugadoresActuales int
+		var latitud, longitud sql.NullFloat64
+		var canchaID, serieID, jugadorID, usuarioID, nombreJugador sql.NullString
+		var duracionMinutos int
+
+		err := rows.Scan(&retaID, &titulo, &fechaHora, &maxJugadores, &jugadoresActuales, &creadorID, &estado,
+			&latitud, &longitud, &canchaID, &duracionMinutos, &serieID, &jugadorID, &usuarioID, &nombreJugador)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear reta: %w", err)
+		}
+
+		if _, exists := retasMap[retaID]; !exists {
+			retasMap[retaID] = &entities.RetaInfo{
+				ID:                retaID,
+				ZonaID:            zonaID,
+				Titulo:            titulo,
+				FechaHora:         fechaHora.Format("2006-01-02 15:04:05"),
+				MaxJugadores:      maxJugadores,
+				JugadoresActuales: jugadoresActuales,
+				CreadorID:         creadorID,
+				Estado:            estado,
+				ListaJugadores:    []entities.Jugador{},
+				ListaEspera:       []entities.Jugador{},
+				Ubicacion:         ubicacionDesdeColumnas(latitud, longitud),
+				CanchaID:          canchaID.String,
+				DuracionMinutos:   duracionMinutos,
+				SerieID:           serieID.String,
+			}
+			orden = append(orden, retaID)
+		}
+
+		if jugadorID.Valid {
+			retasMap[retaID].ListaJugadores = append(retasMap[retaID].ListaJugadores, entities.Jugador{
+				ID:        jugadorID.String,
+				UsuarioID: usuarioID.String,
+				Nombre:    nombreJugador.String,
+				RetaID:    retaID,
+			})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al recorrer retas: %w", err)
+	}
+
+	// Cargar las listas de espera de todas las retas de la zona en una sola consulta
+	esperaQuery := `
+		SELECT le.reta_id, le.id, le.usuario_id, u.nombre
+		FROM reta_lista_espera le
+		INNER JOIN retas r ON le.reta_id = r.id
+		INNER JOIN usuarios u ON le.usuario_id = u.id
+		WHERE r.zona_id = ?
+		ORDER BY le.reta_id, le.posicion ASC
+	`
+	esperaRows, err := repo.db.Query(esperaQuery, zonaID)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar listas de espera: %w", err)
+	}
+	defer esperaRows.Close()
+
+	for esperaRows.Next() {
+		var retaID string
+		var jugador entities.Jugador
+		if err := esperaRows.Scan(&retaID, &jugador.ID, &jugador.UsuarioID, &jugador.Nombre); err != nil {
+			return nil, fmt.Errorf("error al escanear lista de espera: %w", err)
+		}
+		if info, ok := retasMap[retaID]; ok {
+			jugador.RetaID = retaID
+			jugador.Posicion = len(info.ListaEspera) + 1
+			info.ListaEspera = append(info.ListaEspera, jugador)
+		}
+	}
+
+	if err := repo.cargarUltimosMensajes(retasMap, orden); err != nil {
+		return nil, err
+	}
+
+	if filtro.UsuarioID != "" {
+		noLeidos, err := repo.contarNoLeidos(filtro.UsuarioID, orden)
+		if err != nil {
+			return nil, err
+		}
+		for retaID, total := range noLeidos {
+			retasMap[retaID].NoLeidos = total
+		}
+	}
+
+	result := make([]entities.RetaInfo, 0, len(orden))
+	for _, id := range orden {
+		result = append(result, *retasMap[id])
+	}
+	return result, nil
+}
+
+// cargarUltimosMensajes agrega a cada reta sus últimos mensajes y el total del chat en una sola consulta
+func (repo *MySQLRetaRepository) cargarUltimosMensajes(retasMap map[string]*entities.RetaInfo, retaIDs []string) error {
+	for _, id := range retaIDs {
+		retasMap[id].HistorialChat = []entities.Mensaje{}
+	}
+	if len(retaIDs) == 0 {
+		return nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(retaIDs)), ", ")
+	args := make([]interface{}, 0, len(retaIDs)+1)
+	for _, id := range retaIDs {
+		args = append(args, id)
+	}
+	args = append(args, entities.MensajesEnSnapshot)
+
+	query := `
+		SELECT id, reta_id, usuario_id, nombre, texto, creado_en, editado_en, eliminado_en, total
+		FROM (
+			SELECT ` + columnasMensaje + `,
+			       ROW_NUMBER() OVER (PARTITION BY m.reta_id ORDER BY m.creado_en DESC, m.id DESC) AS fila,
+			       COUNT(*) OVER (PARTITION BY m.reta_id) AS total
+			FROM mensajes_reta m
+			INNER JOIN usuarios u ON m.usuario_id = u.id
+			WHERE m.reta_id IN (` + placeholders + `)
+		) ultimos
+		WHERE fila <= ?
+		ORDER BY reta_id, creado_en ASC, id ASC
+	`
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("error al consultar mensajes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var total int
+		msg, err := escanearMensaje(rows, &total)
+		if err != nil {
+			return fmt.Errorf("error al escanear mensaje: %w", err)
+		}
+		if info, ok := retasMap[msg.RetaID]; ok {
+			info.HistorialChat = append(info.HistorialChat, msg)
+			info.TotalMensajes = total
+		}
+	}
+
+	return rows.Err()
+}
+
+// condicionesFiltro arma las condiciones SQL (sobre el alias r) y sus argumentos a partir del filtro
+func condicionesFiltro(filtro entities.FiltroRetas) (string, []interface{}) {
+	condiciones := ""
+	args := []interface{}{}
+
+	switch {
+	case filtro.Estado != "":
+		condiciones += " AND r.estado = ?"
+		args = append(args, filtro.Estado)
+	case !filtro.IncluirCerradas:
+		condiciones += " AND r.estado IN (?, ?)"
+		args = append(args, entities.EstadoAbierta, entities.EstadoLlena)
+	}
+
+	if filtro.Desde != nil {
+		condiciones += " AND r.fecha_hora >= ?"
+		args = append(args, *filtro.Desde)
+	}
+	if filtro.Hasta != nil {
+		condiciones += " AND r.fecha_hora < ?"
+		args = append(args, *filtro.Hasta)
+	}
+	if filtro.ConLugares {
+		condiciones += " AND r.jugadores_actuales < r.max_jugadores"
+	}
+
+	return condiciones, args
+}
+
+// ObtenerJugadoresDeReta obtiene la lista de jugadores confirmados con nombre real de usuarios
+func (repo *MySQLRetaRepository) ObtenerJugadoresDeReta(retaID string) ([]entities.Jugador, error) {
+	query := `
+		SELECT rj.id, rj.usuario_id, u.nombre
+		FROM reta_jugadores rj
+		INNER JOIN usuarios u ON rj.usuario_id = u.id
+		WHERE rj.reta_id = ?
+		ORDER BY rj.created_at ASC
+	`
+	rows, err := repo.db.Query(query, retaID)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar jugadores: %w", err)
+	}
+	defer rows.Close()
+
+	jugadores := make([]entities.Jugador, 0)
+	for rows.Next() {
+		var jugador entities.Jugador
+		err := rows.Scan(&jugador.ID, &jugador.UsuarioID, &jugador.Nombre)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear jugador: %w", err)
+		}
+		jugadores = append(jugadores, jugador)
+	}
+
+	return jugadores, nil
+}
+
+// GuardarMensaje inserta un mensaje de chat y retorna el mensaje con el nombre real del usuario (JOIN)
+func (repo *MySQLRetaRepository) GuardarMensaje(mensaje entities.Mensaje) (*entities.Mensaje, error) {
+	mensajeID := uuid.New().String()
+
+	insertQuery := "INSERT INTO mensajes_reta (id, reta_id, usuario_id, texto) VALUES (?, ?, ?, ?)"
+	_, err := repo.db.Exec(insertQuery, mensajeID, mensaje.RetaID, mensaje.UsuarioID, mensaje.Texto)
+	if err != nil {
+		return nil, fmt.Errorf("error al guardar mensaje: %w", err)
+	}
+
+	// Recuperar el mensaje con JOIN a usuarios para obtener el nombre real
+	resultado, err := repo.mensajePorID(mensajeID)
+	if err != nil {
+		return nil, fmt.Errorf("error al recuperar mensaje enriquecido: %w", err)
+	}
+
+	return resultado, nil
+}
+
+// ObtenerMensajesDeReta obtiene una página del historial de una reta usando (creado_en, id) como cursor,
+// lo que permite paginar sin OFFSET aunque lleguen mensajes nuevos mientras se consulta
+func (repo *MySQLRetaRepository) ObtenerMensajesDeReta(retaID string, consulta entities.ConsultaMensajes) (*entities.PaginaMensajes, error) {
+	condicion := ""
+	args := []interface{}{retaID}
+
+	switch {
+	case consulta.Antes != "" || consulta.Despues != "":
+		cursorID := consulta.Antes
+		operador := "<"
+		if consulta.Despues != "" {
+			cursorID = consulta.Despues
+			operador = ">"
+		}
+
+		var cursorFecha time.Time
+		err := repo.db.QueryRow("SELECT creado_en FROM mensajes_reta WHERE id = ? AND reta_id = ?", cursorID, retaID).Scan(&cursorFecha)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, entities.ErrMensajeNoEncontrado
+			}
+			return nil, fmt.Errorf("error al consultar cursor de mensajes: %w", err)
+		}
+
+		condicion = " AND (m.creado_en " + operador + " ? OR (m.creado_en = ? AND m.id " + operador + " ?))"
+		args = append(args, cursorFecha, cursorFecha, cursorID)
+	case consulta.AntesDe != nil:
+		condicion = " AND m.creado_en < ?"
+		args = append(args, *consulta.AntesDe)
+	case consulta.DespuesDe != nil:
+		condicion = " AND m.creado_en > ?"
+		args = append(args, *consulta.DespuesDe)
+	}
+
+	// Hacia atrás se leen los más recientes primero y luego se invierten; se pide uno extra para saber si hay más
+	orden := "DESC"
+	if consulta.HaciaAdelante() {
+		orden = "ASC"
+	}
+	args = append(args, consulta.Limite+1)
+
+	query := `
+		SELECT ` + columnasMensaje + `
+		FROM mensajes_reta m
+		INNER JOIN usuarios u ON m.usuario_id = u.id
+		WHERE m.reta_id = ?` + condicion + `
+		ORDER BY m.creado_en ` + orden + `, m.id ` + orden + `
+		LIMIT ?
+	`
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar mensajes: %w", err)
+	}
+	defer rows.Close()
+
+	mensajes := make([]entities.Mensaje, 0, consulta.Limite+1)
+	for rows.Next() {
+		msg, err := escanearMensaje(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear mensaje: %w", err)
+		}
+		mensajes = append(mensajes, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al recorrer mensajes: %w", err)
+	}
+
+	pagina := &entities.PaginaMensajes{}
+	if len(mensajes) > consulta.Limite {
+		pagina.HayMas = true
+		mensajes = mensajes[:consulta.Limite]
+	}
+	if !consulta.HaciaAdelante() {
+		for i, j := 0, len(mensajes)-1; i < j; i, j = i+1, j-1 {
+			mensajes[i], mensajes[j] = mensajes[j], mensajes[i]
+		}
+	}
+	pagina.Mensajes = mensajes
+
+	return pagina, nil
+}
+
+// ContarMensajesDeReta retorna cuántos mensajes tiene el chat de una reta
+func (repo *MySQLRetaRepository) ContarMensajesDeReta(retaID string) (int, error) {
+	var total int
+	err := repo.db.QueryRow("SELECT COUNT(*) FROM mensajes_reta WHERE reta_id = ?", retaID).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("error al contar mensajes: %w", err)
+	}
+	return total, nil
+}
+
+// MarcarLeido avanza el puntero de lectura del usuario usando el mismo orden (creado_en, id) que la paginación;
+// si el mensaje es anterior al último leído, el puntero se queda como estaba
+func (repo *MySQLRetaRepository) MarcarLeido(retaID, usuarioID, mensajeID string) (*entities.LecturaChat, error) {
+	var creadoEn time.Time
+	err := repo.db.QueryRow("SELECT creado_en FROM mensajes_reta WHERE id = ? AND reta_id = ?", mensajeID, retaID).Scan(&creadoEn)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrMensajeNoEncontrado
+		}
+		return nil, fmt.Errorf("error al consultar mensaje: %w", err)
+	}
+
+	// ultimo_leido_id se asigna antes que ultimo_leido_en para comparar contra el puntero anterior
+	upsertQuery := `
+		INSERT INTO mensajes_leidos (reta_id, usuario_id, ultimo_leido_id, ultimo_leido_en)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			ultimo_leido_id = IF((VALUES(ultimo_leido_en), VALUES(ultimo_leido_id)) > (ultimo_leido_en, ultimo_leido_id),
+			                     VALUES(ultimo_leido_id), ultimo_leido_id),
+			ultimo_leido_en = GREATEST(ultimo_leido_en, VALUES(ultimo_leido_en))
+	`
+	if _, err := repo.db.Exec(upsertQuery, retaID, usuarioID, mensajeID, creadoEn); err != nil {
+		return nil, fmt.Errorf("error al guardar lectura: %w", err)
+	}
+
+	lectura := &entities.LecturaChat{RetaID: retaID, UsuarioID: usuarioID}
+	err = repo.db.QueryRow(
+		"SELECT ultimo_leido_id, actualizado_en FROM mensajes_leidos WHERE reta_id = ? AND usuario_id = ?",
+		retaID, usuarioID,
+	).Scan(&lectura.UltimoLeido, &lectura.LeidoEn)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar lectura: %w", err)
+	}
+
+	noLeidos, err := repo.contarNoLeidos(usuarioID, []string{retaID})
+	if err != nil {
+		return nil, err
+	}
+	lectura.NoLeidos = noLeidos[retaID]
+
+	return lectura, nil
+}
+
+// contarNoLeidos cuenta por reta los mensajes de otros usuarios posteriores al último leído; si el usuario
+// nunca ha marcado una lectura en esa reta, cuentan todos. Las retas sin pendientes no aparecen en el mapa
+func (repo *MySQLRetaRepository) contarNoLeidos(usuarioID string, retaIDs []string) (map[string]int, error) {
+	noLeidos := make(map[string]int)
+	if len(retaIDs) == 0 {
+		return noLeidos, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(retaIDs)), ", ")
+	args := make([]interface{}, 0, len(retaIDs)+2)
+	args = append(args, usuarioID)
+	for _, id := range retaIDs {
+		args = append(args, id)
+	}
+	args = append(args, usuarioID)
+
+	query := `
+		SELECT m.reta_id, COUNT(*)
+		FROM mensajes_reta m
+		LEFT JOIN mensajes_leidos l ON l.reta_id = m.reta_id AND l.usuario_id = ?
+		WHERE m.reta_id IN (` + placeholders + `)
+		  AND m.usuario_id <> ?
+		  AND m.eliminado_en IS NULL
+		  AND (l.reta_id IS NULL
+		       OR m.creado_en > l.ultimo_leido_en
+		       OR (m.creado_en = l.ultimo_leido_en AND m.id > l.ultimo_leido_id))
+		GROUP BY m.reta_id
+	`
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error al contar mensajes no leídos: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var retaID string
+		var total int
+		if err := rows.Scan(&retaID, &total); err != nil {
+			return nil, fmt.Errorf("error al escanear mensajes no leídos: %w", err)
+		}
+		noLeidos[retaID] = total
+	}
+
+	return noLeidos, rows.Err()
+}
+
+// columnasMensaje son las columnas (alias m para mensajes_reta y u para usuarios) que lee escanearMensaje
+const columnasMensaje = "m.id, m.reta_id, m.usuario_id, u.nombre, m.texto, m.creado_en, m.editado_en, m.eliminado_en"
+
+// filaEscaneable es una fila de *sql.Row o *sql.Rows
+type filaEscaneable interface {
+	Scan(dest ...interface{}) error
+}
+
+// escanearMensaje lee las columnas de columnasMensaje seguidas de las columnas extra de la consulta
+func escanearMensaje(fila filaEscaneable, extra ...interface{}) (entities.Mensaje, error) {
+	var msg entities.Mensaje
+	var editadoEn, eliminadoEn sql.NullTime
+
+	destinos := []interface{}{
+		&msg.ID, &msg.RetaID, &msg.UsuarioID, &msg.NombreUsuario, &msg.Texto, &msg.Timestamp, &editadoEn, &eliminadoEn,
+	}
+	if err := fila.Scan(append(destinos, extra...)...); err != nil {
+		return msg, err
+	}
+
+	if editadoEn.Valid {
+		msg.EditadoEn = &editadoEn.Time
+	}
+	if eliminadoEn.Valid {
+		msg.Eliminado = true
+		msg.EliminadoEn = &eliminadoEn.Time
+	}
+	return msg, nil
+}
+
+// mensajePorID obtiene un mensaje con el nombre de su autor
+func (repo *MySQLRetaRepository) mensajePorID(mensajeID string) (*entities.Mensaje, error) {
+	query := `
+		SELECT ` + columnasMensaje + `
+		FROM mensajes_reta m
+		INNER JOIN usuarios u ON m.usuario_id = u.id
+		WHERE m.id = ?
+	`
+	msg, err := escanearMensaje(repo.db.QueryRow(query, mensajeID))
+	if err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// ObtenerMensaje obtiene un mensaje del chat de una reta (incluso si fue eliminado)
+func (repo *MySQLRetaRepository) ObtenerMensaje(retaID, mensajeID string) (*entities.Mensaje, error) {
+	msg, err := repo.mensajePorID(mensajeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrMensajeNoEncontrado
+		}
+		return nil, fmt.Errorf("error al consultar mensaje: %w", err)
+	}
+	if msg.RetaID != retaID {
+		return nil, entities.ErrMensajeNoEncontrado
+	}
+	return msg, nil
+}
+
+// EditarMensaje reemplaza el texto de un mensaje no eliminado y registra editado_en
+func (repo *MySQLRetaRepository) EditarMensaje(mensajeID, texto string) (*entities.Mensaje, error) {
+	query := "UPDATE mensajes_reta SET texto = ?, editado_en = CURRENT_TIMESTAMP(3) WHERE id = ? AND eliminado_en IS NULL"
+	result, err := repo.db.Exec(query, texto, mensajeID)
+	if err != nil {
+		return nil, fmt.Errorf("error al editar mensaje: %w", err)
+	}
+
+	// Si se eliminó entre la validación y el UPDATE no hay filas afectadas
+	afectadas, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("error al verificar edición: %w", err)
+	}
+	if afectadas == 0 {
+		return nil, entities.ErrMensajeEliminado
+	}
+
+	msg, err := repo.mensajePorID(mensajeID)
+	if err != nil {
+		return nil, fmt.Errorf("error al recuperar mensaje editado: %w", err)
+	}
+	return msg, nil
+}
+
+// EliminarMensaje deja el mensaje como marcador: borra el texto y registra quién y cuándo lo eliminó;
+// si la eliminación es por moderación, su evento de auditoría va en la misma transacción
+func (repo *MySQLRetaRepository) EliminarMensaje(mensajeID, eliminadoPor string, auditoria *entities.EventoModeracion) (*entities.Mensaje, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE mensajes_reta
+		SET texto = '', eliminado_en = CURRENT_TIMESTAMP(3), eliminado_por = ?
+		WHERE id = ? AND eliminado_en IS NULL
+	`
+	result, err := tx.Exec(query, eliminadoPor, mensajeID)
+	if err != nil {
+		return nil, fmt.Errorf("error al eliminar mensaje: %w", err)
+	}
+
+	afectadas, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("error al verificar eliminación: %w", err)
+	}
+	if afectadas == 0 {
+		return nil, entities.ErrMensajeEliminado
+	}
+
+	if auditoria != nil {
+		if err := registrarAuditoria(tx, auditoria); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error al confirmar transacción: %w", err)
+	}
+
+	msg, err := repo.mensajePorID(mensajeID)
+	if err != nil {
+		return nil, fmt.Errorf("error al recuperar mensaje eliminado: %w", err)
+	}
+	return msg, nil
+}
+
+// ObtenerTextosRecientes retorna los textos que el usuario envió al chat desde la fecha dada (sin eliminados)
+func (repo *MySQLRetaRepository) ObtenerTextosRecientes(retaID, usuarioID string, desde time.Time) ([]string, error) {
+	query := `
+		SELECT texto
+		FROM mensajes_reta
+		WHERE reta_id = ? AND usuario_id = ? AND creado_en >= ? AND eliminado_en IS NULL
+		ORDER BY creado_en DESC
+	`
+	rows, err := repo.db.Query(query, retaID, usuarioID, desde)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar mensajes recientes: %w", err)
+	}
+	defer rows.Close()
+
+	textos := []string{}
+	for rows.Next() {
+		var texto string
+		if err := rows.Scan(&texto); err != nil {
+			return nil, fmt.Errorf("error al escanear mensaje reciente: %w", err)
+		}
+		textos = append(textos, texto)
+	}
+
+	return textos, rows.Err()
+}
+
+// GuardarSancion aplica una sanción reemplazando la que el usuario tuviera en la reta
+func (repo *MySQLRetaRepository) GuardarSancion(sancion *entities.Sancion) error {
+	query := `
+		INSERT INTO reta_sanciones (reta_id, usuario_id, tipo, motivo, hasta, creado_por, creado_en)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			tipo = VALUES(tipo), motivo = VALUES(motivo), hasta = VALUES(hasta),
+			creado_por = VALUES(creado_por), creado_en = VALUES(creado_en)
+	`
+	_, err := repo.db.Exec(query, sancion.RetaID, sancion.UsuarioID, sancion.Tipo, sancion.Motivo,
+		sancion.Hasta, sancion.CreadoPor, sancion.CreadoEn)
+	if err != nil {
+		return fmt.Errorf("error al guardar sanción: %w", err)
+	}
+	return nil
+}
+
+// ObtenerSancion obtiene la sanción del usuario en la reta (vigente o vencida); nil si no tiene
+func (repo *MySQLRetaRepository) ObtenerSancion(retaID, usuarioID string) (*entities.Sancion, error) {
+	query := `
+		SELECT reta_id, usuario_id, tipo, motivo, hasta, creado_por, creado_en
+		FROM reta_sanciones
+		WHERE reta_id = ? AND usuario_id = ?
+	`
+	sancion, err := escanearSancion(repo.db.QueryRow(query, retaID, usuarioID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error al consultar sanción: %w", err)
+	}
+	return &sancion, nil
+}
+
+// ObtenerSancionesActivas obtiene las sanciones vigentes del chat de una reta
+func (repo *MySQLRetaRepository) ObtenerSancionesActivas(retaID string, ahora time.Time) ([]entities.Sancion, error) {
+	query := `
+		SELECT reta_id, usuario_id, tipo, motivo, hasta, creado_por, creado_en
+		FROM reta_sanciones
+		WHERE reta_id = ? AND (hasta IS NULL OR hasta > ?)
+		ORDER BY creado_en DESC
+	`
+	rows, err := repo.db.Query(query, retaID, ahora)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar sanciones: %w", err)
+	}
+	defer rows.Close()
+
+	sanciones := []entities.Sancion{}
+	for rows.Next() {
+		sancion, err := escanearSancion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear sanción: %w", err)
+		}
+		sanciones = append(sanciones, sancion)
+	}
+
+	return sanciones, rows.Err()
+}
+
+// QuitarSancion elimina la sanción del usuario; retorna si había una
+func (repo *MySQLRetaRepository) QuitarSancion(retaID, usuarioID string) (bool, error) {
+	result, err := repo.db.Exec("DELETE FROM reta_sanciones WHERE reta_id = ? AND usuario_id = ?", retaID, usuarioID)
+	if err != nil {
+		return false, fmt.Errorf("error al quitar sanción: %w", err)
+	}
+
+	afectadas, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error al verificar sanción: %w", err)
+	}
+	return afectadas > 0, nil
+}
+
+// escanearSancion lee una fila de reta_sanciones en el orden de las consultas de sanciones
+func escanearSancion(fila filaEscaneable) (entities.Sancion, error) {
+	var sancion entities.Sancion
+	var motivo sql.NullString
+	var hasta sql.NullTime
+
+	err := fila.Scan(&sancion.RetaID, &sancion.UsuarioID, &sancion.Tipo, &motivo, &hasta, &sancion.CreadoPor, &sancion.CreadoEn)
+	if err != nil {
+		return sancion, err
+	}
+
+	sancion.Motivo = motivo.String
+	if hasta.Valid {
+		sancion.Hasta = &hasta.Time
+	}
+	return sancion, nil
+}
+
+// ForzarCancelacion marca la reta como cancelada desde cualquier estado que no sea terminal y registra
+// la cancelación en la auditoría
+func (repo *MySQLRetaRepository) ForzarCancelacion(retaID string, auditoria *entities.EventoModeracion) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE retas SET estado = ? WHERE id = ? AND estado IN (?, ?, ?)"
+	result, err := tx.Exec(query, entities.EstadoCancelada, retaID, entities.EstadoAbierta, entities.EstadoLlena, entities.EstadoEnJuego)
+	if err != nil {
+		return fmt.Errorf("error al cancelar reta: %w", err)
+	}
+
+	afectadas, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al cancelar reta: %w", err)
+	}
+	if afectadas == 0 {
+		// El scheduler la finalizó o alguien más la canceló entre la validación y el UPDATE
+		return entities.ErrRetaCerrada
+	}
+
+	if err := registrarAuditoria(tx, auditoria); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar transacción: %w", err)
+	}
+	return nil
+}
+
+// PurgarMensajes deja como marcadores los mensajes no eliminados del chat, o solo los del usuario indicado,
+// y registra la purga en la auditoría
+func (repo *MySQLRetaRepository) PurgarMensajes(retaID, usuarioID, eliminadoPor, motivo string) (int, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE mensajes_reta
+		SET texto = '', eliminado_en = CURRENT_TIMESTAMP(3), eliminado_por = ?
+		WHERE reta_id = ? AND eliminado_en IS NULL
+	`
+	args := []interface{}{eliminadoPor, retaID}
+	if usuarioID != "" {
+		query += " AND usuario_id = ?"
+		args = append(args, usuarioID)
+	}
+
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("error al purgar mensajes: %w", err)
+	}
+
+	afectadas, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error al verificar purga: %w", err)
+	}
+
+	err = registrarAuditoria(tx, &entities.EventoModeracion{
+		Evento:   entities.EventoPurgarMensajes,
+		ActorID:  eliminadoPor,
+		Objetivo: retaID,
+		Detalle:  entities.DetallePurga(int(afectadas), usuarioID, motivo),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("error al confirmar transacción: %w", err)
+	}
+	return int(afectadas), nil
+}
+
+// registrarAuditoria guarda un evento de moderación en la tabla auditoria dentro de la transacción de la acción
+func registrarAuditoria(tx *sql.Tx, evento *entities.EventoModeracion) error {
+	query := "INSERT INTO auditoria (id, evento, actor_id, objetivo, detalle, created_at) VALUES (?, ?, ?, ?, ?, ?)"
+	_, err := tx.Exec(query, uuid.New().String(), evento.Evento, evento.ActorID, evento.Objetivo, evento.Detalle, time.Now())
+	if err != nil {
+		return fmt.Errorf("error al registrar evento de auditoría: %w", err)
+	}
+	return nil
+}
+
+// ObtenerZona obtiene el centro y el radio de una zona
+func (repo *MySQLRetaRepository) ObtenerZona(zonaID string) (*entities.Zona, error) {
+	var zona entities.Zona
+	query := "SELECT id, nombre, latitud, longitud, radio_km FROM zonas WHERE id = ?"
+	err := repo.db.QueryRow(query, zonaID).Scan(&zona.ID, &zona.Nombre, &zona.Centro.Latitud, &zona.Centro.Longitud, &zona.RadioKm)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrZonaNoEncontrada
+		}
+		return nil, fmt.Errorf("error al consultar zona: %w", err)
+	}
+	return &zona, nil
+}
+
+// ObtenerCuenta obtiene el rol y el baneo vigentes del usuario
+func (repo *MySQLRetaRepository) ObtenerCuenta(usuarioID string) (*entities.CuentaUsuario, error) {
+	cuenta := entities.CuentaUsuario{UsuarioID: usuarioID}
+	var baneadoHasta sql.NullTime
+	err := repo.db.QueryRow("SELECT rol, baneado_hasta FROM usuarios WHERE id = ?", usuarioID).Scan(&cuenta.Rol, &baneadoHasta)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrUsuarioNoEncontrado
+		}
+		return nil, fmt.Errorf("error al consultar usuario: %w", err)
+	}
+	if baneadoHasta.Valid {
+		cuenta.BaneadoHasta = &baneadoHasta.Time
+	}
+	return &cuenta, nil
+}
+
+// ObtenerCancha obtiene los datos de una cancha que se usan para apartarla
+func (repo *MySQLRetaRepository) ObtenerCancha(canchaID string) (*core.Cancha, error) {
+	query := `
+		SELECT id, zona_id, nombre, capacidad, latitud, longitud,
+		       TIME_FORMAT(hora_apertura, '%H:%i'), TIME_FORMAT(hora_cierre, '%H:%i')
+		FROM canchas
+		WHERE id = ?
+	`
+	var cancha core.Cancha
+	var latitud, longitud sql.NullFloat64
+	err := repo.db.QueryRow(query, canchaID).Scan(&cancha.ID, &cancha.ZonaID, &cancha.Nombre, &cancha.Capacidad,
+		&latitud, &longitud, &cancha.HoraApertura, &cancha.HoraCierre)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, core.ErrCanchaNoEncontrada
+		}
+		return nil, fmt.Errorf("error al consultar cancha: %w", err)
+	}
+	if latitud.Valid && longitud.Valid {
+		cancha.Latitud = &latitud.Float64
+		cancha.Longitud = &longitud.Float64
+	}
+	return &cancha, nil
+}
+
+// BuscarRetasCercanas calcula la distancia con el haversine en SQL. Las retas sin cancha se ubican en el
+// centro de su zona; el rectángulo de la búsqueda filtra primero para no calcular la distancia de
+// todas las retas próximas
+func (repo *MySQLRetaRepository) BuscarRetasCercanas(busqueda entities.BusquedaCercana) ([]entities.RetaCercana, error) {
+	latMin, latMax, lngMin, lngMax := busqueda.Caja()
+
+	condiciones := ""
+	if busqueda.ConLugares {
+		condiciones = " AND c.jugadores_actuales < c.max_jugadores"
+	}
+
+	query := `
+		SELECT c.id, c.zona_id, c.zona_nombre, c.titulo, c.fecha_hora, c.max_jugadores, c.jugadores_actuales, c.estado,
+		       c.duracion_minutos, c.cancha_id, c.cancha_nombre, c.lat, c.lng, c.aproximada,
+		       ? * 2 * ASIN(LEAST(1, SQRT(
+		           POW(SIN(RADIANS(c.lat - ?) / 2), 2) +
+		           COS(RADIANS(?)) * COS(RADIANS(c.lat)) * POW(SIN(RADIANS(c.lng - ?) / 2), 2)
+		       ))) AS distancia
+		FROM (
+			SELECT r.id, r.zona_id, z.nombre AS zona_nombre, r.titulo, r.fecha_hora, r.max_jugadores,
+			       r.jugadores_actuales, r.estado, r.duracion_minutos, r.cancha_id, ca.nombre AS cancha_nombre,
+			       COALESCE(r.latitud, z.latitud) AS lat, COALESCE(r.longitud, z.longitud) AS lng,
+			       r.latitud IS NULL AS aproximada
+			FROM retas r
+			INNER JOIN zonas z ON z.id = r.zona_id
+			LEFT JOIN canchas ca ON ca.id = r.cancha_id
+			WHERE r.estado IN (?, ?) AND r.fecha_hora >= ?
+		) c
+		WHERE c.lat BETWEEN ? AND ? AND c.lng BETWEEN ? AND ?` + condiciones + `
+		HAVING distancia <= ?
+		ORDER BY distancia ASC, c.fecha_hora ASC
+		LIMIT ?
+	`
+	posicion := busqueda.Posicion
+	rows, err := repo.db.Query(query,
+		entities.RadioTierraKm, posicion.Latitud, posicion.Latitud, posicion.Longitud,
+		entities.EstadoAbierta, entities.EstadoLlena, time.Now(),
+		latMin, latMax, lngMin, lngMax,
+		busqueda.RadioKm, busqueda.Limite,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar retas cercanas: %w", err)
+	}
+	defer rows.Close()
+
+	cercanas := []entities.RetaCercana{}
+	for rows.Next() {
+		var reta entities.Reta
+		var zonaNombre string
+		var canchaID, canchaNombre sql.NullString
+		var ubicacion entities.Ubicacion
+		var aproximada bool
+		var distancia float64
+		err := rows.Scan(&reta.ID, &reta.ZonaID, &zonaNombre, &reta.Titulo, &reta.FechaHora, &reta.MaxJugadores,
+			&reta.JugadoresActuales, &reta.Estado, &reta.DuracionMinutos, &canchaID, &canchaNombre,
+			&ubicacion.Latitud, &ubicacion.Longitud, &aproximada, &distancia)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear reta cercana: %w", err)
+		}
+		reta.CanchaID = canchaID.String
+		cercanas = append(cercanas, entities.NewRetaCercana(&reta, zonaNombre, canchaNombre.String, ubicacion, aproximada, distancia))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al recorrer retas cercanas: %w", err)
+	}
+	return cercanas, nil
 }
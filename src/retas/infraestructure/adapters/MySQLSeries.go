@@ -0,0 +1,514 @@
+package adapters
+
+import (
+	"database/sql"
+	"fmt"
+	"games-football-api/src/retas/domain/entities"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// columnasSerie son las columnas que se leen de una serie, en el orden de escanearSerie
+const columnasSerie = `id, zona_id, titulo, dias_semana, TIME_FORMAT(hora, '%H:%i'), fecha_inicio, hasta, ocurrencias,
+	max_jugadores, duracion_minutos, cancha_id, latitud, longitud, creador_id, creador_nombre, estado, created_at`
+
+func escanearSerie(fila filaEscaneable) (entities.Serie, error) {
+	var serie entities.Serie
+	var dias string
+	var fechaInicio time.Time
+	var hasta sql.NullTime
+	var ocurrencias sql.NullInt64
+	var canchaID sql.NullString
+	var latitud, longitud sql.NullFloat64
+	err := fila.Scan(&serie.ID, &serie.ZonaID, &serie.Titulo, &dias, &serie.Hora, &fechaInicio, &hasta, &ocurrencias,
+		&serie.MaxJugadores, &serie.DuracionMinutos, &canchaID, &latitud, &longitud, &serie.CreadorID, &serie.CreadorNombre,
+		&serie.Estado, &serie.CreadoEn)
+	if err != nil {
+		return serie, err
+	}
+
+	serie.DiasSemana = strings.Split(dias, ",")
+	serie.FechaInicio = fechaInicio.Format("2006-01-02")
+	if hasta.Valid {
+		serie.Hasta = hasta.Time.Format("2006-01-02")
+	}
+	serie.Ocurrencias = int(ocurrencias.Int64)
+	serie.CanchaID = canchaID.String
+	serie.Ubicacion = ubicacionDesdeColumnas(latitud, longitud)
+	serie.Suscriptores = []entities.Suscriptor{}
+	return serie, nil
+}
+
+// columnasFinSerie retorna los valores de hasta y ocurrencias a guardar (NULL si no se usan)
+func columnasFinSerie(regla entities.ReglaRecurrencia) (interface{}, interface{}) {
+	var hasta, ocurrencias interface{}
+	if regla.Hasta != "" {
+		hasta = regla.Hasta
+	}
+	if regla.Ocurrencias > 0 {
+		ocurrencias = regla.Ocurrencias
+	}
+	return hasta, ocurrencias
+}
+
+// CrearSerie guarda una serie nueva en estado activa
+func (repo *MySQLRetaRepository) CrearSerie(serie *entities.Serie) error {
+	serie.ID = uuid.New().String()
+	serie.Estado = entities.SerieActiva
+
+	query := `
+		INSERT INTO reta_series (id, zona_id, titulo, dias_semana, hora, fecha_inicio, hasta, ocurrencias, max_jugadores,
+		                         duracion_minutos, cancha_id, latitud, longitud, creador_id, creador_nombre, estado)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	hasta, ocurrencias := columnasFinSerie(serie.ReglaRecurrencia)
+	latitud, longitud := columnasUbicacion(serie.Ubicacion)
+	_, err := repo.db.Exec(query, serie.ID, serie.ZonaID, serie.Titulo, strings.Join(serie.DiasSemana, ","), serie.Hora,
+		serie.FechaInicio, hasta, ocurrencias, serie.MaxJugadores, serie.DuracionMinutos, columnaCancha(serie.CanchaID),
+		latitud, longitud, serie.CreadorID, serie.CreadorNombre, serie.Estado)
+	if err != nil {
+		return fmt.Errorf("error al crear serie: %w", err)
+	}
+
+	serie.CreadoEn = time.Now()
+	serie.Suscriptores = []entities.Suscriptor{}
+	return nil
+}
+
+// ObtenerSerie obtiene una serie con sus suscriptores
+func (repo *MySQLRetaRepository) ObtenerSerie(serieID string) (*entities.Serie, error) {
+	query := "SELECT " + columnasSerie + " FROM reta_series WHERE id = ?"
+	serie, err := escanearSerie(repo.db.QueryRow(query, serieID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrSerieNoEncontrada
+		}
+		return nil, fmt.Errorf("error al consultar serie: %w", err)
+	}
+
+	series := []entities.Serie{serie}
+	if err := repo.cargarSuscriptores(series); err != nil {
+		return nil, err
+	}
+	return &series[0], nil
+}
+
+// ObtenerSeriesPorZona obtiene las series activas de una zona con sus suscriptores
+func (repo *MySQLRetaRepository) ObtenerSeriesPorZona(zonaID string) ([]entities.Serie, error) {
+	query := "SELECT " + columnasSerie + " FROM reta_series WHERE zona_id = ? AND estado = ? ORDER BY created_at DESC"
+	return repo.consultarSeries(query, zonaID, entities.SerieActiva)
+}
+
+// ObtenerSeriesActivas obtiene todas las series activas con sus suscriptores
+func (repo *MySQLRetaRepository) ObtenerSeriesActivas() ([]entities.Serie, error) {
+	query := "SELECT " + columnasSerie + " FROM reta_series WHERE estado = ? ORDER BY created_at ASC"
+	return repo.consultarSeries(query, entities.SerieActiva)
+}
+
+func (repo *MySQLRetaRepository) consultarSeries(query string, args ...interface{}) ([]entities.Serie, error) {
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar series: %w", err)
+	}
+	defer rows.Close()
+
+	series := make([]entities.Serie, 0)
+	for rows.Next() {
+		serie, err := escanearSerie(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear serie: %w", err)
+		}
+		series = append(series, serie)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al recorrer series: %w", err)
+	}
+
+	if err := repo.cargarSuscriptores(series); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// cargarSuscriptores agrega a cada serie sus suscriptores, en orden de suscripción, en una sola consulta
+func (repo *MySQLRetaRepository) cargarSuscriptores(series []entities.Serie) error {
+	if len(series) == 0 {
+		return nil
+	}
+
+	indices := make(map[string]int, len(series))
+	args := make([]interface{}, 0, len(series))
+	for i, serie := range series {
+		indices[serie.ID] = i
+		args = append(args, serie.ID)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(series)), ", ")
+
+	query := `
+		SELECT serie_id, usuario_id, nombre_jugador, created_at
+		FROM reta_serie_suscriptores
+		WHERE serie_id IN (` + placeholders + `)
+		ORDER BY created_at ASC, usuario_id ASC
+	`
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("error al consultar suscriptores: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var serieID string
+		var suscriptor entities.Suscriptor
+		if err := rows.Scan(&serieID, &suscriptor.UsuarioID, &suscriptor.Nombre, &suscriptor.SuscritoEn); err != nil {
+			return fmt.Errorf("error al escanear suscriptor: %w", err)
+		}
+		i := indices[serieID]
+		series[i].Suscriptores = append(series[i].Suscriptores, suscriptor)
+	}
+
+	return rows.Err()
+}
+
+// ActualizarSerie guarda el título, el cupo, la duración, la regla y el estado de la serie; una serie
+// cancelada mientras tanto no se toca
+func (repo *MySQLRetaRepository) ActualizarSerie(serie *entities.Serie) error {
+	query := `
+		UPDATE reta_series
+		SET titulo = ?, dias_semana = ?, hora = ?, hasta = ?, ocurrencias = ?, max_jugadores = ?, duracion_minutos = ?, estado = ?
+		WHERE id = ? AND estado <> ?
+	`
+	hasta, ocurrencias := columnasFinSerie(serie.ReglaRecurrencia)
+	_, err := repo.db.Exec(query, serie.Titulo, strings.Join(serie.DiasSemana, ","), serie.Hora, hasta, ocurrencias,
+		serie.MaxJugadores, serie.DuracionMinutos, serie.Estado, serie.ID, entities.SerieCancelada)
+	if err != nil {
+		return fmt.Errorf("error al actualizar serie: %w", err)
+	}
+	return nil
+}
+
+// CambiarEstadoSerie cambia el estado de la serie solo si sigue en `desde`
+func (repo *MySQLRetaRepository) CambiarEstadoSerie(serieID, desde, hacia string) (bool, error) {
+	result, err := repo.db.Exec("UPDATE reta_series SET estado = ? WHERE id = ? AND estado = ?", hacia, serieID, desde)
+	if err != nil {
+		return false, fmt.Errorf("error al actualizar estado de la serie: %w", err)
+	}
+
+	afectadas, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error al actualizar estado de la serie: %w", err)
+	}
+	return afectadas > 0, nil
+}
+
+// ObtenerOcurrencias retorna los días (YYYY-MM-DD) de la serie que ya tienen reta, incluidas las canceladas
+func (repo *MySQLRetaRepository) ObtenerOcurrencias(serieID string) (map[string]bool, error) {
+	rows, err := repo.db.Query("SELECT serie_fecha FROM retas WHERE serie_id = ?", serieID)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar ocurrencias: %w", err)
+	}
+	defer rows.Close()
+
+	dias := make(map[string]bool)
+	for rows.Next() {
+		var dia time.Time
+		if err := rows.Scan(&dia); err != nil {
+			return nil, fmt.Errorf("error al escanear ocurrencia: %w", err)
+		}
+		dias[dia.Format("2006-01-02")] = true
+	}
+
+	return dias, rows.Err()
+}
+
+// ObtenerOcurrenciasPendientes obtiene las retas abiertas o llenas de la serie, de la más próxima a la más lejana
+func (repo *MySQLRetaRepository) ObtenerOcurrenciasPendientes(serieID string) ([]entities.Reta, error) {
+	query := `
+		SELECT id, zona_id, titulo, fecha_hora, max_jugadores, jugadores_actuales, creador_id, creador_nombre, estado, created_at,
+		       latitud, longitud, cancha_id, duracion_minutos, serie_id, serie_fecha, serie_editada
+		FROM retas
+		WHERE serie_id = ? AND estado IN (?, ?)
+		ORDER BY fecha_hora ASC
+	`
+	rows, err := repo.db.Query(query, serieID, entities.EstadoAbierta, entities.EstadoLlena)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar ocurrencias pendientes: %w", err)
+	}
+	defer rows.Close()
+
+	retas := make([]entities.Reta, 0)
+	for rows.Next() {
+		reta, err := escanearRetaDeSerie(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear reta: %w", err)
+		}
+		retas = append(retas, reta)
+	}
+
+	return retas, rows.Err()
+}
+
+// CrearOcurrencia crea la reta de un día de la serie con el creador como primer jugador e inscribe a los
+// suscriptores en orden de suscripción; los que no caben quedan en la lista de espera. La fila de la serie
+// se bloquea durante la transacción para que dos réplicas no creen el mismo día ni lo creen en una serie
+// que se acaba de cancelar
+func (repo *MySQLRetaRepository) CrearOcurrencia(reta *entities.Reta, suscriptores []entities.Suscriptor) (*entities.MovimientoReta, error) {
+	// Iniciar transacción
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var estadoSerie string
+	err = tx.QueryRow("SELECT estado FROM reta_series WHERE id = ? FOR UPDATE", reta.SerieID).Scan(&estadoSerie)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrSerieNoEncontrada
+		}
+		return nil, fmt.Errorf("error al consultar serie: %w", err)
+	}
+	if estadoSerie != entities.SerieActiva {
+		tx.Rollback()
+		return nil, entities.ErrSerieInactiva
+	}
+
+	var existentes int
+	err = tx.QueryRow("SELECT COUNT(*) FROM retas WHERE serie_id = ? AND serie_fecha = ?", reta.SerieID, reta.Ocurrencia).Scan(&existentes)
+	if err != nil {
+		return nil, fmt.Errorf("error al verificar ocurrencia: %w", err)
+	}
+	if existentes > 0 {
+		tx.Rollback()
+		return nil, entities.ErrOcurrenciaExistente
+	}
+
+	reta.ID = uuid.New().String()
+
+	// Apartar la cancha igual que una reta creada a mano
+	if err := repo.verificarCanchaLibre(tx, reta); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	_, err = repo.insertarReta(tx, reta)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inscribir a los suscriptores mientras haya cupo; el resto entra a la lista de espera
+	jugadores, posicion := 1, 0
+	for _, suscriptor := range suscriptores {
+		if suscriptor.UsuarioID == reta.CreadorID {
+			continue
+		}
+
+		if jugadores < reta.MaxJugadores {
+			insertQuery := "INSERT INTO reta_jugadores (id, reta_id, usuario_id, nombre_jugador) VALUES (?, ?, ?, ?)"
+			_, err = tx.Exec(insertQuery, uuid.New().String(), reta.ID, suscriptor.UsuarioID, suscriptor.Nombre)
+			if err != nil {
+				return nil, fmt.Errorf("error al inscribir suscriptor: %w", err)
+			}
+			jugadores++
+			continue
+		}
+
+		posicion++
+		insertEsperaQuery := "INSERT INTO reta_lista_espera (id, reta_id, usuario_id, nombre_jugador, posicion) VALUES (?, ?, ?, ?, ?)"
+		_, err = tx.Exec(insertEsperaQuery, uuid.New().String(), reta.ID, suscriptor.UsuarioID, suscriptor.Nombre, posicion)
+		if err != nil {
+			return nil, fmt.Errorf("error al agregar suscriptor a la lista de espera: %w", err)
+		}
+	}
+
+	_, err = tx.Exec("UPDATE retas SET jugadores_actuales = ? WHERE id = ?", jugadores, reta.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error al actualizar contador: %w", err)
+	}
+
+	var estado string
+	estado, err = repo.actualizarEstadoPorCupo(tx, reta.ID, reta.Estado, jugadores, reta.MaxJugadores)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit de la transacción
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("error al hacer commit: %w", err)
+	}
+
+	reta.JugadoresActuales = jugadores
+	reta.Estado = estado
+	movimiento := &entities.MovimientoReta{
+		RetaID:            reta.ID,
+		ZonaID:            reta.ZonaID,
+		Estado:            estado,
+		JugadoresActuales: jugadores,
+	}
+	if err := repo.completarListas(movimiento); err != nil {
+		return nil, err
+	}
+
+	return movimiento, nil
+}
+
+// SuscribirASerie agrega al usuario a los jugadores habituales de la serie y, en la misma transacción,
+// lo inscribe (o lo agrega a la lista de espera) en las retas abiertas o llenas de la serie que empiezan
+// después de desde y no se editaron por separado. La fila de la serie se bloquea igual que en
+// CrearOcurrencia para que no se cree una ocurrencia a medias de la suscripción
+func (repo *MySQLRetaRepository) SuscribirASerie(serieID, usuarioID, nombre string, desde time.Time) ([]entities.MovimientoReta, error) {
+	// Iniciar transacción
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var estadoSerie string
+	err = tx.QueryRow("SELECT estado FROM reta_series WHERE id = ? FOR UPDATE", serieID).Scan(&estadoSerie)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrSerieNoEncontrada
+		}
+		return nil, fmt.Errorf("error al consultar serie: %w", err)
+	}
+	if estadoSerie != entities.SerieActiva {
+		tx.Rollback()
+		return nil, entities.ErrSerieInactiva
+	}
+
+	var existe int
+	err = tx.QueryRow("SELECT COUNT(*) FROM reta_serie_suscriptores WHERE serie_id = ? AND usuario_id = ?", serieID, usuarioID).Scan(&existe)
+	if err != nil {
+		return nil, fmt.Errorf("error al verificar suscripción: %w", err)
+	}
+	if existe > 0 {
+		tx.Rollback()
+		return nil, entities.ErrYaSuscrito
+	}
+
+	query := "INSERT INTO reta_serie_suscriptores (serie_id, usuario_id, nombre_jugador) VALUES (?, ?, ?)"
+	if _, err = tx.Exec(query, serieID, usuarioID, nombre); err != nil {
+		return nil, fmt.Errorf("error al suscribir a la serie: %w", err)
+	}
+
+	// Bloquear las ocurrencias pendientes que siguen la serie, de la más próxima a la más lejana
+	var rows *sql.Rows
+	rows, err = tx.Query(`
+		SELECT id, zona_id, jugadores_actuales, max_jugadores, estado
+		FROM retas
+		WHERE serie_id = ? AND estado IN (?, ?) AND serie_editada = FALSE AND fecha_hora > ?
+		ORDER BY fecha_hora ASC
+		FOR UPDATE
+	`, serieID, entities.EstadoAbierta, entities.EstadoLlena, desde)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar ocurrencias pendientes: %w", err)
+	}
+
+	type ocurrencia struct {
+		movimiento   entities.MovimientoReta
+		maxJugadores int
+	}
+	ocurrencias := make([]ocurrencia, 0)
+	for rows.Next() {
+		var o ocurrencia
+		err = rows.Scan(&o.movimiento.RetaID, &o.movimiento.ZonaID, &o.movimiento.JugadoresActuales, &o.maxJugadores, &o.movimiento.Estado)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("error al escanear ocurrencia: %w", err)
+		}
+		ocurrencias = append(ocurrencias, o)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al consultar ocurrencias pendientes: %w", err)
+	}
+
+	movimientos := make([]entities.MovimientoReta, 0, len(ocurrencias))
+	for _, o := range ocurrencias {
+		movimiento := o.movimiento
+
+		// Si ya se había unido por su cuenta (como jugador o en espera) la reta se queda como está
+		var yaInscrito int
+		err = tx.QueryRow(`
+			SELECT (SELECT COUNT(*) FROM reta_jugadores WHERE reta_id = ? AND usuario_id = ?)
+			     + (SELECT COUNT(*) FROM reta_lista_espera WHERE reta_id = ? AND usuario_id = ?)
+		`, movimiento.RetaID, usuarioID, movimiento.RetaID, usuarioID).Scan(&yaInscrito)
+		if err != nil {
+			return nil, fmt.Errorf("error al verificar jugador: %w", err)
+		}
+		if yaInscrito > 0 {
+			continue
+		}
+
+		if movimiento.JugadoresActuales >= o.maxJugadores {
+			insertEsperaQuery := `
+				INSERT INTO reta_lista_espera (id, reta_id, usuario_id, nombre_jugador, posicion)
+				SELECT ?, ?, ?, ?, COALESCE(MAX(posicion), 0) + 1 FROM reta_lista_espera WHERE reta_id = ?
+			`
+			_, err = tx.Exec(insertEsperaQuery, uuid.New().String(), movimiento.RetaID, usuarioID, nombre, movimiento.RetaID)
+			if err != nil {
+				return nil, fmt.Errorf("error al agregar a la lista de espera: %w", err)
+			}
+			movimiento.EnEspera = true
+		} else {
+			_, err = tx.Exec("UPDATE retas SET jugadores_actuales = jugadores_actuales + 1 WHERE id = ?", movimiento.RetaID)
+			if err != nil {
+				return nil, fmt.Errorf("error al actualizar contador: %w", err)
+			}
+
+			insertQuery := "INSERT INTO reta_jugadores (id, reta_id, usuario_id, nombre_jugador) VALUES (?, ?, ?, ?)"
+			_, err = tx.Exec(insertQuery, uuid.New().String(), movimiento.RetaID, usuarioID, nombre)
+			if err != nil {
+				return nil, fmt.Errorf("error al insertar jugador: %w", err)
+			}
+			movimiento.JugadoresActuales++
+
+			movimiento.Estado, err = repo.actualizarEstadoPorCupo(tx, movimiento.RetaID, movimiento.Estado, movimiento.JugadoresActuales, o.maxJugadores)
+			if err != nil {
+				return nil, err
+			}
+		}
+		movimientos = append(movimientos, movimiento)
+	}
+
+	// Commit de la transacción
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("error al hacer commit: %w", err)
+	}
+
+	for i := range movimientos {
+		if err := repo.completarListas(&movimientos[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return movimientos, nil
+}
+
+// DesuscribirDeSerie quita al usuario de los jugadores habituales; retorna si estaba suscrito
+func (repo *MySQLRetaRepository) DesuscribirDeSerie(serieID, usuarioID string) (bool, error) {
+	result, err := repo.db.Exec("DELETE FROM reta_serie_suscriptores WHERE serie_id = ? AND usuario_id = ?", serieID, usuarioID)
+	if err != nil {
+		return false, fmt.Errorf("error al desuscribir de la serie: %w", err)
+	}
+
+	afectadas, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error al desuscribir de la serie: %w", err)
+	}
+	return afectadas > 0, nil
+}
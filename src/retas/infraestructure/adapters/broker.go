@@ -0,0 +1,452 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"games-football-api/src/core"
+	"games-football-api/src/retas/domain/entities"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Broker distribuye entre los nodos de la API los eventos que publica cada hub. El nodo que origina
+// un evento lo entrega a sus clientes directamente; el broker solo lo lleva a los demás nodos
+type Broker interface {
+	// Publicar envía el evento a los demás nodos; los efímeros no se numeran ni se guardan para reanudar
+	Publicar(topic string, mensaje []byte, efimero bool) error
+
+	// Escuchar bloquea entregando los eventos que originaron otros nodos
+	Escuchar(entregar func(topic string, mensaje []byte, efimero bool))
+
+	// ActualizarPresencia suma delta a las conexiones del usuario en el topic y retorna cuántas tiene en
+	// todos los nodos y la versión del cambio, que crece con cada cambio del usuario en el topic
+	ActualizarPresencia(topic string, usuario entities.UsuarioPresente, delta int) (total int, version int64, err error)
+
+	// Presencia retorna los usuarios con al menos una conexión al topic en cualquier nodo
+	Presencia(topic string) ([]entities.UsuarioPresente, error)
+}
+
+// NewBrokerDesdeEnv crea el broker configurado en BROKER: "local" (por defecto, un solo nodo) o "redis"
+func NewBrokerDesdeEnv() (Broker, error) {
+	switch os.Getenv("BROKER") {
+	case "", "local":
+		return NewBrokerLocal(), nil
+	case "redis":
+		canal := os.Getenv("REDIS_CANAL")
+		if canal == "" {
+			canal = "games_football:eventos"
+		}
+		return NewBrokerRedis(core.RedisConfigDesdeEnv(), canal)
+	default:
+		return nil, fmt.Errorf("BROKER inválido: %q (usa local o redis)", os.Getenv("BROKER"))
+	}
+}
+
+// BrokerLocal es el broker de un solo proceso: no hay otros nodos a los que enviar eventos
+type BrokerLocal struct {
+	// Conexiones por topic y usuario, y la última versión de presencia entregada
+	presencia map[string]map[string]*entities.UsuarioPresente
+	version   int64
+	mu        sync.Mutex
+}
+
+func NewBrokerLocal() *BrokerLocal {
+	return &BrokerLocal{
+		presencia: make(map[string]map[string]*entities.UsuarioPresente),
+	}
+}
+
+// Publicar no hace nada: el hub ya entregó el evento a sus clientes
+func (b *BrokerLocal) Publicar(topic string, mensaje []byte, efimero bool) error {
+	return nil
+}
+
+// Escuchar no recibe nada; retorna de inmediato
+func (b *BrokerLocal) Escuchar(entregar func(topic string, mensaje []byte, efimero bool)) {}
+
+// ActualizarPresencia lleva la cuenta en memoria: las conexiones de este nodo son todas las que hay
+func (b *BrokerLocal) ActualizarPresencia(topic string, usuario entities.UsuarioPresente, delta int) (int, int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	usuarios, ok := b.presencia[topic]
+	if !ok {
+		usuarios = make(map[string]*entities.UsuarioPresente)
+		b.presencia[topic] = usuarios
+	}
+	presente, ok := usuarios[usuario.UsuarioID]
+	if !ok {
+		presente = &entities.UsuarioPresente{UsuarioID: usuario.UsuarioID}
+		usuarios[usuario.UsuarioID] = presente
+	}
+	presente.Nombre = usuario.Nombre
+	presente.Conexiones += delta
+
+	total := presente.Conexiones
+	if total <= 0 {
+		total = 0
+		delete(usuarios, usuario.UsuarioID)
+		if len(usuarios) == 0 {
+			delete(b.presencia, topic)
+		}
+	}
+	b.version++
+	return total, b.version, nil
+}
+
+// Presencia retorna los usuarios conectados al topic en este proceso
+func (b *BrokerLocal) Presencia(topic string) ([]entities.UsuarioPresente, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	usuarios := make([]entities.UsuarioPresente, 0, len(b.presencia[topic]))
+	for _, presente := range b.presencia[topic] {
+		usuarios = append(usuarios, *presente)
+	}
+	return usuarios, nil
+}
+
+// sobreBroker es el formato con el que viajan los eventos entre nodos
+type sobreBroker struct {
+	Nodo    string          `json:"nodo"`
+	Topic   string          `json:"topic"`
+	Mensaje json.RawMessage `json:"mensaje"`
+	Efimero bool            `json:"efimero,omitempty"`
+}
+
+// Tiempos de la suscripción a Redis: si no llega nada en intervaloPingRedis se envía un PING, y si
+// tampoco llega el PONG en otro intervalo la conexión se da por perdida (ej. TCP medio abierto) y se reconecta
+const (
+	intervaloPingRedis   = 15 * time.Second
+	timeoutPublicarRedis = 5 * time.Second // Tiempo máximo para publicar un evento, reintentos incluidos
+)
+
+// Cada nodo renueva su marca de vida cada intervaloVidaNodo; si la marca expira, otro nodo descuenta
+// de la presencia las conexiones que el nodo caído dejó registradas
+const (
+	intervaloVidaNodo = 15 * time.Second
+	vidaNodo          = 45 * time.Second
+)
+
+// BrokerRedis comparte los eventos entre nodos con Redis pub/sub en un solo canal
+type BrokerRedis struct {
+	cliente *redis.Client
+	canal   string
+	nodoID  string
+}
+
+func NewBrokerRedis(config core.RedisConfig, canal string) (*BrokerRedis, error) {
+	cliente, err := core.NewRedis(config)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &BrokerRedis{
+		cliente: cliente,
+		canal:   canal,
+		nodoID:  uuid.New().String(),
+	}
+	// El nodo queda registrado antes de contar su primera conexión, así su presencia se limpia si cae
+	if err := b.renovarVida(); err != nil {
+		cliente.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// Publicar envía el evento al canal de Redis marcado con el id de este nodo; el cliente reintenta
+// con otra conexión del pool si la actual falla
+func (b *BrokerRedis) Publicar(topic string, mensaje []byte, efimero bool) error {
+	sobre, err := json.Marshal(sobreBroker{Nodo: b.nodoID, Topic: topic, Mensaje: mensaje, Efimero: efimero})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutPublicarRedis)
+	defer cancel()
+	if err := b.cliente.Publish(ctx, b.canal, sobre).Err(); err != nil {
+		return fmt.Errorf("error al publicar en Redis: %w", err)
+	}
+	return nil
+}
+
+// Escuchar se suscribe al canal y entrega los eventos de otros nodos; si se pierde la conexión
+// se reconecta con espera creciente
+func (b *BrokerRedis) Escuchar(entregar func(topic string, mensaje []byte, efimero bool)) {
+	go b.mantenerNodo(entregar)
+
+	espera := time.Second
+	for {
+		inicio := time.Now()
+		err := b.escucharConexion(entregar)
+		// Una suscripción que duró se considera sana: el siguiente reintento vuelve a la espera mínima
+		if time.Since(inicio) > time.Minute {
+			espera = time.Second
+		}
+		log.Printf("Suscripción a Redis interrumpida: %v; reintentando en %s", err, espera)
+		time.Sleep(espera)
+		if espera < 30*time.Second {
+			espera *= 2
+		}
+	}
+}
+
+// escucharConexion mantiene una suscripción hasta que falle la conexión o deje de responder a los PING
+func (b *BrokerRedis) escucharConexion(entregar func(topic string, mensaje []byte, efimero bool)) error {
+	ctx := context.Background()
+	pubsub := b.cliente.Subscribe(ctx, b.canal)
+	defer pubsub.Close()
+
+	pingPendiente := false
+	for {
+		recibido, err := pubsub.ReceiveTimeout(ctx, intervaloPingRedis)
+		if err != nil {
+			var netErr net.Error
+			if !errors.As(err, &netErr) || !netErr.Timeout() {
+				return err
+			}
+			if pingPendiente {
+				return errors.New("Redis no respondió al PING")
+			}
+			if err := pubsub.Ping(ctx); err != nil {
+				return err
+			}
+			pingPendiente = true
+			continue
+		}
+		// Cualquier respuesta demuestra que la conexión sigue viva
+		pingPendiente = false
+
+		switch msg := recibido.(type) {
+		case *redis.Subscription:
+			log.Printf("Nodo %s suscrito al canal de Redis %s", b.nodoID, msg.Channel)
+		case *redis.Message:
+			b.entregar(msg.Payload, entregar)
+		}
+	}
+}
+
+// entregar decodifica un evento del canal y lo entrega si lo originó otro nodo
+func (b *BrokerRedis) entregar(payload string, entregar func(topic string, mensaje []byte, efimero bool)) {
+	var sobre sobreBroker
+	if err := json.Unmarshal([]byte(payload), &sobre); err != nil {
+		log.Printf("Evento de Redis inválido: %v", err)
+		return
+	}
+	// Los eventos de este nodo ya se entregaron localmente
+	if sobre.Nodo == b.nodoID {
+		return
+	}
+	entregar(sobre.Topic, sobre.Mensaje, sobre.Efimero)
+}
+
+// Llaves de la presencia en Redis, con el canal como prefijo:
+//   - <canal>:presencia:<topic>          conexiones de cada usuario en el topic, sumando todos los nodos
+//   - <canal>:presencia-version:<topic>  versión del último cambio de cada usuario en el topic
+//   - <canal>:presencia-nombres          nombre de cada usuario para los listados
+//   - <canal>:nodo:<id>                  conexiones de un nodo por "<topic>\n<usuario>", para limpiarlas si cae
+//   - <canal>:vivo:<id>                  marca de vida del nodo (expira en vidaNodo)
+//   - <canal>:nodos                      nodos registrados
+func (b *BrokerRedis) llave(partes ...string) string {
+	llave := b.canal
+	for _, parte := range partes {
+		llave += ":" + parte
+	}
+	return llave
+}
+
+// scriptActualizarPresencia cuenta la conexión en el topic y en el nodo y sube la versión en una sola
+// operación, así dos nodos no pueden ver el mismo total
+var scriptActualizarPresencia = redis.NewScript(`
+local total = redis.call('HINCRBY', KEYS[1], ARGV[1], ARGV[3])
+if total <= 0 then
+	redis.call('HDEL', KEYS[1], ARGV[1])
+	total = 0
+end
+if redis.call('HINCRBY', KEYS[3], ARGV[2], ARGV[3]) <= 0 then
+	redis.call('HDEL', KEYS[3], ARGV[2])
+end
+redis.call('HSET', KEYS[4], ARGV[1], ARGV[4])
+local version = redis.call('HINCRBY', KEYS[2], ARGV[1], 1)
+return {total, version}
+`)
+
+// scriptLimpiarNodo descuenta las conexiones de un nodo caído y retorna por cada usuario afectado
+// {topic, usuario, nombre, total, versión}; borra los datos del nodo en la misma operación para que
+// solo un nodo haga la limpieza
+var scriptLimpiarNodo = redis.NewScript(`
+local campos = redis.call('HGETALL', KEYS[1])
+redis.call('DEL', KEYS[1])
+redis.call('SREM', KEYS[2], ARGV[1])
+local cambios = {}
+for i = 1, #campos, 2 do
+	local separador = string.find(campos[i], '\n', 1, true)
+	local topic = string.sub(campos[i], 1, separador - 1)
+	local usuario = string.sub(campos[i], separador + 1)
+	local llave = ARGV[2] .. topic
+	local total = redis.call('HINCRBY', llave, usuario, -tonumber(campos[i + 1]))
+	if total <= 0 then
+		redis.call('HDEL', llave, usuario)
+		total = 0
+	end
+	local version = redis.call('HINCRBY', ARGV[3] .. topic, usuario, 1)
+	local nombre = redis.call('HGET', KEYS[3], usuario) or ''
+	table.insert(cambios, {topic, usuario, nombre, total, version})
+end
+return cambios
+`)
+
+// ActualizarPresencia suma delta a las conexiones del usuario en el topic (en este nodo y en el total)
+func (b *BrokerRedis) ActualizarPresencia(topic string, usuario entities.UsuarioPresente, delta int) (int, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutPublicarRedis)
+	defer cancel()
+
+	llaves := []string{
+		b.llave("presencia", topic),
+		b.llave("presencia-version", topic),
+		b.llave("nodo", b.nodoID),
+		b.llave("presencia-nombres"),
+	}
+	resultado, err := scriptActualizarPresencia.Run(ctx, b.cliente, llaves,
+		usuario.UsuarioID, topic+"\n"+usuario.UsuarioID, delta, usuario.Nombre).Int64Slice()
+	if err != nil {
+		return 0, 0, fmt.Errorf("error al actualizar la presencia en Redis: %w", err)
+	}
+	return int(resultado[0]), resultado[1], nil
+}
+
+// Presencia retorna los usuarios conectados al topic sumando las conexiones de todos los nodos
+func (b *BrokerRedis) Presencia(topic string) ([]entities.UsuarioPresente, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutPublicarRedis)
+	defer cancel()
+
+	conexiones, err := b.cliente.HGetAll(ctx, b.llave("presencia", topic)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar la presencia en Redis: %w", err)
+	}
+	usuarios := make([]entities.UsuarioPresente, 0, len(conexiones))
+	if len(conexiones) == 0 {
+		return usuarios, nil
+	}
+
+	ids := make([]string, 0, len(conexiones))
+	for id := range conexiones {
+		ids = append(ids, id)
+	}
+	nombres, err := b.cliente.HMGet(ctx, b.llave("presencia-nombres"), ids...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar la presencia en Redis: %w", err)
+	}
+
+	for i, id := range ids {
+		total, _ := strconv.Atoi(conexiones[id])
+		if total <= 0 {
+			continue
+		}
+		nombre, _ := nombres[i].(string)
+		usuarios = append(usuarios, entities.UsuarioPresente{UsuarioID: id, Nombre: nombre, Conexiones: total})
+	}
+	return usuarios, nil
+}
+
+// renovarVida registra el nodo y renueva su marca de vida
+func (b *BrokerRedis) renovarVida() error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutPublicarRedis)
+	defer cancel()
+
+	_, err := b.cliente.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, b.llave("nodos"), b.nodoID)
+		pipe.Set(ctx, b.llave("vivo", b.nodoID), 1, vidaNodo)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error al registrar el nodo en Redis: %w", err)
+	}
+	return nil
+}
+
+// mantenerNodo renueva la marca de vida de este nodo y limpia la presencia de los nodos caídos
+func (b *BrokerRedis) mantenerNodo(entregar func(topic string, mensaje []byte, efimero bool)) {
+	ticker := time.NewTicker(intervaloVidaNodo)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := b.renovarVida(); err != nil {
+			log.Printf("%v", err)
+			continue
+		}
+		if err := b.limpiarNodosCaidos(entregar); err != nil {
+			log.Printf("Error al limpiar la presencia de nodos caídos: %v", err)
+		}
+	}
+}
+
+// limpiarNodosCaidos descuenta las conexiones de los nodos sin marca de vida y avisa la salida de los
+// usuarios que se quedaron sin conexiones, a este nodo y a los demás
+func (b *BrokerRedis) limpiarNodosCaidos(entregar func(topic string, mensaje []byte, efimero bool)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutPublicarRedis)
+	defer cancel()
+
+	nodos, err := b.cliente.SMembers(ctx, b.llave("nodos")).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, nodo := range nodos {
+		if nodo == b.nodoID {
+			continue
+		}
+		vivo, err := b.cliente.Exists(ctx, b.llave("vivo", nodo)).Result()
+		if err != nil {
+			return err
+		}
+		if vivo > 0 {
+			continue
+		}
+
+		llaves := []string{b.llave("nodo", nodo), b.llave("nodos"), b.llave("presencia-nombres")}
+		resultado, err := scriptLimpiarNodo.Run(ctx, b.cliente, llaves,
+			nodo, b.llave("presencia", ""), b.llave("presencia-version", "")).Slice()
+		if err != nil {
+			return err
+		}
+		if len(resultado) > 0 {
+			log.Printf("Presencia del nodo caído %s descontada (%d usuarios)", nodo, len(resultado))
+		}
+
+		for _, elemento := range resultado {
+			cambio, ok := elemento.([]interface{})
+			if !ok || len(cambio) != 5 {
+				continue
+			}
+			total, _ := cambio[3].(int64)
+			if total > 0 {
+				continue
+			}
+			topic, _ := cambio[0].(string)
+			version, _ := cambio[4].(int64)
+			aviso := avisoPresencia{Status: "usuario_desconectado", Version: version}
+			aviso.Usuario.UsuarioID, _ = cambio[1].(string)
+			aviso.Usuario.Nombre, _ = cambio[2].(string)
+
+			mensaje, err := json.Marshal(aviso)
+			if err != nil {
+				continue
+			}
+			entregar(prefijoPresencia+topic, mensaje, true)
+			if err := b.Publicar(prefijoPresencia+topic, mensaje, true); err != nil {
+				log.Printf("%v", err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package adapters
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+const (
+	TamanoBufferReanudacion = 256              // Eventos que se guardan por topic para reanudar
+	retencionBuffer         = 10 * time.Minute // Tiempo que se conserva el buffer de un topic sin suscriptores
+)
+
+// eventoTopic es un mensaje ya serializado y numerado dentro de su topic
+type eventoTopic struct {
+	seq     uint64
+	mensaje []byte
+}
+
+// bufferTopic lleva la secuencia de un topic y sus últimos eventos en un buffer circular
+type bufferTopic struct {
+	seq             uint64
+	eventos         []eventoTopic
+	inicio          int
+	ultimaActividad time.Time
+
+	// Versión del último aviso de presencia aplicado por usuario (ver avisoPresencia)
+	versionesPresencia map[string]int64
+}
+
+func newBufferTopic() *bufferTopic {
+	return &bufferTopic{
+		eventos:         make([]eventoTopic, 0, TamanoBufferReanudacion),
+		ultimaActividad: time.Now(),
+	}
+}
+
+// agregar numera el mensaje con el siguiente seq, lo guarda y retorna el mensaje sellado
+func (b *bufferTopic) agregar(topic string, mensaje []byte) []byte {
+	b.seq++
+	b.ultimaActividad = time.Now()
+	sellado := sellarMensaje(mensaje, topic, b.seq)
+
+	evento := eventoTopic{seq: b.seq, mensaje: sellado}
+	if len(b.eventos) < TamanoBufferReanudacion {
+		b.eventos = append(b.eventos, evento)
+	} else {
+		// Buffer lleno: se sobrescribe el evento más antiguo
+		b.eventos[b.inicio] = evento
+		b.inicio = (b.inicio + 1) % TamanoBufferReanudacion
+	}
+	return sellado
+}
+
+// desde retorna en orden los eventos con seq mayor a ultimoSeq; ok es false si hay un hueco
+// que el buffer ya no cubre (o el seq no corresponde a este buffer) y el cliente necesita un snapshot completo
+func (b *bufferTopic) desde(ultimoSeq uint64) (mensajes [][]byte, ok bool) {
+	if ultimoSeq > b.seq {
+		return nil, false
+	}
+	if ultimoSeq == b.seq {
+		return nil, true
+	}
+	if len(b.eventos) == 0 || b.eventos[b.inicio].seq > ultimoSeq+1 {
+		return nil, false
+	}
+
+	for i := 0; i < len(b.eventos); i++ {
+		evento := b.eventos[(b.inicio+i)%len(b.eventos)]
+		if evento.seq > ultimoSeq {
+			mensajes = append(mensajes, evento.mensaje)
+		}
+	}
+	return mensajes, true
+}
+
+// sellarMensaje inserta "seq" y "topic" al inicio del objeto JSON ya serializado
+func sellarMensaje(mensaje []byte, topic string, seq uint64) []byte {
+	if len(mensaje) < 2 || mensaje[0] != '{' {
+		return mensaje
+	}
+
+	topicJSON, _ := json.Marshal(topic)
+	prefijo := `{"seq":` + strconv.FormatUint(seq, 10) + `,"topic":` + string(topicJSON)
+	sellado := make([]byte, 0, len(prefijo)+len(mensaje)+1)
+	sellado = append(sellado, prefijo...)
+	if mensaje[1] != '}' {
+		sellado = append(sellado, ',')
+	}
+	return append(sellado, mensaje[1:]...)
+}
@@ -0,0 +1,162 @@
+package adapters
+
+import (
+	"encoding/json"
+	"games-football-api/src/retas/domain/entities"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Topic de control de la presencia: no tiene suscriptores, el hub convierte el aviso en el evento
+// usuario_conectado / usuario_desconectado del topic que sigue al prefijo
+const prefijoPresencia = "presencia:"
+
+// cambioPresencia es una conexión que entra (delta 1) o sale (delta -1) de un topic
+type cambioPresencia struct {
+	topic   string
+	usuario entities.UsuarioPresente
+	delta   int
+}
+
+// avisoPresencia viaja por el broker cuando un usuario conecta su primera conexión a un topic o cierra
+// la última en todos los nodos. Version crece con cada cambio del usuario en el topic, así los nodos
+// descartan un aviso que llega después de otro más reciente
+type avisoPresencia struct {
+	Status  string                   `json:"status"`
+	Usuario entities.UsuarioPresente `json:"usuario"`
+	Version int64                    `json:"version"`
+}
+
+// colaPresencia guarda en orden los cambios de presencia para procesarlos fuera del goroutine del hub;
+// no tiene límite para que el hub nunca se bloquee al encolar
+type colaPresencia struct {
+	mu        sync.Mutex
+	cambios   []cambioPresencia
+	pendiente chan struct{}
+}
+
+func newColaPresencia() *colaPresencia {
+	return &colaPresencia{pendiente: make(chan struct{}, 1)}
+}
+
+func (c *colaPresencia) agregar(cambio cambioPresencia) {
+	c.mu.Lock()
+	c.cambios = append(c.cambios, cambio)
+	c.mu.Unlock()
+
+	select {
+	case c.pendiente <- struct{}{}:
+	default:
+	}
+}
+
+// tomar bloquea hasta que haya cambios y los retorna todos en el orden en que llegaron
+func (c *colaPresencia) tomar() []cambioPresencia {
+	for {
+		c.mu.Lock()
+		cambios := c.cambios
+		c.cambios = nil
+		c.mu.Unlock()
+		if len(cambios) > 0 {
+			return cambios
+		}
+		<-c.pendiente
+	}
+}
+
+// cambiarPresencia encola la entrada o salida de una conexión en un topic de zona o chat (los topics
+// de usuario no tienen presencia); requiere h.mu tomado
+func (h *Hub) cambiarPresencia(topic string, client *Client, delta int) {
+	if strings.HasPrefix(topic, prefijoUsuario) {
+		return
+	}
+	h.presencia.agregar(cambioPresencia{
+		topic:   topic,
+		usuario: entities.UsuarioPresente{UsuarioID: client.UsuarioID, Nombre: client.Nombre},
+		delta:   delta,
+	})
+}
+
+// procesarPresencia lleva al broker, uno por uno y en orden, los cambios de presencia de este nodo y
+// publica el aviso cuando el usuario entra o sale del topic contando las conexiones de todos los nodos
+func (h *Hub) procesarPresencia() {
+	for {
+		for _, cambio := range h.presencia.tomar() {
+			total, version, err := h.broker.ActualizarPresencia(cambio.topic, cambio.usuario, cambio.delta)
+			if err != nil {
+				log.Printf("Error al actualizar la presencia de %s en %s: %v", cambio.usuario.UsuarioID, cambio.topic, err)
+				continue
+			}
+
+			var status string
+			switch {
+			case cambio.delta > 0 && total == 1:
+				status = "usuario_conectado"
+			case cambio.delta < 0 && total == 0:
+				status = "usuario_desconectado"
+			default:
+				continue
+			}
+
+			usuario := cambio.usuario
+			usuario.Conexiones = total
+			aviso := avisoPresencia{Status: status, Usuario: usuario, Version: version}
+			if err := h.publicar(prefijoPresencia+cambio.topic, aviso, true); err != nil {
+				log.Printf("Error al publicar presencia: %v", err)
+			}
+		}
+	}
+}
+
+// aplicarPresencia convierte un aviso de presencia en el evento del topic para los clientes de este nodo,
+// salvo que ya se haya aplicado uno más reciente del mismo usuario; requiere h.mu tomado
+func (h *Hub) aplicarPresencia(topic string, mensaje []byte) {
+	var aviso avisoPresencia
+	if err := json.Unmarshal(mensaje, &aviso); err != nil {
+		log.Printf("Error al leer el aviso de presencia de %s: %v", topic, err)
+		return
+	}
+
+	buffer, ok := h.buffers[topic]
+	if !ok {
+		buffer = newBufferTopic()
+		h.buffers[topic] = buffer
+	}
+	if buffer.versionesPresencia == nil {
+		buffer.versionesPresencia = make(map[string]int64)
+	}
+	if aviso.Version <= buffer.versionesPresencia[aviso.Usuario.UsuarioID] {
+		return
+	}
+	buffer.versionesPresencia[aviso.Usuario.UsuarioID] = aviso.Version
+
+	presenciaMsg := entities.BroadcastMessage{
+		Status:  aviso.Status,
+		Usuario: &aviso.Usuario,
+	}
+	if strings.HasPrefix(topic, prefijoReta) {
+		presenciaMsg.RetaID = strings.TrimPrefix(topic, prefijoReta)
+	}
+
+	evento, err := json.Marshal(presenciaMsg)
+	if err != nil {
+		log.Printf("Error al serializar presencia: %v", err)
+		return
+	}
+	h.publicarLocal(topic, evento)
+}
+
+// Presencia retorna los usuarios conectados a un topic en todos los nodos, uno por usuario aunque
+// tenga varias conexiones
+func (h *Hub) Presencia(topic string) ([]entities.UsuarioPresente, error) {
+	usuarios, err := h.broker.Presencia(topic)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(usuarios, func(i, j int) bool {
+		return usuarios[i].Nombre < usuarios[j].Nombre
+	})
+	return usuarios, nil
+}
@@ -2,10 +2,13 @@ package adapters
 
 import (
 	"encoding/json"
+	"games-football-api/src/retas/domain/entities"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
@@ -15,119 +18,390 @@ const (
 	writeWait  = 10 * time.Second  // Tiempo máximo para escribir un mensaje
 )
 
+// Prefijos de los topics a los que se suscriben los clientes
+const (
+	prefijoZona    = "zona:"
+	prefijoReta    = "reta:"
+	prefijoUsuario = "usuario:"
+
+	// Topic de control: no tiene suscriptores, el hub cierra las conexiones del usuario
+	prefijoDesconexion = "desconectar:"
+)
+
+// TopicZona retorna el topic con los cambios de retas de una zona
+func TopicZona(zonaID string) string {
+	return prefijoZona + zonaID
+}
+
+// TopicReta retorna el topic del chat de una reta
+func TopicReta(retaID string) string {
+	return prefijoReta + retaID
+}
+
+// TopicUsuario retorna el topic de los mensajes directos a un usuario (todas sus conexiones)
+func TopicUsuario(usuarioID string) string {
+	return prefijoUsuario + usuarioID
+}
+
 // Client representa un cliente conectado al WebSocket
 type Client struct {
 	Conn   *websocket.Conn
 	ZonaID string
 	Send   chan []byte
+
+	// Usuario autenticado en el handshake; es la única identidad que se usa en las acciones
+	UsuarioID string
+	Nombre    string
+
+	// Topics a los que está suscrito; solo lo modifica el hub
+	topics map[string]bool
+
+	// mu protege Send contra el cierre: el hub lo cierra desde su goroutine mientras el goroutine
+	// de lectura sigue respondiendo al cliente
+	mu      sync.Mutex
+	cerrado bool
+
+	// Close frame que WritePump envía al cerrar; lo fija Desconectar
+	cierre []byte
 }
 
-// Hub mantiene el conjunto de clientes activos y difunde mensajes por zona
+// Enviar encola el mensaje sin bloquear; retorna false si el buffer está lleno o el hub ya cerró Send
+func (c *Client) Enviar(mensaje []byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cerrado {
+		return false
+	}
+	select {
+	case c.Send <- mensaje:
+		return true
+	default:
+		return false
+	}
+}
+
+// Desconectar encola un último mensaje y prepara el close frame con el código y el motivo dados. Después
+// hay que desregistrar al cliente: al cerrar Send, WritePump envía lo pendiente, luego el close frame
+// y cierra la conexión
+func (c *Client) Desconectar(ultimo []byte, codigo int, motivo string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cerrado {
+		return
+	}
+	c.cierre = websocket.FormatCloseMessage(codigo, motivo)
+	select {
+	case c.Send <- ultimo:
+	default:
+	}
+}
+
+// Desconectando indica si se llamó a Desconectar; en ese caso la conexión la cierra WritePump
+func (c *Client) Desconectando() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cierre != nil
+}
+
+// cerrarSend cierra Send una sola vez; después Enviar y Desconectar ya no encolan nada
+func (c *Client) cerrarSend() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.cerrado {
+		c.cerrado = true
+		close(c.Send)
+	}
+}
+
+// closeFrame retorna el close frame fijado por Desconectar (vacío si no se llamó)
+func (c *Client) closeFrame() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cierre
+}
+
+// Hub mantiene el conjunto de clientes activos y difunde mensajes por topic (zona, chat de reta o usuario)
 type Hub struct {
-	// Clientes registrados agrupados por zona_id
-	clients map[string]map[*Client]bool
+	// Clientes registrados
+	clients map[*Client]bool
+
+	// Suscriptores agrupados por topic
+	topics map[string]map[*Client]bool
+
+	// Secuencia y últimos eventos de cada topic para que los clientes puedan reanudar
+	buffers map[string]*bufferTopic
+
+	// Identifica esta ejecución del hub; si cambia, las secuencias anteriores ya no son válidas
+	epoch string
+
+	// Lleva los eventos a los demás nodos de la API y trae los que ellos originan
+	broker Broker
 
-	// Canal para registrar clientes
+	// Cambios de presencia pendientes de llevar al broker
+	presencia *colaPresencia
+
+	// Canal para registrar clientes (quedan suscritos a su topic de usuario)
 	register chan *Client
 
 	// Canal para desregistrar clientes (cierra la conexión)
 	unregister chan *Client
 
-	// Canal para cambiar de zona sin cerrar la conexión
-	changeZone chan *zoneChangeRequest
+	// Canal para suscribir, desuscribir, cambiar de zona o reanudar un topic
+	subscription chan *subscriptionRequest
 
-	// Canal para broadcast de mensajes
-	broadcast chan *BroadcastRequest
+	// Canal para publicar mensajes en un topic
+	publish chan *PublishRequest
 
 	// Mutex para sincronización
 	mu sync.RWMutex
 }
 
-// zoneChangeRequest contiene la info para cambiar un cliente de zona
-type zoneChangeRequest struct {
-	Client  *Client
-	OldZona string
-	NewZona string
+// subscriptionRequest contiene la info para suscribir o desuscribir un cliente de un topic
+type subscriptionRequest struct {
+	Client    *Client
+	Topic     string
+	Suscribir bool
+
+	// Topic del que se quita al cliente antes de suscribirlo (cambio de zona)
+	Quitar string
+
+	// Si Reanudar es true se reenvían los eventos con seq mayor a UltimoSeq
+	Reanudar  bool
+	UltimoSeq uint64
+
+	respuesta chan ResultadoSuscripcion
+}
+
+// ResultadoSuscripcion informa la secuencia del topic al momento de suscribirse
+type ResultadoSuscripcion struct {
+	// Seq es el último seq publicado en el topic; los eventos siguientes llegan con seq mayor
+	Seq uint64
+
+	// Reanudado es true si se reenviaron desde el buffer todos los eventos perdidos
+	Reanudado bool
+
+	// Reenviados es cuántos eventos perdidos se reenviaron
+	Reenviados int
 }
 
-// BroadcastRequest contiene el mensaje y la zona a la que se enviará
-type BroadcastRequest struct {
-	ZonaID  string
+// PublishRequest contiene el mensaje y el topic en el que se publicará
+type PublishRequest struct {
+	Topic   string
 	Message []byte
+
+	// Efimero entrega el mensaje tal cual, sin seq ni buffer de reanudación (ej. "escribiendo")
+	Efimero bool
 }
 
-// NewHub crea una nueva instancia del Hub
-func NewHub() *Hub {
+// NewHub crea una nueva instancia del Hub que comparte sus eventos a través del broker
+func NewHub(broker Broker) *Hub {
 	return &Hub{
-		clients:    make(map[string]map[*Client]bool),
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
-		changeZone: make(chan *zoneChangeRequest),
-		broadcast:  make(chan *BroadcastRequest),
+		broker:       broker,
+		presencia:    newColaPresencia(),
+		clients:      make(map[*Client]bool),
+		topics:       make(map[string]map[*Client]bool),
+		buffers:      make(map[string]*bufferTopic),
+		epoch:        uuid.New().String(),
+		register:     make(chan *Client),
+		unregister:   make(chan *Client),
+		subscription: make(chan *subscriptionRequest),
+		publish:      make(chan *PublishRequest),
 	}
 }
 
 // Run ejecuta el hub en un goroutine
 func (h *Hub) Run() {
+	// Los eventos de otros nodos entran por el mismo canal que los locales
+	go h.broker.Escuchar(h.entregarLocal)
+	go h.procesarPresencia()
+
+	limpieza := time.NewTicker(time.Minute)
+	defer limpieza.Stop()
+
 	for {
 		select {
 		case client := <-h.register:
 			h.mu.Lock()
-			if _, ok := h.clients[client.ZonaID]; !ok {
-				h.clients[client.ZonaID] = make(map[*Client]bool)
+			h.clients[client] = true
+			client.topics = make(map[string]bool)
+			h.suscribir(client, TopicUsuario(client.UsuarioID))
+			if client.ZonaID != "" {
+				h.suscribir(client, TopicZona(client.ZonaID))
 			}
-			h.clients[client.ZonaID][client] = true
 			h.mu.Unlock()
-			log.Printf("Cliente registrado en zona: %s. Total clientes en zona: %d", client.ZonaID, len(h.clients[client.ZonaID]))
+			log.Printf("Cliente registrado: usuario %s. Total clientes: %d", client.UsuarioID, len(h.clients))
 
 		case client := <-h.unregister:
 			h.mu.Lock()
-			if clients, ok := h.clients[client.ZonaID]; ok {
-				if _, ok := clients[client]; ok {
-					delete(clients, client)
-					close(client.Send)
-					if len(clients) == 0 {
-						delete(h.clients, client.ZonaID)
-					}
-				}
-			}
+			h.eliminar(client)
 			h.mu.Unlock()
-			log.Printf("Cliente desregistrado de zona: %s", client.ZonaID)
+			log.Printf("Cliente desregistrado: usuario %s", client.UsuarioID)
 
-		case req := <-h.changeZone:
+		case req := <-h.subscription:
 			h.mu.Lock()
-			// Remover de la zona antigua sin cerrar el canal Send
-			if clients, ok := h.clients[req.OldZona]; ok {
-				if _, ok := clients[req.Client]; ok {
-					delete(clients, req.Client)
-					if len(clients) == 0 {
-						delete(h.clients, req.OldZona)
-					}
+			var resultado ResultadoSuscripcion
+			if h.clients[req.Client] {
+				if req.Quitar != "" && req.Quitar != req.Topic {
+					h.desuscribir(req.Client, req.Quitar)
+				}
+				if req.Suscribir {
+					h.suscribir(req.Client, req.Topic)
+					resultado = h.reanudar(req)
+				} else {
+					h.desuscribir(req.Client, req.Topic)
 				}
 			}
-			// Registrar en la zona nueva
-			if _, ok := h.clients[req.NewZona]; !ok {
-				h.clients[req.NewZona] = make(map[*Client]bool)
-			}
-			h.clients[req.NewZona][req.Client] = true
 			h.mu.Unlock()
-			log.Printf("Cliente cambió de zona %s a %s. Total clientes en zona nueva: %d", req.OldZona, req.NewZona, len(h.clients[req.NewZona]))
+			if req.respuesta != nil {
+				req.respuesta <- resultado
+			}
 
-		case broadcastReq := <-h.broadcast:
+		case publishReq := <-h.publish:
 			h.mu.Lock()
-			if clients, ok := h.clients[broadcastReq.ZonaID]; ok {
-				for client := range clients {
-					select {
-					case client.Send <- broadcastReq.Message:
-					default:
-						close(client.Send)
-						delete(clients, client)
-					}
+			switch {
+			case strings.HasPrefix(publishReq.Topic, prefijoDesconexion):
+				h.desconectarUsuario(strings.TrimPrefix(publishReq.Topic, prefijoDesconexion), publishReq.Message)
+			case strings.HasPrefix(publishReq.Topic, prefijoPresencia):
+				h.aplicarPresencia(strings.TrimPrefix(publishReq.Topic, prefijoPresencia), publishReq.Message)
+			case publishReq.Efimero:
+				for client := range h.topics[publishReq.Topic] {
+					h.enviar(client, publishReq.Message)
 				}
+			default:
+				h.publicarLocal(publishReq.Topic, publishReq.Message)
 			}
 			h.mu.Unlock()
+
+		case <-limpieza.C:
+			h.mu.Lock()
+			h.limpiarBuffers()
+			h.mu.Unlock()
+		}
+	}
+}
+
+// publicarLocal numera el mensaje en su topic y lo entrega a los clientes de este nodo; requiere h.mu tomado
+func (h *Hub) publicarLocal(topic string, message []byte) {
+	buffer, ok := h.buffers[topic]
+	if !ok {
+		buffer = newBufferTopic()
+		h.buffers[topic] = buffer
+	}
+	mensaje := buffer.agregar(topic, message)
+	for client := range h.topics[topic] {
+		h.enviar(client, mensaje)
+	}
+}
+
+// reanudar retorna el seq actual del topic y, si se pidió, reenvía al cliente los eventos que se perdió;
+// se ejecuta en el goroutine del hub, así que ningún evento nuevo puede colarse entre ambos pasos. Requiere h.mu tomado
+func (h *Hub) reanudar(req *subscriptionRequest) ResultadoSuscripcion {
+	buffer, ok := h.buffers[req.Topic]
+	if !ok {
+		buffer = newBufferTopic()
+		h.buffers[req.Topic] = buffer
+	}
+	buffer.ultimaActividad = time.Now()
+
+	resultado := ResultadoSuscripcion{Seq: buffer.seq}
+	if !req.Reanudar {
+		return resultado
+	}
+
+	perdidos, completo := buffer.desde(req.UltimoSeq)
+	if !completo {
+		return resultado
+	}
+	for _, mensaje := range perdidos {
+		if !h.enviar(req.Client, mensaje) {
+			return resultado
 		}
 	}
+	resultado.Reanudado = true
+	resultado.Reenviados = len(perdidos)
+	return resultado
+}
+
+// enviar encola el mensaje al cliente; si su buffer está lleno se cierra su conexión. Requiere h.mu tomado
+func (h *Hub) enviar(client *Client, mensaje []byte) bool {
+	if client.Enviar(mensaje) {
+		return true
+	}
+	// Cliente lento: se cierra su conexión y se quita de todos sus topics
+	h.eliminar(client)
+	return false
+}
+
+// desconectarUsuario envía el último mensaje a cada conexión del usuario en este nodo y las cierra;
+// requiere h.mu tomado
+func (h *Hub) desconectarUsuario(usuarioID string, ultimo []byte) {
+	var aviso entities.BroadcastMessage
+	if err := json.Unmarshal(ultimo, &aviso); err != nil {
+		log.Printf("Error al leer la desconexión del usuario %s: %v", usuarioID, err)
+	}
+
+	for client := range h.topics[TopicUsuario(usuarioID)] {
+		client.Desconectar(ultimo, websocket.ClosePolicyViolation, aviso.Codigo)
+		h.eliminar(client)
+	}
+	log.Printf("Conexiones del usuario %s cerradas: %s", usuarioID, aviso.Codigo)
+}
+
+// limpiarBuffers descarta los buffers de topics sin suscriptores ni actividad reciente; requiere h.mu tomado
+func (h *Hub) limpiarBuffers() {
+	limite := time.Now().Add(-retencionBuffer)
+	for topic, buffer := range h.buffers {
+		if len(h.topics[topic]) == 0 && buffer.ultimaActividad.Before(limite) {
+			delete(h.buffers, topic)
+		}
+	}
+}
+
+// suscribir agrega el cliente al topic y cuenta su conexión en la presencia; requiere h.mu tomado
+func (h *Hub) suscribir(client *Client, topic string) {
+	if h.topics[topic][client] {
+		return
+	}
+
+	if _, ok := h.topics[topic]; !ok {
+		h.topics[topic] = make(map[*Client]bool)
+	}
+	h.topics[topic][client] = true
+	client.topics[topic] = true
+
+	h.cambiarPresencia(topic, client, 1)
+}
+
+// desuscribir quita el cliente del topic y descuenta su conexión de la presencia; requiere h.mu tomado
+func (h *Hub) desuscribir(client *Client, topic string) {
+	if !client.topics[topic] {
+		return
+	}
+	if suscriptores, ok := h.topics[topic]; ok {
+		delete(suscriptores, client)
+		if len(suscriptores) == 0 {
+			delete(h.topics, topic)
+		}
+	}
+	delete(client.topics, topic)
+
+	h.cambiarPresencia(topic, client, -1)
+}
+
+// eliminar quita al cliente de todos sus topics y cierra su canal Send una sola vez; requiere h.mu tomado
+func (h *Hub) eliminar(client *Client) {
+	if !h.clients[client] {
+		return
+	}
+	for topic := range client.topics {
+		h.desuscribir(client, topic)
+		// El buffer del topic se conserva desde la desconexión para que el cliente pueda reanudar
+		if buffer, ok := h.buffers[topic]; ok {
+			buffer.ultimaActividad = time.Now()
+		}
+	}
+	delete(h.clients, client)
+	client.cerrarSend()
 }
 
 // RegisterClient registra un nuevo cliente en el hub
@@ -140,30 +414,139 @@ func (h *Hub) UnregisterClient(client *Client) {
 	h.unregister <- client
 }
 
-// ChangeClientZone cambia un cliente de una zona a otra sin cerrar la conexión
-func (h *Hub) ChangeClientZone(client *Client, oldZona, newZona string) {
-	h.changeZone <- &zoneChangeRequest{
-		Client:  client,
-		OldZona: oldZona,
-		NewZona: newZona,
+// Epoch identifica esta ejecución del hub; los clientes deben enviarlo al reanudar
+func (h *Hub) Epoch() string {
+	return h.epoch
+}
+
+// Subscribe suscribe al cliente a un topic adicional (por ejemplo el chat de una reta)
+// y retorna el último seq publicado en él
+func (h *Hub) Subscribe(client *Client, topic string) uint64 {
+	return h.suscribirYEsperar(&subscriptionRequest{
+		Client:    client,
+		Topic:     topic,
+		Suscribir: true,
+	}).Seq
+}
+
+// Resume suscribe al cliente al topic y le reenvía los eventos con seq mayor a ultimoSeq;
+// si el buffer ya no los tiene, Reanudado es false y el cliente necesita un snapshot completo
+func (h *Hub) Resume(client *Client, topic string, ultimoSeq uint64) ResultadoSuscripcion {
+	return h.suscribirYEsperar(&subscriptionRequest{
+		Client:    client,
+		Topic:     topic,
+		Suscribir: true,
+		Reanudar:  true,
+		UltimoSeq: ultimoSeq,
+	})
+}
+
+// Unsubscribe quita al cliente de un topic
+func (h *Hub) Unsubscribe(client *Client, topic string) {
+	h.subscription <- &subscriptionRequest{
+		Client: client,
+		Topic:  topic,
 	}
 }
 
-// BroadcastToZone envía un mensaje a todos los clientes de una zona específica
-func (h *Hub) BroadcastToZone(zonaID string, message interface{}) error {
+// ChangeClientZone cambia un cliente de una zona a otra sin cerrar la conexión y retorna el seq
+// actual de la zona nueva; oldZona vacío solo lo suscribe a la zona nueva
+func (h *Hub) ChangeClientZone(client *Client, oldZona, newZona string) uint64 {
+	return h.ResumeZone(client, oldZona, newZona, 0, false).Seq
+}
+
+// ResumeZone cambia al cliente de zona y, si reanudar es true, le reenvía los eventos de la zona nueva
+// con seq mayor a ultimoSeq
+func (h *Hub) ResumeZone(client *Client, oldZona, newZona string, ultimoSeq uint64, reanudar bool) ResultadoSuscripcion {
+	req := &subscriptionRequest{
+		Client:    client,
+		Topic:     TopicZona(newZona),
+		Suscribir: true,
+		Reanudar:  reanudar,
+		UltimoSeq: ultimoSeq,
+	}
+	if oldZona != "" {
+		req.Quitar = TopicZona(oldZona)
+	}
+	return h.suscribirYEsperar(req)
+}
+
+// suscribirYEsperar envía la petición al hub y espera su resultado
+func (h *Hub) suscribirYEsperar(req *subscriptionRequest) ResultadoSuscripcion {
+	req.respuesta = make(chan ResultadoSuscripcion, 1)
+	h.subscription <- req
+	return <-req.respuesta
+}
+
+// Publish envía un mensaje a todos los suscriptores de un topic en todos los nodos; el hub le agrega
+// "seq" y "topic" y lo guarda en el buffer de reanudación
+func (h *Hub) Publish(topic string, message interface{}) error {
+	return h.publicar(topic, message, false)
+}
+
+// PublishEfimero envía un mensaje a los suscriptores de un topic en todos los nodos sin numerarlo
+// ni guardarlo: quien se conecte o reanude después no lo recibe
+func (h *Hub) PublishEfimero(topic string, message interface{}) error {
+	return h.publicar(topic, message, true)
+}
+
+func (h *Hub) publicar(topic string, message interface{}, efimero bool) error {
 	messageBytes, err := json.Marshal(message)
 	if err != nil {
 		return err
 	}
 
-	h.broadcast <- &BroadcastRequest{
-		ZonaID:  zonaID,
-		Message: messageBytes,
+	h.entregarLocal(topic, messageBytes, efimero)
+
+	// Un fallo del broker no impide la entrega local; los demás nodos se pierden este evento
+	if err := h.broker.Publicar(topic, messageBytes, efimero); err != nil {
+		log.Printf("Error al publicar evento en el broker: %v", err)
 	}
 
 	return nil
 }
 
+// entregarLocal pasa el evento al goroutine del hub para los clientes de este nodo
+func (h *Hub) entregarLocal(topic string, message []byte, efimero bool) {
+	h.publish <- &PublishRequest{
+		Topic:   topic,
+		Message: message,
+		Efimero: efimero,
+	}
+}
+
+// Suscrito indica si la conexión está suscrita al topic
+func (h *Hub) Suscrito(client *Client, topic string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return client.topics[topic]
+}
+
+// DesconectarUsuario cierra las conexiones del usuario en todos los nodos; cada una recibe antes un
+// error con el código y el mensaje indicados (ej. al banear la cuenta)
+func (h *Hub) DesconectarUsuario(usuarioID, codigo, mensaje string) error {
+	return h.publicar(prefijoDesconexion+usuarioID, entities.BroadcastMessage{
+		Status:  "error",
+		Codigo:  codigo,
+		Mensaje: mensaje,
+	}, true)
+}
+
+// BroadcastToZone envía un mensaje a todos los clientes de una zona específica
+func (h *Hub) BroadcastToZone(zonaID string, message interface{}) error {
+	return h.Publish(TopicZona(zonaID), message)
+}
+
+// BroadcastToReta envía un mensaje a los suscriptores del chat de una reta
+func (h *Hub) BroadcastToReta(retaID string, message interface{}) error {
+	return h.Publish(TopicReta(retaID), message)
+}
+
+// SendToUser envía un mensaje a todas las conexiones de un usuario, sin importar su zona
+func (h *Hub) SendToUser(usuarioID string, message interface{}) error {
+	return h.Publish(TopicUsuario(usuarioID), message)
+}
+
 // WritePump envía mensajes del hub al cliente websocket y mantiene la conexión viva con pings
 func (c *Client) WritePump() {
 	ticker := time.NewTicker(pingPeriod)
@@ -178,7 +561,7 @@ func (c *Client) WritePump() {
 			c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
 				// El hub cerró el canal
-				c.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+				c.Conn.WriteMessage(websocket.CloseMessage, c.closeFrame())
 				return
 			}
 			err := c.Conn.WriteMessage(websocket.TextMessage, message)
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminRetasController struct {
+	hub                      *adapters.Hub
+	cancelarRetaAdminUseCase *application.CancelarRetaAdminUseCase
+	purgarMensajesUseCase    *application.PurgarMensajesUseCase
+}
+
+func NewAdminRetasController(hub *adapters.Hub, cancelarRetaAdminUseCase *application.CancelarRetaAdminUseCase, purgarMensajesUseCase *application.PurgarMensajesUseCase) *AdminRetasController {
+	return &AdminRetasController{
+		hub:                      hub,
+		cancelarRetaAdminUseCase: cancelarRetaAdminUseCase,
+		purgarMensajesUseCase:    purgarMensajesUseCase,
+	}
+}
+
+// MotivoRequest representa el cuerpo de las acciones de moderación que solo piden el motivo
+type MotivoRequest struct {
+	Motivo string `json:"motivo" binding:"required"`
+}
+
+// PurgarMensajesRequest representa el cuerpo de la purga; sin usuario_id se purga todo el chat
+type PurgarMensajesRequest struct {
+	Motivo    string `json:"motivo" binding:"required"`
+	UsuarioID string `json:"usuario_id"`
+}
+
+// HandleCancelar maneja la petición POST con la que un moderador cancela cualquier reta no finalizada
+func (ac *AdminRetasController) HandleCancelar(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req MotivoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: motivo",
+		})
+		return
+	}
+
+	reta, err := ac.cancelarRetaAdminUseCase.Execute(c.Param("id"), claims.UsuarioID, req.Motivo)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	notificarRetaCanceladaPorModeracion(ac.hub, reta, req.Motivo)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Reta cancelada",
+		"reta_id": reta.ID,
+		"estado":  reta.Estado,
+	})
+}
+
+// HandlePurgarMensajes maneja la petición DELETE que elimina los mensajes del chat de una reta
+func (ac *AdminRetasController) HandlePurgarMensajes(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req PurgarMensajesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: motivo",
+		})
+		return
+	}
+
+	retaID := c.Param("id")
+	eliminados, err := ac.purgarMensajesUseCase.Execute(retaID, req.UsuarioID, claims.UsuarioID, req.Motivo)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	// Los clientes del chat reemplazan por marcadores los mensajes purgados (de un usuario o todos)
+	if eliminados > 0 {
+		purgaMsg := entities.BroadcastMessage{
+			Status:    "mensajes_purgados",
+			RetaID:    retaID,
+			Purgados:  eliminados,
+			UsuarioID: req.UsuarioID,
+		}
+		if err := ac.hub.BroadcastToReta(retaID, purgaMsg); err != nil {
+			log.Printf("Error al hacer broadcast de mensajes_purgados: %v", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":     "success",
+		"mensaje":    "Mensajes eliminados",
+		"eliminados": eliminados,
+	})
+}
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CancelarRetaController struct {
+	hub                 *adapters.Hub
+	cancelarRetaUseCase *application.CancelarRetaUseCase
+}
+
+func NewCancelarRetaController(hub *adapters.Hub, cancelarRetaUseCase *application.CancelarRetaUseCase) *CancelarRetaController {
+	return &CancelarRetaController{
+		hub:                 hub,
+		cancelarRetaUseCase: cancelarRetaUseCase,
+	}
+}
+
+// HandleCancelar maneja la petición POST con la que el creador cancela su reta
+func (cc *CancelarRetaController) HandleCancelar(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	reta, err := cc.cancelarRetaUseCase.Execute(c.Param("id"), claims.UsuarioID)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	notificarRetaCancelada(cc.hub, reta)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Reta cancelada",
+		"reta_id": reta.ID,
+		"estado":  reta.Estado,
+	})
+}
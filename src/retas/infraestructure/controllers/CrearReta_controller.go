@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CrearRetaController struct {
+	hub              *adapters.Hub
+	crearRetaUseCase *application.CrearRetaUseCase
+}
+
+func NewCrearRetaController(hub *adapters.Hub, crearRetaUseCase *application.CrearRetaUseCase) *CrearRetaController {
+	return &CrearRetaController{
+		hub:              hub,
+		crearRetaUseCase: crearRetaUseCase,
+	}
+}
+
+// CrearRetaRequest representa el cuerpo de la petición para crear una reta
+type CrearRetaRequest struct {
+	ZonaID       string `json:"zona_id" binding:"required"`
+	Titulo       string `json:"titulo" binding:"required"`
+	FechaHora    string `json:"fecha_hora" binding:"required"`
+	MaxJugadores int    `json:"max_jugadores" binding:"required,min=1"`
+
+	// Ubicacion de la cancha (opcional); debe estar dentro del radio de la zona
+	Ubicacion *entities.Ubicacion `json:"ubicacion"`
+
+	// CanchaID aparta una cancha registrada (opcional, excluye a ubicacion) durante DuracionMinutos
+	CanchaID        string `json:"cancha_id"`
+	DuracionMinutos int    `json:"duracion_minutos"`
+}
+
+// HandleCrear maneja la petición POST para crear una reta; el creador es el usuario autenticado
+func (cc *CrearRetaController) HandleCrear(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req CrearRetaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: zona_id, titulo, fecha_hora, max_jugadores",
+		})
+		return
+	}
+
+	retaCreada, primerJugador, err := cc.crearRetaUseCase.Execute(req.ZonaID, req.Titulo, req.FechaHora, req.MaxJugadores, claims.UsuarioID, claims.Nombre,
+		req.Ubicacion, req.CanchaID, req.DuracionMinutos)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	// Mismo broadcast "nueva_reta" que la acción "crear" del WebSocket
+	info := notificarNuevaReta(cc.hub, retaCreada, primerJugador)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status":  "success",
+		"mensaje": "Reta creada",
+		"reta":    info,
+	})
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EditarMensajeController struct {
+	hub                  *adapters.Hub
+	editarMensajeUseCase *application.EditarMensajeUseCase
+}
+
+func NewEditarMensajeController(hub *adapters.Hub, editarMensajeUseCase *application.EditarMensajeUseCase) *EditarMensajeController {
+	return &EditarMensajeController{
+		hub:                  hub,
+		editarMensajeUseCase: editarMensajeUseCase,
+	}
+}
+
+// EditarMensajeRequest representa el cuerpo de la petición con el nuevo texto
+type EditarMensajeRequest struct {
+	Texto string `json:"texto" binding:"required"`
+}
+
+// HandleEditar maneja la petición PUT con la que el autor corrige un mensaje del chat
+func (ec *EditarMensajeController) HandleEditar(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req EditarMensajeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: texto",
+		})
+		return
+	}
+
+	mensaje, err := ec.editarMensajeUseCase.Execute(c.Param("id"), c.Param("mensaje_id"), claims.UsuarioID, req.Texto)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	notificarCambioMensaje(ec.hub, "mensaje_editado", mensaje)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":       "success",
+		"mensaje":      "Mensaje editado",
+		"mensaje_chat": mensaje,
+	})
+}
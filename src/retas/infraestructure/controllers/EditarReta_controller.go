@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EditarRetaController struct {
+	hub               *adapters.Hub
+	editarRetaUseCase *application.EditarRetaUseCase
+}
+
+func NewEditarRetaController(hub *adapters.Hub, editarRetaUseCase *application.EditarRetaUseCase) *EditarRetaController {
+	return &EditarRetaController{
+		hub:               hub,
+		editarRetaUseCase: editarRetaUseCase,
+	}
+}
+
+// EditarRetaRequest representa el cuerpo de la petición de edición; los campos vacíos no se modifican
+type EditarRetaRequest struct {
+	Titulo       string `json:"titulo"`
+	FechaHora    string `json:"fecha_hora"`
+	MaxJugadores int    `json:"max_jugadores"`
+
+	// Ubicacion reemplaza la cancha; debe estar dentro del radio de la zona
+	Ubicacion *entities.Ubicacion `json:"ubicacion"`
+
+	DuracionMinutos int `json:"duracion_minutos"`
+}
+
+// HandleEditar maneja la petición PUT con la que el creador edita su reta
+func (ec *EditarRetaController) HandleEditar(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req EditarRetaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Formato de petición inválido",
+		})
+		return
+	}
+
+	reta, movimiento, err := ec.editarRetaUseCase.Execute(c.Param("id"), claims.UsuarioID, req.Titulo, req.FechaHora, req.MaxJugadores, req.Ubicacion, req.DuracionMinutos)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	notificarRetaActualizada(ec.hub, reta, movimiento)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Reta actualizada",
+		"reta":    entities.NewRetaInfo(reta, movimiento.ListaJugadores, movimiento.ListaEspera),
+	})
+}
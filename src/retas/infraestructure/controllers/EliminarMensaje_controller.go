@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EliminarMensajeController struct {
+	hub                    *adapters.Hub
+	eliminarMensajeUseCase *application.EliminarMensajeUseCase
+}
+
+func NewEliminarMensajeController(hub *adapters.Hub, eliminarMensajeUseCase *application.EliminarMensajeUseCase) *EliminarMensajeController {
+	return &EliminarMensajeController{
+		hub:                    hub,
+		eliminarMensajeUseCase: eliminarMensajeUseCase,
+	}
+}
+
+// HandleEliminar maneja la petición DELETE que elimina un mensaje del chat (queda un marcador en el historial)
+func (ec *EliminarMensajeController) HandleEliminar(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	mensaje, err := ec.eliminarMensajeUseCase.Execute(c.Param("id"), c.Param("mensaje_id"), claims.UsuarioID)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	notificarCambioMensaje(ec.hub, "mensaje_eliminado", mensaje)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":       "success",
+		"mensaje":      "Mensaje eliminado",
+		"mensaje_chat": mensaje,
+	})
+}
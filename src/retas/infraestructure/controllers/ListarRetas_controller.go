@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/domain/entities"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListarRetasController struct {
+	obtenerRetasUseCase *application.ObtenerRetasPorZonaUseCase
+}
+
+func NewListarRetasController(obtenerRetasUseCase *application.ObtenerRetasPorZonaUseCase) *ListarRetasController {
+	return &ListarRetasController{
+		obtenerRetasUseCase: obtenerRetasUseCase,
+	}
+}
+
+// HandleListar maneja la petición GET que lista las retas de una zona.
+// Query params: zona_id (requerido), estado, incluir_cerradas, con_lugares, desde, hasta
+func (lc *ListarRetasController) HandleListar(c *gin.Context) {
+	zonaID := c.Query("zona_id")
+	if zonaID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "El parámetro zona_id es requerido",
+		})
+		return
+	}
+
+	filtro, err := filtroDesdeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	claims, _ := core.ClaimsDesdeContexto(c)
+	filtro.UsuarioID = claims.UsuarioID
+
+	retas, err := lc.obtenerRetasUseCase.Execute(zonaID, filtro)
+	if errors.Is(err, entities.ErrZonaNoEncontrada) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"mensaje": "Error al obtener las retas",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"zona_id": zonaID,
+		"total":   len(retas),
+		"retas":   retas,
+	})
+}
+
+// filtroDesdeQuery arma el filtro de retas a partir de los query params de la petición
+func filtroDesdeQuery(c *gin.Context) (entities.FiltroRetas, error) {
+	var filtro entities.FiltroRetas
+
+	if estado := c.Query("estado"); estado != "" {
+		if !entities.EstadoValido(estado) {
+			return filtro, errors.New("estado inválido: usa abierta, llena, en_juego, finalizada o cancelada")
+		}
+		filtro.Estado = estado
+	}
+
+	var err error
+	if filtro.IncluirCerradas, err = boolDesdeQuery(c, "incluir_cerradas"); err != nil {
+		return filtro, err
+	}
+	if filtro.ConLugares, err = boolDesdeQuery(c, "con_lugares"); err != nil {
+		return filtro, err
+	}
+
+	if desde := c.Query("desde"); desde != "" {
+		fecha, _, err := fechaDesdeQuery(desde)
+		if err != nil {
+			return filtro, errors.New("desde debe tener el formato YYYY-MM-DD o YYYY-MM-DD HH:MM:SS")
+		}
+		filtro.Desde = &fecha
+	}
+	if hasta := c.Query("hasta"); hasta != "" {
+		fecha, soloDia, err := fechaDesdeQuery(hasta)
+		if err != nil {
+			return filtro, errors.New("hasta debe tener el formato YYYY-MM-DD o YYYY-MM-DD HH:MM:SS")
+		}
+		// Con solo la fecha se incluye el día completo
+		if soloDia {
+			fecha = fecha.AddDate(0, 0, 1)
+		}
+		filtro.Hasta = &fecha
+	}
+
+	return filtro, nil
+}
+
+// boolDesdeQuery lee un query param booleano opcional (false si no viene)
+func boolDesdeQuery(c *gin.Context, nombre string) (bool, error) {
+	valor := c.Query(nombre)
+	if valor == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(valor)
+	if err != nil {
+		return false, errors.New(nombre + " debe ser true o false")
+	}
+	return b, nil
+}
+
+// fechaDesdeQuery acepta YYYY-MM-DD o YYYY-MM-DD HH:MM:SS; indica si solo se envió el día
+func fechaDesdeQuery(valor string) (time.Time, bool, error) {
+	if fecha, err := core.FechaHoraLocal("2006-01-02 15:04:05", valor); err == nil {
+		return fecha, false, nil
+	}
+	fecha, err := core.FechaHoraLocal("2006-01-02", valor)
+	return fecha, true, err
+}
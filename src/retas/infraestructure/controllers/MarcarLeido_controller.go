@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MarcarLeidoController struct {
+	hub                *adapters.Hub
+	marcarLeidoUseCase *application.MarcarLeidoUseCase
+}
+
+func NewMarcarLeidoController(hub *adapters.Hub, marcarLeidoUseCase *application.MarcarLeidoUseCase) *MarcarLeidoController {
+	return &MarcarLeidoController{
+		hub:                hub,
+		marcarLeidoUseCase: marcarLeidoUseCase,
+	}
+}
+
+// MarcarLeidoRequest representa el cuerpo de la petición: el último mensaje que el usuario vio
+type MarcarLeidoRequest struct {
+	MensajeID string `json:"mensaje_id" binding:"required"`
+}
+
+// HandleMarcarLeido maneja la petición POST que guarda hasta qué mensaje leyó el usuario el chat de una reta
+func (mc *MarcarLeidoController) HandleMarcarLeido(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req MarcarLeidoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: mensaje_id",
+		})
+		return
+	}
+
+	lectura, err := mc.marcarLeidoUseCase.Execute(c.Param("id"), claims.UsuarioID, req.MensajeID)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	notificarLectura(mc.hub, lectura, claims.Nombre)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"lectura": lectura,
+	})
+}
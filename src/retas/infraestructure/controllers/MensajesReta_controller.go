@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/domain/entities"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MensajesRetaController struct {
+	historialChatUseCase *application.ObtenerHistorialChatUseCase
+}
+
+func NewMensajesRetaController(historialChatUseCase *application.ObtenerHistorialChatUseCase) *MensajesRetaController {
+	return &MensajesRetaController{
+		historialChatUseCase: historialChatUseCase,
+	}
+}
+
+// HandleListar maneja la petición GET que retorna una página del chat de una reta.
+// Query params: antes / despues (id de mensaje), antes_de / despues_de (fecha), limite
+func (mc *MensajesRetaController) HandleListar(c *gin.Context) {
+	consulta, err := consultaDesdeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	claims, _ := core.ClaimsDesdeContexto(c)
+	pagina, err := mc.historialChatUseCase.Execute(c.Param("id"), claims.UsuarioID, consulta)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"reta_id":  c.Param("id"),
+		"mensajes": pagina.Mensajes,
+		"hay_mas":  pagina.HayMas,
+	})
+}
+
+// consultaDesdeQuery arma la consulta de mensajes a partir de los query params de la petición
+func consultaDesdeQuery(c *gin.Context) (entities.ConsultaMensajes, error) {
+	consulta := entities.ConsultaMensajes{
+		Antes:   c.Query("antes"),
+		Despues: c.Query("despues"),
+	}
+
+	if limite := c.Query("limite"); limite != "" {
+		n, err := strconv.Atoi(limite)
+		if err != nil || n <= 0 {
+			return consulta, errors.New("limite debe ser un número mayor a 0")
+		}
+		consulta.Limite = n
+	}
+
+	if antesDe := c.Query("antes_de"); antesDe != "" {
+		fecha, err := fechaCursorDesdeQuery(antesDe)
+		if err != nil {
+			return consulta, errors.New("antes_de debe ser una fecha ISO 8601 o YYYY-MM-DD HH:MM:SS")
+		}
+		consulta.AntesDe = &fecha
+	}
+	if despuesDe := c.Query("despues_de"); despuesDe != "" {
+		fecha, err := fechaCursorDesdeQuery(despuesDe)
+		if err != nil {
+			return consulta, errors.New("despues_de debe ser una fecha ISO 8601 o YYYY-MM-DD HH:MM:SS")
+		}
+		consulta.DespuesDe = &fecha
+	}
+
+	return consulta, nil
+}
+
+// fechaCursorDesdeQuery acepta el timestamp tal como llega en los mensajes (ISO 8601) o YYYY-MM-DD HH:MM:SS
+func fechaCursorDesdeQuery(valor string) (time.Time, error) {
+	if fecha, err := time.Parse(time.RFC3339Nano, valor); err == nil {
+		return fecha, nil
+	}
+	return core.FechaHoraLocal("2006-01-02 15:04:05", valor)
+}
@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ObtenerRetaController struct {
+	obtenerRetaUseCase *application.ObtenerRetaUseCase
+}
+
+func NewObtenerRetaController(obtenerRetaUseCase *application.ObtenerRetaUseCase) *ObtenerRetaController {
+	return &ObtenerRetaController{
+		obtenerRetaUseCase: obtenerRetaUseCase,
+	}
+}
+
+// HandleObtener maneja la petición GET que retorna una reta con sus jugadores, lista de espera y chat
+func (oc *ObtenerRetaController) HandleObtener(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+	reta, err := oc.obtenerRetaUseCase.Execute(c.Param("id"), claims.UsuarioID)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"reta":   reta,
+	})
+}
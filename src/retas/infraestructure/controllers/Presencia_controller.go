@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PresenciaController struct {
+	hub               *adapters.Hub
+	accesoChatUseCase *application.VerificarAccesoChatUseCase
+}
+
+func NewPresenciaController(hub *adapters.Hub, accesoChatUseCase *application.VerificarAccesoChatUseCase) *PresenciaController {
+	return &PresenciaController{
+		hub:               hub,
+		accesoChatUseCase: accesoChatUseCase,
+	}
+}
+
+// HandlePresenciaZona maneja la petición GET que retorna los usuarios en línea de una zona.
+// Query param requerido: zona_id
+func (pc *PresenciaController) HandlePresenciaZona(c *gin.Context) {
+	zonaID := c.Query("zona_id")
+	if zonaID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: zona_id",
+		})
+		return
+	}
+
+	usuarios, err := pc.hub.Presencia(adapters.TopicZona(zonaID))
+	if err != nil {
+		pc.responderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"zona_id":  zonaID,
+		"usuarios": usuarios,
+	})
+}
+
+// HandlePresenciaReta maneja la petición GET que retorna los usuarios en línea en el chat de una reta;
+// solo la pueden consultar quienes pueden leer ese chat
+func (pc *PresenciaController) HandlePresenciaReta(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+	if err := pc.accesoChatUseCase.Execute(c.Param("id"), claims.UsuarioID); err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	usuarios, err := pc.hub.Presencia(adapters.TopicReta(c.Param("id")))
+	if err != nil {
+		pc.responderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"reta_id":  c.Param("id"),
+		"usuarios": usuarios,
+	})
+}
+
+// responderError responde 500 cuando no se pudo consultar la presencia (ej. Redis caído)
+func (pc *PresenciaController) responderError(c *gin.Context, err error) {
+	log.Printf("Error al consultar la presencia: %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"status":  "error",
+		"mensaje": "Error al consultar los usuarios en línea",
+	})
+}
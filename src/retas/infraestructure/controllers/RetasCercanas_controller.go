@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"errors"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/domain/entities"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RetasCercanasController struct {
+	buscarRetasCercanasUseCase *application.BuscarRetasCercanasUseCase
+}
+
+func NewRetasCercanasController(buscarRetasCercanasUseCase *application.BuscarRetasCercanasUseCase) *RetasCercanasController {
+	return &RetasCercanasController{
+		buscarRetasCercanasUseCase: buscarRetasCercanasUseCase,
+	}
+}
+
+// HandleBuscar maneja la petición GET que busca retas próximas a la posición del usuario en todas las zonas.
+// Query params: latitud y longitud (requeridos), radio_km, limite, con_lugares
+func (rc *RetasCercanasController) HandleBuscar(c *gin.Context) {
+	busqueda, err := busquedaDesdeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	cercanas, err := rc.buscarRetasCercanasUseCase.Execute(busqueda)
+	if errors.Is(err, entities.ErrUbicacionInvalida) || errors.Is(err, entities.ErrRadioBusqueda) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"mensaje": "Error al buscar retas cercanas",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"total":    len(cercanas),
+		"cercanas": cercanas,
+	})
+}
+
+// busquedaDesdeQuery arma los parámetros de la búsqueda a partir de los query params de la petición
+func busquedaDesdeQuery(c *gin.Context) (entities.BusquedaCercana, error) {
+	var busqueda entities.BusquedaCercana
+
+	if c.Query("latitud") == "" || c.Query("longitud") == "" {
+		return busqueda, errors.New("Los parámetros latitud y longitud son requeridos")
+	}
+
+	var err error
+	if busqueda.Posicion.Latitud, err = strconv.ParseFloat(c.Query("latitud"), 64); err != nil {
+		return busqueda, errors.New("latitud debe ser un número")
+	}
+	if busqueda.Posicion.Longitud, err = strconv.ParseFloat(c.Query("longitud"), 64); err != nil {
+		return busqueda, errors.New("longitud debe ser un número")
+	}
+	if radio := c.Query("radio_km"); radio != "" {
+		if busqueda.RadioKm, err = strconv.ParseFloat(radio, 64); err != nil {
+			return busqueda, errors.New("radio_km debe ser un número")
+		}
+	}
+	if limite := c.Query("limite"); limite != "" {
+		if busqueda.Limite, err = strconv.Atoi(limite); err != nil {
+			return busqueda, errors.New("limite debe ser un número entero")
+		}
+	}
+	if busqueda.ConLugares, err = boolDesdeQuery(c, "con_lugares"); err != nil {
+		return busqueda, err
+	}
+
+	return busqueda, nil
+}
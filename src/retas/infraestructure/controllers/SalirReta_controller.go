@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SalirRetaController struct {
+	hub          *adapters.Hub
+	salirUseCase *application.SalirRetaUseCase
+}
+
+func NewSalirRetaController(hub *adapters.Hub, salirUseCase *application.SalirRetaUseCase) *SalirRetaController {
+	return &SalirRetaController{
+		hub:          hub,
+		salirUseCase: salirUseCase,
+	}
+}
+
+// HandleSalir maneja la petición POST para que el usuario autenticado salga de una reta
+func (sc *SalirRetaController) HandleSalir(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	movimiento, err := sc.salirUseCase.Execute(c.Param("id"), claims.UsuarioID)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	// Mismos broadcasts que la acción "salir" del WebSocket para mantener sincronizados a los clientes
+	notificarMovimiento(sc.hub, movimiento)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":             "success",
+		"mensaje":            "Saliste de la reta",
+		"reta_id":            movimiento.RetaID,
+		"jugadores_actuales": movimiento.JugadoresActuales,
+		"lista_jugadores":    movimiento.ListaJugadores,
+		"lista_espera":       movimiento.ListaEspera,
+	})
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SancionesRetaController struct {
+	hub                     *adapters.Hub
+	sancionarUsuarioUseCase *application.SancionarUsuarioUseCase
+	quitarSancionUseCase    *application.QuitarSancionUseCase
+	obtenerSancionesUseCase *application.ObtenerSancionesUseCase
+}
+
+func NewSancionesRetaController(hub *adapters.Hub, sancionarUsuarioUseCase *application.SancionarUsuarioUseCase, quitarSancionUseCase *application.QuitarSancionUseCase, obtenerSancionesUseCase *application.ObtenerSancionesUseCase) *SancionesRetaController {
+	return &SancionesRetaController{
+		hub:                     hub,
+		sancionarUsuarioUseCase: sancionarUsuarioUseCase,
+		quitarSancionUseCase:    quitarSancionUseCase,
+		obtenerSancionesUseCase: obtenerSancionesUseCase,
+	}
+}
+
+// SancionarRequest representa el cuerpo de la petición para silenciar o expulsar a un usuario del chat
+type SancionarRequest struct {
+	UsuarioID string `json:"usuario_id" binding:"required"`
+	Tipo      string `json:"tipo" binding:"required"` // "silencio" o "expulsion"
+	Minutos   int    `json:"minutos"`                 // Solo para "silencio"; 15 por defecto
+	Motivo    string `json:"motivo"`
+}
+
+// HandleSancionar maneja la petición POST con la que el creador silencia o expulsa a un usuario del chat
+func (sc *SancionesRetaController) HandleSancionar(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req SancionarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: usuario_id, tipo",
+		})
+		return
+	}
+
+	sancion, err := sc.sancionarUsuarioUseCase.Execute(c.Param("id"), claims.UsuarioID, req.UsuarioID, req.Tipo, req.Minutos, req.Motivo)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	notificarSancion(sc.hub, "usuario_sancionado", sancion)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Sanción aplicada",
+		"sancion": sancion,
+	})
+}
+
+// HandleQuitar maneja la petición DELETE que levanta la sanción de un usuario
+func (sc *SancionesRetaController) HandleQuitar(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	retaID := c.Param("id")
+	usuarioID := c.Param("usuario_id")
+	if err := sc.quitarSancionUseCase.Execute(retaID, claims.UsuarioID, usuarioID); err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	notificarSancion(sc.hub, "sancion_retirada", &entities.Sancion{RetaID: retaID, UsuarioID: usuarioID})
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Sanción retirada",
+	})
+}
+
+// HandleListar maneja la petición GET que lista las sanciones vigentes del chat (solo el creador)
+func (sc *SancionesRetaController) HandleListar(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	sanciones, err := sc.obtenerSancionesUseCase.Execute(c.Param("id"), claims.UsuarioID)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"reta_id":   c.Param("id"),
+		"sanciones": sanciones,
+	})
+}
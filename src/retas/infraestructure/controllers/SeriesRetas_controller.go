@@ -0,0 +1,267 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SeriesRetasController struct {
+	hub                  *adapters.Hub
+	crearSerieUseCase    *application.CrearSerieUseCase
+	materializarUseCase  *application.MaterializarSeriesUseCase
+	obtenerSeriesUseCase *application.ObtenerSeriesPorZonaUseCase
+	obtenerSerieUseCase  *application.ObtenerSerieUseCase
+	editarSerieUseCase   *application.EditarSerieUseCase
+	cancelarSerieUseCase *application.CancelarSerieUseCase
+}
+
+func NewSeriesRetasController(hub *adapters.Hub, crearSerieUseCase *application.CrearSerieUseCase, materializarUseCase *application.MaterializarSeriesUseCase, obtenerSeriesUseCase *application.ObtenerSeriesPorZonaUseCase, obtenerSerieUseCase *application.ObtenerSerieUseCase, editarSerieUseCase *application.EditarSerieUseCase, cancelarSerieUseCase *application.CancelarSerieUseCase) *SeriesRetasController {
+	return &SeriesRetasController{
+		hub:                  hub,
+		crearSerieUseCase:    crearSerieUseCase,
+		materializarUseCase:  materializarUseCase,
+		obtenerSeriesUseCase: obtenerSeriesUseCase,
+		obtenerSerieUseCase:  obtenerSerieUseCase,
+		editarSerieUseCase:   editarSerieUseCase,
+		cancelarSerieUseCase: cancelarSerieUseCase,
+	}
+}
+
+// CrearSerieRequest representa el cuerpo de la petición para crear una serie recurrente
+type CrearSerieRequest struct {
+	ZonaID       string   `json:"zona_id" binding:"required"`
+	Titulo       string   `json:"titulo" binding:"required"`
+	DiasSemana   []string `json:"dias_semana" binding:"required"`
+	Hora         string   `json:"hora" binding:"required"`
+	MaxJugadores int      `json:"max_jugadores" binding:"required,min=1"`
+
+	// FechaInicio vacía empieza hoy; Hasta (fecha) u Ocurrencias (número de retas) terminan la serie,
+	// sin ninguno de los dos se repite hasta cancelarla
+	FechaInicio string `json:"fecha_inicio"`
+	Hasta       string `json:"hasta"`
+	Ocurrencias int    `json:"ocurrencias"`
+
+	// Igual que al crear una reta: ubicacion o cancha_id (opcionales y excluyentes)
+	Ubicacion       *entities.Ubicacion `json:"ubicacion"`
+	CanchaID        string              `json:"cancha_id"`
+	DuracionMinutos int                 `json:"duracion_minutos"`
+}
+
+// EditarSerieRequest representa los cambios a la serie completa; los campos vacíos no se modifican.
+// hasta y ocurrencias reemplazan el fin de la serie ("" o 0 la dejan sin fin)
+type EditarSerieRequest struct {
+	Titulo          string   `json:"titulo"`
+	MaxJugadores    int      `json:"max_jugadores"`
+	DuracionMinutos int      `json:"duracion_minutos"`
+	DiasSemana      []string `json:"dias_semana"`
+	Hora            string   `json:"hora"`
+	Hasta           *string  `json:"hasta"`
+	Ocurrencias     *int     `json:"ocurrencias"`
+}
+
+// HandleCrear maneja la petición POST que crea una serie; las retas que caen dentro del horizonte se
+// crean en ese momento y el resto las crea el scheduler
+func (sc *SeriesRetasController) HandleCrear(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req CrearSerieRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: zona_id, titulo, dias_semana, hora, max_jugadores",
+		})
+		return
+	}
+
+	regla := entities.ReglaRecurrencia{
+		DiasSemana:  req.DiasSemana,
+		Hora:        req.Hora,
+		FechaInicio: req.FechaInicio,
+		Hasta:       req.Hasta,
+		Ocurrencias: req.Ocurrencias,
+	}
+	serie, err := sc.crearSerieUseCase.Execute(req.ZonaID, req.Titulo, regla, req.MaxJugadores, claims.UsuarioID, claims.Nombre,
+		req.Ubicacion, req.CanchaID, req.DuracionMinutos)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	// Si esto falla la serie ya quedó guardada; el scheduler crea las retas que falten en la siguiente ejecución
+	resultado, err := sc.materializarUseCase.ExecuteSerie(serie, time.Now())
+	if err != nil {
+		log.Printf("Error al materializar la serie %s: %v", serie.ID, err)
+	}
+	if resultado == nil {
+		resultado = &entities.ResultadoSerie{}
+	}
+	notificarResultadoSerie(sc.hub, resultado, "")
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status":        "success",
+		"mensaje":       "Serie creada",
+		"serie":         serie,
+		"retas_creadas": retasDeOcurrencias(resultado.Creadas),
+		"conflictos":    conflictosDeResultado(resultado),
+	})
+}
+
+// HandleListar maneja la petición GET que lista las series activas de una zona (query param zona_id)
+func (sc *SeriesRetasController) HandleListar(c *gin.Context) {
+	zonaID := c.Query("zona_id")
+	if zonaID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "El parámetro zona_id es requerido",
+		})
+		return
+	}
+
+	series, err := sc.obtenerSeriesUseCase.Execute(zonaID)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"zona_id": zonaID,
+		"total":   len(series),
+		"series":  series,
+	})
+}
+
+// HandleObtener maneja la petición GET de una serie con sus suscriptores y sus próximas retas
+func (sc *SeriesRetasController) HandleObtener(c *gin.Context) {
+	serie, proximas, err := sc.obtenerSerieUseCase.Execute(c.Param("id"))
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"serie":    serie,
+		"proximas": proximas,
+	})
+}
+
+// HandleEditar maneja la petición PUT con la que el creador edita la serie completa. Para cambiar solo
+// una fecha se edita esa reta con PUT /api/retas/:id
+func (sc *SeriesRetasController) HandleEditar(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req EditarSerieRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Formato de petición inválido",
+		})
+		return
+	}
+
+	cambios := entities.CambiosSerie{
+		Titulo:          req.Titulo,
+		MaxJugadores:    req.MaxJugadores,
+		DuracionMinutos: req.DuracionMinutos,
+		DiasSemana:      req.DiasSemana,
+		Hora:            req.Hora,
+		Hasta:           req.Hasta,
+		Ocurrencias:     req.Ocurrencias,
+	}
+	serie, resultado, err := sc.editarSerieUseCase.Execute(c.Param("id"), claims.UsuarioID, cambios)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	// Los días nuevos de la regla que caen dentro del horizonte se crean de inmediato
+	materializadas, err := sc.materializarUseCase.ExecuteSerie(serie, time.Now())
+	if err != nil {
+		log.Printf("Error al materializar la serie %s: %v", serie.ID, err)
+	}
+	if materializadas != nil {
+		resultado.Creadas = materializadas.Creadas
+		resultado.Conflictos = append(resultado.Conflictos, materializadas.Conflictos...)
+	}
+	notificarResultadoSerie(sc.hub, resultado, "su día ya no es parte de la serie")
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":             "success",
+		"mensaje":            "Serie actualizada",
+		"serie":              serie,
+		"retas_creadas":      retasDeOcurrencias(resultado.Creadas),
+		"retas_actualizadas": retasDeOcurrencias(resultado.Actualizadas),
+		"retas_canceladas":   idsDeRetas(resultado.Canceladas),
+		"conflictos":         conflictosDeResultado(resultado),
+	})
+}
+
+// HandleCancelar maneja la petición POST con la que el creador cancela la serie completa. Para cancelar
+// solo una fecha se cancela esa reta con POST /api/retas/:id/cancelar
+func (sc *SeriesRetasController) HandleCancelar(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	serie, resultado, err := sc.cancelarSerieUseCase.Execute(c.Param("id"), claims.UsuarioID)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	notificarResultadoSerie(sc.hub, resultado, "se canceló la serie completa")
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":           "success",
+		"mensaje":          "Serie cancelada",
+		"serie_id":         serie.ID,
+		"estado":           serie.Estado,
+		"retas_canceladas": idsDeRetas(resultado.Canceladas),
+		"conflictos":       conflictosDeResultado(resultado),
+	})
+}
+
+// retasDeOcurrencias arma las retas creadas o actualizadas con sus listas de jugadores
+func retasDeOcurrencias(ocurrencias []entities.OcurrenciaActualizada) []*entities.RetaInfo {
+	retas := make([]*entities.RetaInfo, 0, len(ocurrencias))
+	for _, ocurrencia := range ocurrencias {
+		retas = append(retas, entities.NewRetaInfo(ocurrencia.Reta, ocurrencia.Movimiento.ListaJugadores, ocurrencia.Movimiento.ListaEspera))
+	}
+	return retas
+}
+
+func idsDeRetas(retas []entities.Reta) []string {
+	ids := make([]string, 0, len(retas))
+	for _, reta := range retas {
+		ids = append(ids, reta.ID)
+	}
+	return ids
+}
+
+func conflictosDeResultado(resultado *entities.ResultadoSerie) []entities.ConflictoOcurrencia {
+	if resultado.Conflictos == nil {
+		return []entities.ConflictoOcurrencia{}
+	}
+	return resultado.Conflictos
+}
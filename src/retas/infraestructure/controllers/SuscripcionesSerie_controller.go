@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SuscripcionesSerieController struct {
+	suscribirUseCase   *application.SuscribirSerieUseCase
+	desuscribirUseCase *application.DesuscribirSerieUseCase
+	hub                *adapters.Hub
+}
+
+func NewSuscripcionesSerieController(suscribirUseCase *application.SuscribirSerieUseCase, desuscribirUseCase *application.DesuscribirSerieUseCase, hub *adapters.Hub) *SuscripcionesSerieController {
+	return &SuscripcionesSerieController{
+		suscribirUseCase:   suscribirUseCase,
+		desuscribirUseCase: desuscribirUseCase,
+		hub:                hub,
+	}
+}
+
+// HandleSuscribir maneja la petición POST con la que un jugador habitual pide que se le inscriba
+// automáticamente en las próximas retas de la serie, incluidas las que ya están publicadas
+func (sc *SuscripcionesSerieController) HandleSuscribir(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	serie, movimientos, err := sc.suscribirUseCase.Execute(c.Param("id"), claims.UsuarioID, claims.Nombre)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	retasInscrito, retasEnEspera := make([]string, 0), make([]string, 0)
+	for i := range movimientos {
+		notificarMovimiento(sc.hub, &movimientos[i])
+		if movimientos[i].EnEspera {
+			retasEnEspera = append(retasEnEspera, movimientos[i].RetaID)
+		} else {
+			retasInscrito = append(retasInscrito, movimientos[i].RetaID)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":          "success",
+		"mensaje":         "Te inscribiremos automáticamente en las próximas retas de \"" + serie.Titulo + "\"",
+		"serie_id":        serie.ID,
+		"retas_inscrito":  retasInscrito,
+		"retas_en_espera": retasEnEspera,
+	})
+}
+
+// HandleDesuscribir maneja la petición POST con la que el jugador deja de inscribirse automáticamente
+func (sc *SuscripcionesSerieController) HandleDesuscribir(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	serieID := c.Param("id")
+	if err := sc.desuscribirUseCase.Execute(serieID, claims.UsuarioID); err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"mensaje":  "Ya no se te inscribirá automáticamente; sigues en las retas que ya estaban creadas",
+		"serie_id": serieID,
+	})
+}
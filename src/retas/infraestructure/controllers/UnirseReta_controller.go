@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"fmt"
+	"games-football-api/src/core"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UnirseRetaController struct {
+	hub           *adapters.Hub
+	unirseUseCase *application.UnirseRetaUseCase
+}
+
+func NewUnirseRetaController(hub *adapters.Hub, unirseUseCase *application.UnirseRetaUseCase) *UnirseRetaController {
+	return &UnirseRetaController{
+		hub:           hub,
+		unirseUseCase: unirseUseCase,
+	}
+}
+
+// HandleUnirse maneja la petición POST para que el usuario autenticado se una a una reta
+// (o quede en la lista de espera si está llena)
+func (uc *UnirseRetaController) HandleUnirse(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	movimiento, err := uc.unirseUseCase.Execute(c.Param("id"), claims.UsuarioID, claims.Nombre)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	// Mismos broadcasts que la acción "unirse" del WebSocket para mantener sincronizados a los clientes
+	notificarMovimiento(uc.hub, movimiento)
+
+	status := "success"
+	mensaje := "Te uniste a la reta"
+	if movimiento.EnEspera {
+		status = "en_lista_espera"
+		mensaje = fmt.Sprintf("Reta llena: quedaste en la posición %d de la lista de espera", posicionEnEspera(movimiento, claims.UsuarioID))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":             status,
+		"mensaje":            mensaje,
+		"reta_id":            movimiento.RetaID,
+		"estado":             movimiento.Estado,
+		"jugadores_actuales": movimiento.JugadoresActuales,
+		"lista_jugadores":    movimiento.ListaJugadores,
+		"lista_espera":       movimiento.ListaEspera,
+	})
+}
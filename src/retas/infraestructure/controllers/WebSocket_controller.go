@@ -2,79 +2,192 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"games-football-api/src/core"
 	"games-football-api/src/retas/application"
 	"games-football-api/src/retas/domain/entities"
 	"games-football-api/src/retas/infraestructure/adapters"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
+// errIdentidad se envía cuando el cliente manda un usuario_id distinto al autenticado
+const errIdentidad = "No puedes actuar en nombre de otro usuario"
+
+// tokenSubprotocol es el subprotocolo con el que el cliente envía el token en Sec-WebSocket-Protocol:
+// new WebSocket(url, ["access_token", "<token>"])
+const tokenSubprotocol = "access_token"
+
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
+	Subprotocols:    []string{tokenSubprotocol},
 	CheckOrigin: func(r *http.Request) bool {
 		return true // Permitir todas las conexiones en desarrollo
 	},
 }
 
 type WebSocketController struct {
-	hub                  *adapters.Hub
-	unirseUseCase        *application.UnirseRetaUseCase
-	crearRetaUseCase     *application.CrearRetaUseCase
-	obtenerRetasUseCase  *application.ObtenerRetasPorZonaUseCase
-	enviarMensajeUseCase *application.EnviarMensajeUseCase
-	historialChatUseCase *application.ObtenerHistorialChatUseCase
+	hub                    *adapters.Hub
+	jwtManager             *core.JWTManager
+	unirseUseCase          *application.UnirseRetaUseCase
+	salirUseCase           *application.SalirRetaUseCase
+	crearRetaUseCase       *application.CrearRetaUseCase
+	editarRetaUseCase      *application.EditarRetaUseCase
+	cancelarRetaUseCase    *application.CancelarRetaUseCase
+	obtenerRetasUseCase    *application.ObtenerRetasPorZonaUseCase
+	validarZonaUseCase     *application.ValidarZonaUseCase
+	verificarCuentaUseCase *application.VerificarCuentaUseCase
+	cercanasUseCase        *application.BuscarRetasCercanasUseCase
+	enviarMensajeUseCase   *application.EnviarMensajeUseCase
+	historialChatUseCase   *application.ObtenerHistorialChatUseCase
+	accesoChatUseCase      *application.VerificarAccesoChatUseCase
+	marcarLeidoUseCase     *application.MarcarLeidoUseCase
+
+	editarMensajeUseCase   *application.EditarMensajeUseCase
+	eliminarMensajeUseCase *application.EliminarMensajeUseCase
+
+	sancionarUsuarioUseCase *application.SancionarUsuarioUseCase
+	quitarSancionUseCase    *application.QuitarSancionUseCase
+
+	limites *LimitesWebSocket
 }
 
-func NewWebSocketController(hub *adapters.Hub, unirseUseCase *application.UnirseRetaUseCase, crearRetaUseCase *application.CrearRetaUseCase, obtenerRetasUseCase *application.ObtenerRetasPorZonaUseCase, enviarMensajeUseCase *application.EnviarMensajeUseCase, historialChatUseCase *application.ObtenerHistorialChatUseCase) *WebSocketController {
+func NewWebSocketController(hub *adapters.Hub, jwtManager *core.JWTManager, unirseUseCase *application.UnirseRetaUseCase, salirUseCase *application.SalirRetaUseCase, crearRetaUseCase *application.CrearRetaUseCase, editarRetaUseCase *application.EditarRetaUseCase, cancelarRetaUseCase *application.CancelarRetaUseCase, obtenerRetasUseCase *application.ObtenerRetasPorZonaUseCase, validarZonaUseCase *application.ValidarZonaUseCase, verificarCuentaUseCase *application.VerificarCuentaUseCase, cercanasUseCase *application.BuscarRetasCercanasUseCase, enviarMensajeUseCase *application.EnviarMensajeUseCase, historialChatUseCase *application.ObtenerHistorialChatUseCase, accesoChatUseCase *application.VerificarAccesoChatUseCase, marcarLeidoUseCase *application.MarcarLeidoUseCase, editarMensajeUseCase *application.EditarMensajeUseCase, eliminarMensajeUseCase *application.EliminarMensajeUseCase, sancionarUsuarioUseCase *application.SancionarUsuarioUseCase, quitarSancionUseCase *application.QuitarSancionUseCase, limites *LimitesWebSocket) *WebSocketController {
 	return &WebSocketController{
-		hub:                  hub,
-		unirseUseCase:        unirseUseCase,
-		crearRetaUseCase:     crearRetaUseCase,
-		obtenerRetasUseCase:  obtenerRetasUseCase,
-		enviarMensajeUseCase: enviarMensajeUseCase,
-		historialChatUseCase: historialChatUseCase,
+		hub:                    hub,
+		jwtManager:             jwtManager,
+		unirseUseCase:          unirseUseCase,
+		salirUseCase:           salirUseCase,
+		crearRetaUseCase:       crearRetaUseCase,
+		editarRetaUseCase:      editarRetaUseCase,
+		cancelarRetaUseCase:    cancelarRetaUseCase,
+		obtenerRetasUseCase:    obtenerRetasUseCase,
+		validarZonaUseCase:     validarZonaUseCase,
+		verificarCuentaUseCase: verificarCuentaUseCase,
+		cercanasUseCase:        cercanasUseCase,
+		enviarMensajeUseCase:   enviarMensajeUseCase,
+		historialChatUseCase:   historialChatUseCase,
+		accesoChatUseCase:      accesoChatUseCase,
+		marcarLeidoUseCase:     marcarLeidoUseCase,
+
+		editarMensajeUseCase:   editarMensajeUseCase,
+		eliminarMensajeUseCase: eliminarMensajeUseCase,
+
+		sancionarUsuarioUseCase: sancionarUsuarioUseCase,
+		quitarSancionUseCase:    quitarSancionUseCase,
+
+		limites: limites,
 	}
 }
 
+// autenticarHandshake valida el token del handshake, enviado como segundo valor de Sec-WebSocket-Protocol
+// después de "access_token". No se acepta en la URL porque el logger de gin y los proxies registran el
+// query string completo
+func (wsc *WebSocketController) autenticarHandshake(c *gin.Context) (*core.Claims, error) {
+	var token string
+	protocolos := websocket.Subprotocols(c.Request)
+	if len(protocolos) == 2 && protocolos[0] == tokenSubprotocol {
+		token = strings.TrimSpace(protocolos[1])
+	}
+	if token == "" {
+		return nil, errors.New("Token de acceso requerido")
+	}
+
+	return wsc.jwtManager.Validar(token)
+}
+
+// rechazarCuenta responde 403 si la cuenta del usuario está suspendida: el access token de un usuario
+// baneado sigue siendo válido hasta que expire, así que el baneo se revisa en la base de datos
+func (wsc *WebSocketController) rechazarCuenta(c *gin.Context, usuarioID string) bool {
+	_, err := wsc.verificarCuentaUseCase.Execute(usuarioID)
+	if err == nil {
+		return false
+	}
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, entities.ErrCuentaSuspendida):
+		status = http.StatusForbidden
+	case errors.Is(err, entities.ErrUsuarioNoEncontrado):
+		status = http.StatusUnauthorized
+	default:
+		log.Printf("Error al verificar la cuenta de %s: %v", usuarioID, err)
+	}
+	c.JSON(status, gin.H{
+		"status":  "error",
+		"mensaje": err.Error(),
+	})
+	return true
+}
+
+// identidadValida indica si el usuario_id enviado por el cliente (opcional) coincide con el usuario autenticado
+func identidadValida(client *adapters.Client, usuarioID string) bool {
+	return usuarioID == "" || usuarioID == client.UsuarioID
+}
+
 // HandleWebSocket maneja las conexiones WebSocket
 func (wsc *WebSocketController) HandleWebSocket(c *gin.Context) {
+	claims, err := wsc.autenticarHandshake(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+	if wsc.rechazarBloqueado(c, claims.UsuarioID) {
+		return
+	}
+	if wsc.rechazarCuenta(c, claims.UsuarioID) {
+		return
+	}
+
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		log.Printf("Error al actualizar a WebSocket: %v", err)
 		return
 	}
+	conn.SetReadLimit(wsc.limites.tamanoMaximo)
 
 	client := &adapters.Client{
-		Conn: conn,
-		Send: make(chan []byte, 256),
+		Conn:      conn,
+		Send:      make(chan []byte, 256),
+		UsuarioID: claims.UsuarioID,
+		Nombre:    claims.Nombre,
 	}
 
+	// Registrar en el hub desde el inicio: el cliente queda suscrito a su topic de usuario
+	wsc.hub.RegisterClient(client)
+
+	// Si se desconectó por abuso, WritePump envía el último error y el frame de cierre antes de cerrar
 	defer func() {
-		if client.ZonaID != "" {
-			wsc.hub.UnregisterClient(client)
+		wsc.hub.UnregisterClient(client)
+		if !client.Desconectando() {
+			conn.Close()
 		}
-		conn.Close()
 	}()
 
 	// Iniciar escritura en goroutine
 	go client.WritePump()
 
 	// Enviar confirmación inmediata de conexión WebSocket establecida
+	// El epoch se guarda junto con el último seq de cada topic para poder reanudar después de reconectar
 	confirmMsg := entities.BroadcastMessage{
 		Status:  "conectado",
 		Mensaje: "WebSocket conectado correctamente",
+		Epoch:   wsc.hub.Epoch(),
 	}
 	confirmBytes, _ := json.Marshal(confirmMsg)
-	select {
-	case client.Send <- confirmBytes:
+	if client.Enviar(confirmBytes) {
 		log.Printf("Confirmación de conexión enviada al cliente")
-	default:
+	} else {
 		log.Printf("No se pudo enviar confirmación de conexión")
 	}
 
@@ -85,13 +198,14 @@ func (wsc *WebSocketController) HandleWebSocket(c *gin.Context) {
 		return nil
 	})
 
+	escribiendo := newLimitadorEscribiendo()
+	limitador := wsc.limites.nuevaConexion(client.UsuarioID)
+
 	// Leer mensajes del cliente
 	for {
 		_, message, err := conn.ReadMessage()
 		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure, websocket.CloseNormalClosure) {
-				log.Printf("Error inesperado: %v", err)
-			}
+			errorDeLectura(err, "")
 			break
 		}
 
@@ -106,35 +220,28 @@ func (wsc *WebSocketController) HandleWebSocket(c *gin.Context) {
 			continue
 		}
 
-		// Registrar o cambiar de zona si el mensaje trae zona_id
-		if wsMsg.ZonaID != "" && client.ZonaID != wsMsg.ZonaID {
-			// Si ya estaba en otra zona, cambiar sin cerrar la conexión
-			if client.ZonaID != "" {
-				log.Printf("Cliente cambiando de zona %s a %s", client.ZonaID, wsMsg.ZonaID)
-				oldZona := client.ZonaID
-				client.ZonaID = wsMsg.ZonaID
-				wsc.hub.ChangeClientZone(client, oldZona, wsMsg.ZonaID)
-			} else {
-				client.ZonaID = wsMsg.ZonaID
-				wsc.hub.RegisterClient(client)
+		// "escribiendo" ya tiene su propio límite por reta
+		if wsMsg.Accion != "escribiendo" && !wsc.aplicarLimite(client, limitador, wsMsg.Accion) {
+			if client.Desconectando() {
+				break
+			}
+			continue
+		}
+
+		// Suscribir o cambiar de zona si el mensaje trae zona_id (sin cerrar la conexión);
+		// "reanudar" maneja la zona por su cuenta para no enviar el snapshot completo.
+		// Una zona que no existe se rechaza antes de tocar el hub y el mensaje no se procesa
+		if wsMsg.Accion != "reanudar" && wsMsg.ZonaID != "" && client.ZonaID != wsMsg.ZonaID {
+			if !wsc.zonaValida(client, wsMsg.ZonaID) {
+				continue
 			}
+			oldZona := client.ZonaID
+			client.ZonaID = wsMsg.ZonaID
+			seq := wsc.hub.ChangeClientZone(client, oldZona, wsMsg.ZonaID)
 			log.Printf("Cliente registrado en zona: %s", client.ZonaID)
 
 			// Enviar las retas existentes de esta zona al cliente
-			retas, err := wsc.obtenerRetasUseCase.Execute(client.ZonaID)
-			if err != nil {
-				log.Printf("Error al obtener retas de zona %s: %v", client.ZonaID, err)
-			} else {
-				initMsg := entities.BroadcastMessage{
-					Status: "retas_zona",
-					Retas:  retas,
-				}
-				msgBytes, _ := json.Marshal(initMsg)
-				select {
-				case client.Send <- msgBytes:
-				default:
-				}
-			}
+			wsc.enviarRetasZona(client, entities.FiltroRetas{IncluirCerradas: wsMsg.IncluirCerradas}, seq)
 		}
 
 		// Enrutar según la acción
@@ -147,105 +254,235 @@ func (wsc *WebSocketController) HandleWebSocket(c *gin.Context) {
 			} else {
 				wsc.sendError(client, "Debes enviar zona_id para conectarte a una zona")
 			}
+		case "listar_retas":
+			// Reenvía el snapshot de la zona actual, por ejemplo para incluir las retas cerradas
+			if client.ZonaID == "" {
+				wsc.sendError(client, "Debes conectarte a una zona primero (envía zona_id)")
+				continue
+			}
+			seq := wsc.hub.Subscribe(client, adapters.TopicZona(client.ZonaID))
+			wsc.enviarRetasZona(client, entities.FiltroRetas{IncluirCerradas: wsMsg.IncluirCerradas}, seq)
+		case "reanudar":
+			wsc.handleReanudar(client, wsMsg)
 		case "unirse":
 			if client.ZonaID == "" {
 				wsc.sendError(client, "Debes conectarte a una zona primero (envía zona_id)")
 				continue
 			}
 			wsc.handleUnirse(client, wsMsg)
+		case "salir":
+			if client.ZonaID == "" {
+				wsc.sendError(client, "Debes conectarte a una zona primero (envía zona_id)")
+				continue
+			}
+			wsc.handleSalir(client, wsMsg)
 		case "crear":
 			if client.ZonaID == "" {
 				wsc.sendError(client, "Debes conectarte a una zona primero (envía zona_id)")
 				continue
 			}
 			wsc.handleCrear(client, wsMsg)
-		case "enviar_mensaje":
+		case "editar_reta":
+			if client.ZonaID == "" {
+				wsc.sendError(client, "Debes conectarte a una zona primero (envía zona_id)")
+				continue
+			}
+			wsc.handleEditarReta(client, wsMsg)
+		case "cancelar_reta":
 			if client.ZonaID == "" {
 				wsc.sendError(client, "Debes conectarte a una zona primero (envía zona_id)")
 				continue
 			}
+			wsc.handleCancelarReta(client, wsMsg)
+		case "enviar_mensaje":
 			wsc.handleEnviarMensaje(client, wsMsg)
+		case "suscribir_chat":
+			wsc.handleSuscribirChat(client, wsMsg)
+		case "cargar_mas":
+			wsc.handleCargarMas(client, wsMsg)
+		case "presencia":
+			wsc.handlePresencia(client, wsMsg)
+		case "cercanas":
+			wsc.handleCercanas(client, wsMsg)
+		case "escribiendo":
+			wsc.handleEscribiendo(client, wsMsg, escribiendo)
+		case "marcar_leido":
+			wsc.handleMarcarLeido(client, wsMsg)
+		case "editar_mensaje":
+			wsc.handleEditarMensaje(client, wsMsg)
+		case "eliminar_mensaje":
+			wsc.handleEliminarMensaje(client, wsMsg)
+		case "silenciar", "expulsar", "quitar_sancion":
+			if wsMsg.RetaID == "" || wsMsg.SancionadoID == "" {
+				wsc.sendError(client, "Campos requeridos: reta_id, sancionado_id")
+				continue
+			}
+			if err := wsc.sancionar(client, wsMsg.Accion, wsMsg.RetaID, wsMsg.SancionadoID, wsMsg.Minutos, wsMsg.Motivo); err != nil {
+				wsc.sendError(client, err.Error())
+			}
+		case "desuscribir_chat":
+			if wsMsg.RetaID == "" {
+				wsc.sendError(client, "Campos requeridos: reta_id")
+				continue
+			}
+			wsc.hub.Unsubscribe(client, adapters.TopicReta(wsMsg.RetaID))
 		default:
 			wsc.sendError(client, "Acción no reconocida: "+wsMsg.Accion)
 		}
 	}
 }
 
+// zonaValida verifica que la zona exista antes de suscribir al cliente; si no, le envía el error
+func (wsc *WebSocketController) zonaValida(client *adapters.Client, zonaID string) bool {
+	err := wsc.validarZonaUseCase.Execute(zonaID)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, entities.ErrZonaNoEncontrada):
+		wsc.sendError(client, "Zona no encontrada: "+zonaID)
+	default:
+		log.Printf("Error al validar zona %s: %v", zonaID, err)
+		wsc.sendError(client, "No se pudo validar la zona")
+	}
+	return false
+}
+
+// enviarRetasZona envía al cliente el snapshot "retas_zona" de su zona actual junto con el seq
+// de la zona al momento de suscribirse
+func (wsc *WebSocketController) enviarRetasZona(client *adapters.Client, filtro entities.FiltroRetas, seq uint64) {
+	// El snapshot es por conexión, así que puede incluir los mensajes no leídos del usuario
+	filtro.UsuarioID = client.UsuarioID
+	retas, err := wsc.obtenerRetasUseCase.Execute(client.ZonaID, filtro)
+	if err != nil {
+		log.Printf("Error al obtener retas de zona %s: %v", client.ZonaID, err)
+		return
+	}
+
+	initMsg := entities.BroadcastMessage{
+		Seq:    seq,
+		Topic:  adapters.TopicZona(client.ZonaID),
+		Epoch:  wsc.hub.Epoch(),
+		Status: "retas_zona",
+		Retas:  retas,
+	}
+	msgBytes, _ := json.Marshal(initMsg)
+	client.Enviar(msgBytes)
+}
+
 // handleUnirse maneja la acción de unirse a una reta
 func (wsc *WebSocketController) handleUnirse(client *adapters.Client, msg entities.WebSocketMessage) {
 	// Validar campos necesarios
-	if msg.RetaID == "" || msg.UsuarioID == "" || msg.Nombre == "" {
-		wsc.sendError(client, "Campos requeridos: reta_id, usuario_id, nombre")
+	if msg.RetaID == "" {
+		wsc.sendError(client, "Campos requeridos: reta_id")
+		return
+	}
+	if !identidadValida(client, msg.UsuarioID) {
+		wsc.sendError(client, errIdentidad)
 		return
 	}
 
-	// Ejecutar el caso de uso
-	jugadoresActuales, listaJugadores, err := wsc.unirseUseCase.Execute(msg.RetaID, msg.UsuarioID, msg.Nombre)
+	// Ejecutar el caso de uso con la identidad autenticada de la conexión
+	movimiento, err := wsc.unirseUseCase.Execute(msg.RetaID, client.UsuarioID, client.Nombre)
 	if err != nil {
 		wsc.sendError(client, err.Error())
 		return
 	}
 
+	// Si la reta estaba llena, avisar solo a este cliente su lugar en la lista de espera
+	if movimiento.EnEspera {
+		wsc.sendSuccess(client, "en_lista_espera", fmt.Sprintf("Reta llena: quedaste en la posición %d de la lista de espera", posicionEnEspera(movimiento, client.UsuarioID)))
+	}
+
 	// Broadcast a todos los clientes de la zona
-	broadcastMsg := entities.BroadcastMessage{
-		Status:            "actualizacion",
-		RetaID:            msg.RetaID,
-		JugadoresActuales: jugadoresActuales,
-		ListaJugadores:    listaJugadores,
+	notificarMovimiento(wsc.hub, movimiento)
+}
+
+// handleSalir maneja la acción de salir de una reta
+func (wsc *WebSocketController) handleSalir(client *adapters.Client, msg entities.WebSocketMessage) {
+	// Validar campos necesarios
+	if msg.RetaID == "" {
+		wsc.sendError(client, "Campos requeridos: reta_id")
+		return
+	}
+	if !identidadValida(client, msg.UsuarioID) {
+		wsc.sendError(client, errIdentidad)
+		return
 	}
 
-	if err := wsc.hub.BroadcastToZone(client.ZonaID, broadcastMsg); err != nil {
-		log.Printf("Error al hacer broadcast: %v", err)
+	// Ejecutar el caso de uso con la identidad autenticada de la conexión
+	movimiento, err := wsc.salirUseCase.Execute(msg.RetaID, client.UsuarioID)
+	if err != nil {
+		wsc.sendError(client, err.Error())
+		return
 	}
+
+	// Broadcast a la zona de la reta y aviso directo a quien entró desde la lista de espera
+	notificarMovimiento(wsc.hub, movimiento)
 }
 
 // handleCrear maneja la acción de crear una nueva reta
 func (wsc *WebSocketController) handleCrear(client *adapters.Client, msg entities.WebSocketMessage) {
 	// Validar campos necesarios
-	if msg.Titulo == "" || msg.FechaHora == "" || msg.MaxJugadores == 0 || msg.CreadorNombre == "" {
-		wsc.sendError(client, "Campos requeridos: titulo, fecha_hora, max_jugadores, creador_nombre")
+	if msg.Titulo == "" || msg.FechaHora == "" || msg.MaxJugadores == 0 {
+		wsc.sendError(client, "Campos requeridos: titulo, fecha_hora, max_jugadores")
 		return
 	}
-
-	// Generar creador_id automáticamente si no se envía
-	creadorID := msg.CreadorID
-	if creadorID == "" {
-		creadorID = uuid.New().String()
+	if !identidadValida(client, msg.CreadorID) {
+		wsc.sendError(client, errIdentidad)
+		return
 	}
 
-	// Ejecutar el caso de uso
+	// Ejecutar el caso de uso; el creador siempre es el usuario autenticado
 	retaCreada, primerJugador, err := wsc.crearRetaUseCase.Execute(
 		msg.ZonaID,
 		msg.Titulo,
 		msg.FechaHora,
 		msg.MaxJugadores,
-		creadorID,
-		msg.CreadorNombre,
+		client.UsuarioID,
+		client.Nombre,
+		msg.Ubicacion,
+		msg.CanchaID,
+		msg.DuracionMinutos,
 	)
 	if err != nil {
 		wsc.sendError(client, err.Error())
 		return
 	}
 
-	// Preparar mensaje de broadcast
-	listaJugadores := []entities.Jugador{*primerJugador}
+	// Broadcast a todos los clientes de la zona
+	notificarNuevaReta(wsc.hub, retaCreada, primerJugador)
+}
 
-	broadcastMsg := entities.BroadcastMessage{
-		Status: "nueva_reta",
-		Reta: &entities.RetaInfo{
-			ID:                retaCreada.ID,
-			Titulo:            retaCreada.Titulo,
-			FechaHora:         retaCreada.FechaHora.Format("2006-01-02 15:04:05"),
-			MaxJugadores:      retaCreada.MaxJugadores,
-			JugadoresActuales: retaCreada.JugadoresActuales,
-			ListaJugadores:    listaJugadores,
-		},
+// handleEditarReta maneja la acción del creador para editar título, fecha o cupo de su reta
+func (wsc *WebSocketController) handleEditarReta(client *adapters.Client, msg entities.WebSocketMessage) {
+	if msg.RetaID == "" {
+		wsc.sendError(client, "Campos requeridos: reta_id")
+		return
 	}
 
-	// Broadcast a todos los clientes de la zona
-	if err := wsc.hub.BroadcastToZone(client.ZonaID, broadcastMsg); err != nil {
-		log.Printf("Error al hacer broadcast: %v", err)
+	reta, movimiento, err := wsc.editarRetaUseCase.Execute(msg.RetaID, client.UsuarioID, msg.Titulo, msg.FechaHora, msg.MaxJugadores, msg.Ubicacion, msg.DuracionMinutos)
+	if err != nil {
+		wsc.sendError(client, err.Error())
+		return
 	}
+
+	notificarRetaActualizada(wsc.hub, reta, movimiento)
+}
+
+// handleCancelarReta maneja la acción del creador para cancelar su reta
+func (wsc *WebSocketController) handleCancelarReta(client *adapters.Client, msg entities.WebSocketMessage) {
+	if msg.RetaID == "" {
+		wsc.sendError(client, "Campos requeridos: reta_id")
+		return
+	}
+
+	reta, err := wsc.cancelarRetaUseCase.Execute(msg.RetaID, client.UsuarioID)
+	if err != nil {
+		wsc.sendError(client, err.Error())
+		return
+	}
+
+	notificarRetaCancelada(wsc.hub, reta)
 }
 
 // sendError envía un mensaje de error solo al cliente específico
@@ -261,9 +498,7 @@ func (wsc *WebSocketController) sendError(client *adapters.Client, mensaje strin
 		return
 	}
 
-	select {
-	case client.Send <- msgBytes:
-	default:
+	if !client.Enviar(msgBytes) {
 		log.Printf("No se pudo enviar mensaje de error al cliente")
 	}
 }
@@ -281,9 +516,7 @@ func (wsc *WebSocketController) sendSuccess(client *adapters.Client, status stri
 		return
 	}
 
-	select {
-	case client.Send <- msgBytes:
-	default:
+	if !client.Enviar(msgBytes) {
 		log.Printf("No se pudo enviar mensaje de éxito al cliente")
 	}
 }
@@ -291,36 +524,349 @@ func (wsc *WebSocketController) sendSuccess(client *adapters.Client, status stri
 // handleEnviarMensaje maneja la acción de enviar un mensaje al chat en vivo de una reta
 func (wsc *WebSocketController) handleEnviarMensaje(client *adapters.Client, msg entities.WebSocketMessage) {
 	// Validar campos necesarios
-	if msg.RetaID == "" || msg.UsuarioID == "" || msg.Texto == "" {
-		wsc.sendError(client, "Campos requeridos: reta_id, usuario_id, texto")
+	if msg.RetaID == "" || msg.Texto == "" {
+		wsc.sendError(client, "Campos requeridos: reta_id, texto")
+		return
+	}
+	if !identidadValida(client, msg.UsuarioID) {
+		wsc.sendError(client, errIdentidad)
 		return
 	}
 
 	// Ejecutar el caso de uso
-	mensaje, err := wsc.enviarMensajeUseCase.Execute(msg.RetaID, msg.UsuarioID, msg.Texto)
+	mensaje, err := wsc.enviarMensajeUseCase.Execute(msg.RetaID, client.UsuarioID, msg.Texto)
 	if err != nil {
 		wsc.sendError(client, err.Error())
 		return
 	}
 
-	// Broadcast a todos los clientes de la zona
+	// Quien escribe queda suscrito al chat para recibir su propio mensaje y las respuestas
+	wsc.hub.Subscribe(client, adapters.TopicReta(msg.RetaID))
+
+	// Broadcast solo a los suscriptores del chat de la reta
 	broadcastMsg := entities.BroadcastMessage{
 		Status:      "nuevo_mensaje",
 		RetaID:      msg.RetaID,
 		MensajeChat: mensaje,
 	}
 
-	if err := wsc.hub.BroadcastToZone(client.ZonaID, broadcastMsg); err != nil {
+	if err := wsc.hub.BroadcastToReta(msg.RetaID, broadcastMsg); err != nil {
 		log.Printf("Error al hacer broadcast de mensaje: %v", err)
 	}
 }
 
+// handleSuscribirChat suscribe la conexión al chat de una reta (además de su zona) y le envía el historial
+func (wsc *WebSocketController) handleSuscribirChat(client *adapters.Client, msg entities.WebSocketMessage) {
+	if msg.RetaID == "" {
+		wsc.sendError(client, "Campos requeridos: reta_id")
+		return
+	}
+	if err := wsc.accesoChatUseCase.Execute(msg.RetaID, client.UsuarioID); err != nil {
+		wsc.sendError(client, err.Error())
+		return
+	}
+
+	// Suscribir antes de leer el historial para no perder mensajes entre ambos pasos
+	topic := adapters.TopicReta(msg.RetaID)
+	seq := wsc.hub.Subscribe(client, topic)
+
+	// Solo se envía la página más reciente; el resto se pide con "cargar_mas"
+	pagina, err := wsc.historialChatUseCase.Execute(msg.RetaID, client.UsuarioID, entities.ConsultaMensajes{Limite: msg.Limite})
+	if err != nil {
+		wsc.hub.Unsubscribe(client, topic)
+		wsc.sendError(client, err.Error())
+		return
+	}
+
+	wsc.enviarPaginaChat(client, "historial_chat", msg.RetaID, pagina, seq)
+}
+
+// handleReanudar reanuda la sesión de una conexión nueva: por cada topic reenvía desde el buffer los eventos
+// con seq mayor al último visto; si el hueco ya no está en el buffer (o cambió el epoch) envía el snapshot completo
+func (wsc *WebSocketController) handleReanudar(client *adapters.Client, msg entities.WebSocketMessage) {
+	epochValido := msg.Epoch != "" && msg.Epoch == wsc.hub.Epoch()
+
+	if msg.ZonaID != "" && (msg.ZonaID == client.ZonaID || wsc.zonaValida(client, msg.ZonaID)) {
+		topic := adapters.TopicZona(msg.ZonaID)
+		ultimoSeq, tieneSeq := msg.Secuencias[topic]
+
+		oldZona := client.ZonaID
+		client.ZonaID = msg.ZonaID
+		resultado := wsc.hub.ResumeZone(client, oldZona, msg.ZonaID, ultimoSeq, epochValido && tieneSeq)
+		if resultado.Reanudado {
+			wsc.sendReanudado(client, topic, resultado)
+		} else {
+			wsc.enviarRetasZona(client, entities.FiltroRetas{IncluirCerradas: msg.IncluirCerradas}, resultado.Seq)
+		}
+	}
+
+	for topic, ultimoSeq := range msg.Secuencias {
+		switch {
+		case strings.HasPrefix(topic, adapters.TopicReta("")):
+			retaID := strings.TrimPrefix(topic, adapters.TopicReta(""))
+			if retaID == "" {
+				continue
+			}
+			if err := wsc.accesoChatUseCase.Execute(retaID, client.UsuarioID); err != nil {
+				wsc.sendError(client, err.Error())
+				continue
+			}
+
+			var resultado adapters.ResultadoSuscripcion
+			if epochValido {
+				resultado = wsc.hub.Resume(client, topic, ultimoSeq)
+			} else {
+				resultado.Seq = wsc.hub.Subscribe(client, topic)
+			}
+			if resultado.Reanudado {
+				wsc.sendReanudado(client, topic, resultado)
+				continue
+			}
+
+			// Hueco demasiado grande: se envía la página más reciente del chat como snapshot
+			pagina, err := wsc.historialChatUseCase.Execute(retaID, client.UsuarioID, entities.ConsultaMensajes{})
+			if err != nil {
+				wsc.hub.Unsubscribe(client, topic)
+				wsc.sendError(client, err.Error())
+				continue
+			}
+			wsc.enviarPaginaChat(client, "historial_chat", retaID, pagina, resultado.Seq)
+
+		case topic == adapters.TopicUsuario(client.UsuarioID) && epochValido:
+			// Los mensajes directos (promovido, etc.) no tienen snapshot; solo se reenvían si siguen en el buffer
+			if resultado := wsc.hub.Resume(client, topic, ultimoSeq); resultado.Reanudado {
+				wsc.sendReanudado(client, topic, resultado)
+			}
+		}
+	}
+}
+
+// sendReanudado confirma al cliente que se reenviaron los eventos perdidos de un topic
+func (wsc *WebSocketController) sendReanudado(client *adapters.Client, topic string, resultado adapters.ResultadoSuscripcion) {
+	reanudadoMsg := entities.BroadcastMessage{
+		Seq:     resultado.Seq,
+		Topic:   topic,
+		Status:  "reanudado",
+		Mensaje: fmt.Sprintf("Se reenviaron %d eventos perdidos", resultado.Reenviados),
+	}
+	msgBytes, _ := json.Marshal(reanudadoMsg)
+	client.Enviar(msgBytes)
+}
+
+// handleCargarMas envía la página anterior (antes) o posterior (despues) al mensaje indicado
+func (wsc *WebSocketController) handleCargarMas(client *adapters.Client, msg entities.WebSocketMessage) {
+	if msg.RetaID == "" {
+		wsc.sendError(client, "Campos requeridos: reta_id")
+		return
+	}
+
+	pagina, err := wsc.historialChatUseCase.Execute(msg.RetaID, client.UsuarioID, entities.ConsultaMensajes{
+		Antes:   msg.Antes,
+		Despues: msg.Despues,
+		Limite:  msg.Limite,
+	})
+	if err != nil {
+		wsc.sendError(client, err.Error())
+		return
+	}
+
+	wsc.enviarPaginaChat(client, "mas_mensajes", msg.RetaID, pagina, 0)
+}
+
+// handleEscribiendo difunde "escribiendo" al chat de la reta si la conexión está suscrita a él;
+// los eventos que llegan antes de intervaloEscribiendo se descartan
+func (wsc *WebSocketController) handleEscribiendo(client *adapters.Client, msg entities.WebSocketMessage, limitador *limitadorEscribiendo) {
+	if msg.RetaID == "" {
+		wsc.sendError(client, "Campos requeridos: reta_id")
+		return
+	}
+	if !wsc.hub.Suscrito(client, adapters.TopicReta(msg.RetaID)) {
+		wsc.sendError(client, "Suscríbete al chat de la reta antes de enviar escribiendo")
+		return
+	}
+	if !limitador.permitir(msg.RetaID) {
+		return
+	}
+
+	publicarEscribiendo(wsc.hub, client, msg.RetaID)
+}
+
+// handleMarcarLeido guarda el último mensaje leído del chat de una reta y difunde la confirmación
+func (wsc *WebSocketController) handleMarcarLeido(client *adapters.Client, msg entities.WebSocketMessage) {
+	if msg.RetaID == "" || msg.MensajeID == "" {
+		wsc.sendError(client, "Campos requeridos: reta_id, mensaje_id")
+		return
+	}
+
+	lectura, err := wsc.marcarLeidoUseCase.Execute(msg.RetaID, client.UsuarioID, msg.MensajeID)
+	if err != nil {
+		wsc.sendError(client, err.Error())
+		return
+	}
+
+	notificarLectura(wsc.hub, lectura, client.Nombre)
+}
+
+// handleEditarMensaje corrige el texto de un mensaje propio dentro de la ventana de edición
+func (wsc *WebSocketController) handleEditarMensaje(client *adapters.Client, msg entities.WebSocketMessage) {
+	if msg.RetaID == "" || msg.MensajeID == "" || msg.Texto == "" {
+		wsc.sendError(client, "Campos requeridos: reta_id, mensaje_id, texto")
+		return
+	}
+
+	mensaje, err := wsc.editarMensajeUseCase.Execute(msg.RetaID, msg.MensajeID, client.UsuarioID, msg.Texto)
+	if err != nil {
+		wsc.sendError(client, err.Error())
+		return
+	}
+
+	notificarCambioMensaje(wsc.hub, "mensaje_editado", mensaje)
+}
+
+// handleEliminarMensaje elimina un mensaje propio o, si es el creador de la reta, de cualquier usuario
+func (wsc *WebSocketController) handleEliminarMensaje(client *adapters.Client, msg entities.WebSocketMessage) {
+	if msg.RetaID == "" || msg.MensajeID == "" {
+		wsc.sendError(client, "Campos requeridos: reta_id, mensaje_id")
+		return
+	}
+
+	mensaje, err := wsc.eliminarMensajeUseCase.Execute(msg.RetaID, msg.MensajeID, client.UsuarioID)
+	if err != nil {
+		wsc.sendError(client, err.Error())
+		return
+	}
+
+	notificarCambioMensaje(wsc.hub, "mensaje_eliminado", mensaje)
+}
+
+// sancionar aplica la acción "silenciar", "expulsar" o "quitar_sancion" del creador de la reta y la notifica
+func (wsc *WebSocketController) sancionar(client *adapters.Client, accion, retaID, sancionadoID string, minutos int, motivo string) error {
+	if accion == "quitar_sancion" {
+		if err := wsc.quitarSancionUseCase.Execute(retaID, client.UsuarioID, sancionadoID); err != nil {
+			return err
+		}
+		notificarSancion(wsc.hub, "sancion_retirada", &entities.Sancion{RetaID: retaID, UsuarioID: sancionadoID})
+		return nil
+	}
+
+	tipo := entities.TipoSilencio
+	if accion == "expulsar" {
+		tipo = entities.TipoExpulsion
+	}
+	sancion, err := wsc.sancionarUsuarioUseCase.Execute(retaID, client.UsuarioID, sancionadoID, tipo, minutos, motivo)
+	if err != nil {
+		return err
+	}
+	notificarSancion(wsc.hub, "usuario_sancionado", sancion)
+	return nil
+}
+
+// handlePresencia envía los usuarios en línea del chat de una reta (si viene reta_id) o de una zona
+// (zona_id o la zona actual de la conexión)
+func (wsc *WebSocketController) handlePresencia(client *adapters.Client, msg entities.WebSocketMessage) {
+	presenciaMsg := entities.BroadcastMessage{Status: "presencia"}
+
+	var err error
+	switch {
+	case msg.RetaID != "":
+		if err := wsc.accesoChatUseCase.Execute(msg.RetaID, client.UsuarioID); err != nil {
+			wsc.sendError(client, err.Error())
+			return
+		}
+		presenciaMsg.RetaID = msg.RetaID
+		presenciaMsg.Usuarios, err = wsc.hub.Presencia(adapters.TopicReta(msg.RetaID))
+	case msg.ZonaID != "" || client.ZonaID != "":
+		zonaID := msg.ZonaID
+		if zonaID == "" {
+			zonaID = client.ZonaID
+		}
+		presenciaMsg.Mensaje = "Usuarios en línea en zona: " + zonaID
+		presenciaMsg.Usuarios, err = wsc.hub.Presencia(adapters.TopicZona(zonaID))
+	default:
+		wsc.sendError(client, "Campos requeridos: zona_id o reta_id")
+		return
+	}
+	if err != nil {
+		log.Printf("Error al consultar la presencia: %v", err)
+		wsc.sendError(client, "Error al consultar los usuarios en línea")
+		return
+	}
+
+	msgBytes, err := json.Marshal(presenciaMsg)
+	if err != nil {
+		log.Printf("Error al serializar presencia: %v", err)
+		return
+	}
+	client.Enviar(msgBytes)
+}
+
+// handleCercanas responde con las retas próximas a la posición enviada, de todas las zonas; no
+// requiere estar conectado a una zona ni suscribe a nada
+func (wsc *WebSocketController) handleCercanas(client *adapters.Client, msg entities.WebSocketMessage) {
+	if msg.Ubicacion == nil {
+		wsc.sendError(client, "Campos requeridos: ubicacion (latitud, longitud)")
+		return
+	}
+
+	cercanas, err := wsc.cercanasUseCase.Execute(entities.BusquedaCercana{
+		Posicion:   *msg.Ubicacion,
+		RadioKm:    msg.RadioKm,
+		Limite:     msg.Limite,
+		ConLugares: msg.ConLugares,
+	})
+	if err != nil {
+		if errors.Is(err, entities.ErrUbicacionInvalida) || errors.Is(err, entities.ErrRadioBusqueda) {
+			wsc.sendError(client, err.Error())
+			return
+		}
+		log.Printf("Error al buscar retas cercanas: %v", err)
+		wsc.sendError(client, "Error al buscar retas cercanas")
+		return
+	}
+
+	cercanasMsg := entities.BroadcastMessage{
+		Status:   "retas_cercanas",
+		Mensaje:  fmt.Sprintf("%d retas cercanas", len(cercanas)),
+		Cercanas: cercanas,
+	}
+	msgBytes, _ := json.Marshal(cercanasMsg)
+	client.Enviar(msgBytes)
+}
+
+// enviarPaginaChat envía al cliente una página del historial con el status indicado; seq (si no es 0)
+// es el del topic del chat al momento de suscribirse
+func (wsc *WebSocketController) enviarPaginaChat(client *adapters.Client, status, retaID string, pagina *entities.PaginaMensajes, seq uint64) {
+	paginaMsg := entities.BroadcastMessage{
+		Seq:      seq,
+		Status:   status,
+		RetaID:   retaID,
+		Mensajes: pagina.Mensajes,
+		HayMas:   pagina.HayMas,
+	}
+	msgBytes, _ := json.Marshal(paginaMsg)
+	client.Enviar(msgBytes)
+}
+
 // ChatMessage representa el mensaje JSON que recibe el endpoint /ws/retas/chat
 type ChatMessage struct {
+	Accion    string `json:"accion,omitempty"` // Vacío para suscribirse o enviar texto; "cargar_mas", "presencia", "escribiendo", "marcar_leido", "editar_mensaje", "eliminar_mensaje", "silenciar", "expulsar" o "quitar_sancion"
 	RetaID    string `json:"reta_id"`
-	ZonaID    string `json:"zona_id"`
+	ZonaID    string `json:"zona_id,omitempty"` // Ya no es necesario: el chat se enruta por reta
 	UsuarioID string `json:"usuario_id,omitempty"`
 	Texto     string `json:"texto,omitempty"`
+
+	// Cursor y tamaño de página para "cargar_mas"
+	Antes   string `json:"antes,omitempty"`
+	Despues string `json:"despues,omitempty"`
+	Limite  int    `json:"limite,omitempty"`
+
+	// Último mensaje leído para "marcar_leido", o mensaje a editar o eliminar
+	MensajeID string `json:"mensaje_id,omitempty"`
+
+	// Usuario, duración y motivo para "silenciar", "expulsar" y "quitar_sancion"
+	SancionadoID string `json:"sancionado_id,omitempty"`
+	Minutos      int    `json:"minutos,omitempty"`
+	Motivo       string `json:"motivo,omitempty"`
 }
 
 // ChatBroadcast representa el mensaje de broadcast del chat
@@ -330,29 +876,58 @@ type ChatBroadcast struct {
 	Mensaje     string             `json:"mensaje,omitempty"`
 	MensajeChat *entities.Mensaje  `json:"mensaje_chat,omitempty"`
 	Mensajes    []entities.Mensaje `json:"mensajes,omitempty"`
+	HayMas      bool               `json:"hay_mas,omitempty"`
+
+	Usuarios []entities.UsuarioPresente `json:"usuarios,omitempty"`
 }
 
 // HandleChat maneja las conexiones WebSocket dedicadas al chat en vivo
 // Endpoint: /ws/retas/chat
 func (wsc *WebSocketController) HandleChat(c *gin.Context) {
+	claims, err := wsc.autenticarHandshake(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+	if wsc.rechazarBloqueado(c, claims.UsuarioID) {
+		return
+	}
+	if wsc.rechazarCuenta(c, claims.UsuarioID) {
+		return
+	}
+
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		log.Printf("Error al actualizar a WebSocket (chat): %v", err)
 		return
 	}
+	conn.SetReadLimit(wsc.limites.tamanoMaximo)
 
 	client := &adapters.Client{
-		Conn: conn,
-		Send: make(chan []byte, 256),
+		Conn:      conn,
+		Send:      make(chan []byte, 256),
+		UsuarioID: claims.UsuarioID,
+		Nombre:    claims.Nombre,
 	}
 
-	var retaID string
+	// Registrar en el hub: el cliente solo recibe los chats de las retas a las que se suscriba
+	wsc.hub.RegisterClient(client)
+
+	// Retas cuyo chat sigue esta conexión; la primera es la que se usa si un mensaje no trae reta_id
+	var retaPrincipal string
+	retasSuscritas := make(map[string]bool)
+	escribiendo := newLimitadorEscribiendo()
+	limitador := wsc.limites.nuevaConexion(client.UsuarioID)
 
+	// Si se desconectó por abuso, WritePump envía el último error y el frame de cierre antes de cerrar
 	defer func() {
-		if client.ZonaID != "" {
-			wsc.hub.UnregisterClient(client)
+		wsc.hub.UnregisterClient(client)
+		if !client.Desconectando() {
+			conn.Close()
 		}
-		conn.Close()
 	}()
 
 	// Iniciar escritura en goroutine
@@ -369,9 +944,7 @@ func (wsc *WebSocketController) HandleChat(c *gin.Context) {
 	for {
 		_, message, err := conn.ReadMessage()
 		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure, websocket.CloseNormalClosure) {
-				log.Printf("Error inesperado (chat): %v", err)
-			}
+			errorDeLectura(err, " (chat)")
 			break
 		}
 
@@ -385,45 +958,151 @@ func (wsc *WebSocketController) HandleChat(c *gin.Context) {
 			continue
 		}
 
-		// Primer mensaje: registrar en zona y enviar historial
-		if client.ZonaID == "" && chatMsg.ZonaID != "" && chatMsg.RetaID != "" {
-			client.ZonaID = chatMsg.ZonaID
-			retaID = chatMsg.RetaID
-			wsc.hub.RegisterClient(client)
+		// Un mensaje sin acción con texto cuenta como mensaje de chat; sin texto, como suscripción
+		accion := chatMsg.Accion
+		if accion == "" && chatMsg.Texto != "" {
+			accion = "texto"
+		}
+		if accion != "escribiendo" && !wsc.aplicarLimite(client, limitador, accion) {
+			if client.Desconectando() {
+				break
+			}
+			continue
+		}
 
-			// Enviar historial de chat de esta reta
-			mensajes, err := wsc.historialChatUseCase.Execute(retaID)
-			if err != nil {
-				log.Printf("Error al obtener historial de chat para reta %s: %v", retaID, err)
-				mensajes = []entities.Mensaje{}
+		// Un mensaje sin texto con un reta_id nuevo suscribe la conexión a ese chat y envía la página más reciente
+		if chatMsg.Accion == "" && chatMsg.Texto == "" && chatMsg.RetaID != "" && !retasSuscritas[chatMsg.RetaID] {
+			if err := wsc.accesoChatUseCase.Execute(chatMsg.RetaID, client.UsuarioID); err != nil {
+				wsc.sendChatError(client, err.Error())
+				continue
 			}
 
-			initMsg := ChatBroadcast{
-				Status:   "historial_chat",
-				RetaID:   retaID,
-				Mensajes: mensajes,
+			topic := adapters.TopicReta(chatMsg.RetaID)
+			wsc.hub.Subscribe(client, topic)
+
+			pagina, err := wsc.historialChatUseCase.Execute(chatMsg.RetaID, client.UsuarioID, entities.ConsultaMensajes{Limite: chatMsg.Limite})
+			if err != nil {
+				log.Printf("Error al obtener historial de chat para reta %s: %v", chatMsg.RetaID, err)
+				wsc.hub.Unsubscribe(client, topic)
+				wsc.sendChatError(client, err.Error())
+				continue
 			}
-			msgBytes, _ := json.Marshal(initMsg)
-			select {
-			case client.Send <- msgBytes:
-			default:
+
+			retasSuscritas[chatMsg.RetaID] = true
+			if retaPrincipal == "" {
+				retaPrincipal = chatMsg.RetaID
 			}
+
+			wsc.enviarPaginaChatDedicado(client, "historial_chat", chatMsg.RetaID, pagina)
 			continue
 		}
 
-		// Validar que ya se haya registrado
+		// Validar que ya se haya suscrito a la reta del mensaje
+		retaID := chatMsg.RetaID
 		if retaID == "" {
-			wsc.sendChatError(client, "Primero envía reta_id y zona_id para unirte al chat")
+			retaID = retaPrincipal
+		}
+		if !retasSuscritas[retaID] {
+			wsc.sendChatError(client, "Primero envía reta_id para unirte al chat")
+			continue
+		}
+
+		// Paginación del historial hacia atrás (antes) o hacia adelante (despues)
+		if chatMsg.Accion == "cargar_mas" {
+			pagina, err := wsc.historialChatUseCase.Execute(retaID, client.UsuarioID, entities.ConsultaMensajes{
+				Antes:   chatMsg.Antes,
+				Despues: chatMsg.Despues,
+				Limite:  chatMsg.Limite,
+			})
+			if err != nil {
+				wsc.sendChatError(client, err.Error())
+				continue
+			}
+			wsc.enviarPaginaChatDedicado(client, "mas_mensajes", retaID, pagina)
+			continue
+		}
+		if chatMsg.Accion == "escribiendo" {
+			if escribiendo.permitir(retaID) {
+				publicarEscribiendo(wsc.hub, client, retaID)
+			}
+			continue
+		}
+		if chatMsg.Accion == "marcar_leido" {
+			if chatMsg.MensajeID == "" {
+				wsc.sendChatError(client, "Campos requeridos: mensaje_id")
+				continue
+			}
+			lectura, err := wsc.marcarLeidoUseCase.Execute(retaID, client.UsuarioID, chatMsg.MensajeID)
+			if err != nil {
+				wsc.sendChatError(client, err.Error())
+				continue
+			}
+			notificarLectura(wsc.hub, lectura, client.Nombre)
+			continue
+		}
+		if chatMsg.Accion == "editar_mensaje" || chatMsg.Accion == "eliminar_mensaje" {
+			if chatMsg.MensajeID == "" {
+				wsc.sendChatError(client, "Campos requeridos: mensaje_id")
+				continue
+			}
+
+			var mensaje *entities.Mensaje
+			status := "mensaje_eliminado"
+			if chatMsg.Accion == "editar_mensaje" {
+				status = "mensaje_editado"
+				mensaje, err = wsc.editarMensajeUseCase.Execute(retaID, chatMsg.MensajeID, client.UsuarioID, chatMsg.Texto)
+			} else {
+				mensaje, err = wsc.eliminarMensajeUseCase.Execute(retaID, chatMsg.MensajeID, client.UsuarioID)
+			}
+			if err != nil {
+				wsc.sendChatError(client, err.Error())
+				continue
+			}
+			notificarCambioMensaje(wsc.hub, status, mensaje)
+			continue
+		}
+		if chatMsg.Accion == "silenciar" || chatMsg.Accion == "expulsar" || chatMsg.Accion == "quitar_sancion" {
+			if chatMsg.SancionadoID == "" {
+				wsc.sendChatError(client, "Campos requeridos: sancionado_id")
+				continue
+			}
+			if err := wsc.sancionar(client, chatMsg.Accion, retaID, chatMsg.SancionadoID, chatMsg.Minutos, chatMsg.Motivo); err != nil {
+				wsc.sendChatError(client, err.Error())
+			}
+			continue
+		}
+		if chatMsg.Accion == "presencia" {
+			usuarios, err := wsc.hub.Presencia(adapters.TopicReta(retaID))
+			if err != nil {
+				log.Printf("Error al consultar la presencia: %v", err)
+				wsc.sendChatError(client, "Error al consultar los usuarios en línea")
+				continue
+			}
+			presenciaMsg := ChatBroadcast{
+				Status:   "presencia",
+				RetaID:   retaID,
+				Usuarios: usuarios,
+			}
+			msgBytes, _ := json.Marshal(presenciaMsg)
+			client.Enviar(msgBytes)
+			continue
+		}
+		if chatMsg.Accion != "" {
+			wsc.sendChatError(client, "Acción no reconocida: "+chatMsg.Accion)
 			continue
 		}
 
 		// Enviar mensaje de chat
-		if chatMsg.UsuarioID == "" || chatMsg.Texto == "" {
-			wsc.sendChatError(client, "Campos requeridos: usuario_id, texto")
+		if chatMsg.Texto == "" {
+			wsc.sendChatError(client, "Campos requeridos: texto")
+			continue
+		}
+		if !identidadValida(client, chatMsg.UsuarioID) {
+			wsc.sendChatError(client, errIdentidad)
 			continue
 		}
 
-		mensaje, err := wsc.enviarMensajeUseCase.Execute(retaID, chatMsg.UsuarioID, chatMsg.Texto)
+		mensaje, err := wsc.enviarMensajeUseCase.Execute(retaID, client.UsuarioID, chatMsg.Texto)
 		if err != nil {
 			wsc.sendChatError(client, err.Error())
 			continue
@@ -435,12 +1114,24 @@ func (wsc *WebSocketController) HandleChat(c *gin.Context) {
 			MensajeChat: mensaje,
 		}
 
-		if err := wsc.hub.BroadcastToZone(client.ZonaID, broadcastMsg); err != nil {
+		if err := wsc.hub.BroadcastToReta(retaID, broadcastMsg); err != nil {
 			log.Printf("Error al hacer broadcast de mensaje de chat: %v", err)
 		}
 	}
 }
 
+// enviarPaginaChatDedicado envía una página del historial al cliente de /ws/retas/chat
+func (wsc *WebSocketController) enviarPaginaChatDedicado(client *adapters.Client, status, retaID string, pagina *entities.PaginaMensajes) {
+	paginaMsg := ChatBroadcast{
+		Status:   status,
+		RetaID:   retaID,
+		Mensajes: pagina.Mensajes,
+		HayMas:   pagina.HayMas,
+	}
+	msgBytes, _ := json.Marshal(paginaMsg)
+	client.Enviar(msgBytes)
+}
+
 // sendChatError envía un error al cliente del chat
 func (wsc *WebSocketController) sendChatError(client *adapters.Client, mensaje string) {
 	errorMsg := ChatBroadcast{
@@ -454,9 +1145,7 @@ func (wsc *WebSocketController) sendChatError(client *adapters.Client, mensaje s
 		return
 	}
 
-	select {
-	case client.Send <- msgBytes:
-	default:
+	if !client.Enviar(msgBytes) {
 		log.Printf("No se pudo enviar mensaje de error al cliente (chat)")
 	}
 }
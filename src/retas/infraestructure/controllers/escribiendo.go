@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"log"
+	"time"
+)
+
+// intervaloEscribiendo es el tiempo mínimo entre dos eventos "escribiendo" de una conexión en la misma reta;
+// los que llegan antes se descartan sin responder
+const intervaloEscribiendo = 2 * time.Second
+
+// limitadorEscribiendo recuerda el último "escribiendo" de una conexión por reta. Solo lo usa
+// el goroutine de lectura de la conexión, por lo que no necesita mutex
+type limitadorEscribiendo struct {
+	ultimo map[string]time.Time
+}
+
+func newLimitadorEscribiendo() *limitadorEscribiendo {
+	return &limitadorEscribiendo{
+		ultimo: make(map[string]time.Time),
+	}
+}
+
+// permitir indica si ya pasó el intervalo desde el último evento en la reta y, si es así, lo registra
+func (l *limitadorEscribiendo) permitir(retaID string) bool {
+	ahora := time.Now()
+	if ultimo, ok := l.ultimo[retaID]; ok && ahora.Sub(ultimo) < intervaloEscribiendo {
+		return false
+	}
+	l.ultimo[retaID] = ahora
+	return true
+}
+
+// publicarEscribiendo avisa al chat de la reta que el usuario está escribiendo. El evento es efímero:
+// no se guarda en BD ni en el buffer de reanudación
+func publicarEscribiendo(hub *adapters.Hub, client *adapters.Client, retaID string) {
+	escribiendoMsg := entities.BroadcastMessage{
+		Status: "escribiendo",
+		RetaID: retaID,
+		Usuario: &entities.UsuarioPresente{
+			UsuarioID: client.UsuarioID,
+			Nombre:    client.Nombre,
+		},
+	}
+	if err := hub.PublishEfimero(adapters.TopicReta(retaID), escribiendoMsg); err != nil {
+		log.Printf("Error al publicar escribiendo: %v", err)
+	}
+}
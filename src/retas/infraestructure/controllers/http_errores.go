@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"games-football-api/src/retas/domain/entities"
+	"net/http"
+)
+
+// statusPorError traduce los errores de dominio a códigos HTTP para los controllers REST
+func statusPorError(err error) int {
+	switch {
+	case errors.Is(err, entities.ErrRetaNoEncontrada), errors.Is(err, entities.ErrZonaNoEncontrada), errors.Is(err, entities.ErrMensajeNoEncontrado),
+		errors.Is(err, entities.ErrSinSancion), errors.Is(err, core.ErrCanchaNoEncontrada), errors.Is(err, entities.ErrSerieNoEncontrada),
+		errors.Is(err, entities.ErrSinSuscripcion):
+		return http.StatusNotFound
+	case errors.Is(err, entities.ErrSoloCreador), errors.Is(err, entities.ErrSoloAutor), errors.Is(err, entities.ErrSinPermisoEliminar),
+		errors.Is(err, entities.ErrSilenciado), errors.Is(err, entities.ErrExpulsadoDelChat), errors.Is(err, entities.ErrSinAccesoChat), errors.Is(err, entities.ErrSoloCreadorSerie),
+		errors.Is(err, entities.ErrRolInsuficiente), errors.Is(err, entities.ErrCuentaSuspendida):
+		return http.StatusForbidden
+	case errors.Is(err, entities.ErrFormatoFecha), errors.Is(err, entities.ErrFechaPasada), errors.Is(err, entities.ErrCursorInvalido),
+		errors.Is(err, entities.ErrSancionInvalida), errors.Is(err, entities.ErrAutoSancion), errors.Is(err, core.ErrMotivoRequerido),
+		errors.Is(err, core.ErrMotivoLargo), errors.Is(err, entities.ErrUbicacionInvalida), errors.Is(err, entities.ErrRadioBusqueda),
+		errors.Is(err, entities.ErrDuracionInvalida), errors.Is(err, entities.ErrCanchaYUbicacion), errors.Is(err, entities.ErrCanchaDeOtraZona),
+		errors.Is(err, entities.ErrSerieDias), errors.Is(err, entities.ErrSerieHora), errors.Is(err, entities.ErrSerieFechaInicio),
+		errors.Is(err, entities.ErrSerieFin), errors.Is(err, entities.ErrSerieHasta), errors.Is(err, entities.ErrSerieOcurrencias),
+		errors.Is(err, entities.ErrSerieSinOcurrencias), errors.Is(err, entities.ErrSerieSinCambios):
+		return http.StatusBadRequest
+	case errors.Is(err, entities.ErrMensajeLargo), errors.Is(err, entities.ErrLenguajeOfensivo),
+		errors.Is(err, entities.ErrEnlaceNoPermitido), errors.Is(err, entities.ErrMensajeRepetido), errors.Is(err, entities.ErrUbicacionFueraDeZona),
+		errors.Is(err, entities.ErrCanchaFueraDeHorario), errors.Is(err, entities.ErrCanchaCapacidad):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusConflict
+	}
+}
@@ -0,0 +1,253 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"games-football-api/src/core"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// Categorías de acciones con límite propio; cada una tiene una cubeta por conexión y otra por usuario
+const (
+	limiteMensajes  = "mensajes"  // enviar_mensaje, editar_mensaje, eliminar_mensaje y texto en /ws/retas/chat
+	limiteRetas     = "retas"     // crear, editar_reta, cancelar_reta
+	limiteAcciones  = "acciones"  // unirse, salir, marcar_leido, sanciones
+	limiteConsultas = "consultas" // cambio de zona, listar_retas, suscribir_chat, cargar_mas, presencia, reanudar, cercanas
+)
+
+// ventanaRechazos es el periodo en el que se cuentan los rechazos de una conexión para detectar abuso
+const ventanaRechazos = time.Minute
+
+// Métricas publicadas en /api/admin/debug/vars
+var (
+	metricaRechazos             = expvar.NewMap("ws_rechazos_por_limite")
+	metricaDesconexionesAbuso   = expvar.NewInt("ws_desconexiones_por_abuso")
+	metricaHandshakesBloqueados = expvar.NewInt("ws_handshakes_bloqueados")
+	metricaMensajesGrandes      = expvar.NewInt("ws_mensajes_demasiado_grandes")
+)
+
+// categoriaDeAccion agrupa las acciones de /ws/retas y /ws/retas/chat por su costo
+func categoriaDeAccion(accion string) string {
+	switch accion {
+	case "enviar_mensaje", "editar_mensaje", "eliminar_mensaje", "texto":
+		return limiteMensajes
+	case "crear", "editar_reta", "cancelar_reta":
+		return limiteRetas
+	case "unirse", "salir", "marcar_leido", "silenciar", "expulsar", "quitar_sancion":
+		return limiteAcciones
+	default:
+		return limiteConsultas
+	}
+}
+
+// LimitesWebSocket contiene los límites de las conexiones WebSocket y los usuarios bloqueados por abuso.
+// Los contadores son de este nodo: con varias réplicas cada una aplica sus propios límites
+type LimitesWebSocket struct {
+	// Tasa de cada categoría por conexión y limitador compartido por usuario
+	porConexion map[string]core.Tasa
+	porUsuario  map[string]*core.LimitadorPorClave
+
+	// tamanoMaximo es el máximo de bytes de un mensaje entrante (SetReadLimit)
+	tamanoMaximo int64
+
+	// Tras rechazosMaximos rechazos en ventanaRechazos se cierra la conexión y el usuario queda
+	// bloqueado durante bloqueo
+	rechazosMaximos int
+	bloqueo         time.Duration
+	bloqueados      map[string]time.Time
+	mu              sync.Mutex
+}
+
+// LimitesWebSocketDesdeEnv lee los límites de WS_LIMITE_<CATEGORIA> (por conexión),
+// WS_LIMITE_USUARIO_<CATEGORIA> (por usuario), WS_TAMANO_MAXIMO, WS_RECHAZOS_MAXIMOS y WS_BLOQUEO_ABUSO
+func LimitesWebSocketDesdeEnv() (*LimitesWebSocket, error) {
+	porDefecto := map[string][2]core.Tasa{
+		limiteMensajes:  {{Rafaga: 5, Periodo: 10 * time.Second}, {Rafaga: 10, Periodo: 10 * time.Second}},
+		limiteRetas:     {{Rafaga: 3, Periodo: time.Minute}, {Rafaga: 5, Periodo: time.Minute}},
+		limiteAcciones:  {{Rafaga: 20, Periodo: time.Minute}, {Rafaga: 40, Periodo: time.Minute}},
+		limiteConsultas: {{Rafaga: 30, Periodo: time.Minute}, {Rafaga: 60, Periodo: time.Minute}},
+	}
+
+	limites := &LimitesWebSocket{
+		porConexion: make(map[string]core.Tasa),
+		porUsuario:  make(map[string]*core.LimitadorPorClave),
+		bloqueados:  make(map[string]time.Time),
+	}
+	for categoria, tasas := range porDefecto {
+		conexion, err := core.TasaDesdeEnv("WS_LIMITE_"+strings.ToUpper(categoria), tasas[0])
+		if err != nil {
+			return nil, err
+		}
+		usuario, err := core.TasaDesdeEnv("WS_LIMITE_USUARIO_"+strings.ToUpper(categoria), tasas[1])
+		if err != nil {
+			return nil, err
+		}
+		limites.porConexion[categoria] = conexion
+		limites.porUsuario[categoria] = core.NewLimitadorPorClave(usuario)
+	}
+
+	tamanoMaximo, err := core.EnteroDesdeEnv("WS_TAMANO_MAXIMO", 4096)
+	if err != nil {
+		return nil, err
+	}
+	limites.tamanoMaximo = int64(tamanoMaximo)
+
+	if limites.rechazosMaximos, err = core.EnteroDesdeEnv("WS_RECHAZOS_MAXIMOS", 20); err != nil {
+		return nil, err
+	}
+	if limites.bloqueo, err = core.DuracionDesdeEnv("WS_BLOQUEO_ABUSO", time.Minute); err != nil {
+		return nil, err
+	}
+
+	return limites, nil
+}
+
+// bloqueoRestante retorna cuánto falta para que el usuario pueda volver a conectarse (0 si no está bloqueado)
+func (l *LimitesWebSocket) bloqueoRestante(usuarioID string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	hasta, ok := l.bloqueados[usuarioID]
+	if !ok {
+		return 0
+	}
+	restante := time.Until(hasta)
+	if restante <= 0 {
+		delete(l.bloqueados, usuarioID)
+		return 0
+	}
+	return restante
+}
+
+func (l *LimitesWebSocket) bloquear(usuarioID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bloqueados[usuarioID] = time.Now().Add(l.bloqueo)
+}
+
+// limitadorConexion lleva las cubetas y los rechazos de una conexión. Solo lo usa el goroutine
+// de lectura de la conexión, por lo que no necesita mutex
+type limitadorConexion struct {
+	limites   *LimitesWebSocket
+	usuarioID string
+	cubetas   map[string]*core.Cubeta
+
+	rechazos      int
+	inicioVentana time.Time
+}
+
+func (l *LimitesWebSocket) nuevaConexion(usuarioID string) *limitadorConexion {
+	cubetas := make(map[string]*core.Cubeta, len(l.porConexion))
+	for categoria, tasa := range l.porConexion {
+		cubetas[categoria] = core.NewCubeta(tasa)
+	}
+	return &limitadorConexion{
+		limites:       l,
+		usuarioID:     usuarioID,
+		cubetas:       cubetas,
+		inicioVentana: time.Now(),
+	}
+}
+
+// permitir consume un token de la conexión y otro del usuario para la categoría de la acción. Si se rechaza,
+// retorna cuánto esperar y si la conexión ya superó los rechazos permitidos (abuso)
+func (lc *limitadorConexion) permitir(accion string) (permitida bool, espera time.Duration, abuso bool) {
+	categoria := categoriaDeAccion(accion)
+	ahora := time.Now()
+
+	ok, espera := lc.cubetas[categoria].Tomar(ahora)
+	if ok {
+		ok, espera = lc.limites.porUsuario[categoria].Permitir(lc.usuarioID)
+	}
+	if ok {
+		return true, 0, false
+	}
+
+	metricaRechazos.Add(categoria, 1)
+	if ahora.Sub(lc.inicioVentana) > ventanaRechazos {
+		lc.rechazos = 0
+		lc.inicioVentana = ahora
+	}
+	lc.rechazos++
+	return false, espera, lc.rechazos >= lc.limites.rechazosMaximos
+}
+
+// aplicarLimite revisa el límite de la acción; si se rechaza envía el error "limite_excedido" y retorna false.
+// Si la conexión abusa, además la cierra con el código 1008 y bloquea al usuario por un tiempo
+func (wsc *WebSocketController) aplicarLimite(client *adapters.Client, limitador *limitadorConexion, accion string) bool {
+	permitida, espera, abuso := limitador.permitir(accion)
+	if permitida {
+		return true
+	}
+
+	if abuso {
+		wsc.limites.bloquear(client.UsuarioID)
+		metricaDesconexionesAbuso.Add(1)
+		segundos := int(math.Ceil(wsc.limites.bloqueo.Seconds()))
+		log.Printf("Usuario %s desconectado por abuso de límites", client.UsuarioID)
+
+		errorMsg, _ := json.Marshal(entities.BroadcastMessage{
+			Status:       "error",
+			Codigo:       "desconectado_por_abuso",
+			Mensaje:      fmt.Sprintf("Demasiadas solicitudes: se cerró la conexión, vuelve a conectar en %d segundos", segundos),
+			ReintentarEn: segundos,
+		})
+		client.Desconectar(errorMsg, websocket.ClosePolicyViolation, "desconectado_por_abuso")
+		return false
+	}
+
+	errorMsg, _ := json.Marshal(entities.BroadcastMessage{
+		Status:       "error",
+		Codigo:       "limite_excedido",
+		Accion:       accion,
+		Mensaje:      "Demasiadas solicitudes, espera un momento",
+		ReintentarEn: int(math.Ceil(espera.Seconds())),
+	})
+	client.Enviar(errorMsg)
+	return false
+}
+
+// rechazarBloqueado responde 429 si el usuario fue desconectado por abuso y su bloqueo sigue vigente
+func (wsc *WebSocketController) rechazarBloqueado(c *gin.Context, usuarioID string) bool {
+	restante := wsc.limites.bloqueoRestante(usuarioID)
+	if restante <= 0 {
+		return false
+	}
+
+	metricaHandshakesBloqueados.Add(1)
+	segundos := int(math.Ceil(restante.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(segundos))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"status":        "error",
+		"codigo":        "desconectado_por_abuso",
+		"mensaje":       "Conexión bloqueada temporalmente por exceso de solicitudes",
+		"reintentar_en": segundos,
+	})
+	return true
+}
+
+// errorDeLectura registra el motivo por el que terminó la lectura de una conexión
+func errorDeLectura(err error, contexto string) {
+	if errors.Is(err, websocket.ErrReadLimit) {
+		// gorilla ya respondió con el código de cierre 1009 (mensaje demasiado grande)
+		metricaMensajesGrandes.Add(1)
+		log.Printf("Conexión%s cerrada: mensaje mayor al tamaño permitido", contexto)
+		return
+	}
+	if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure, websocket.CloseNormalClosure) {
+		log.Printf("Error inesperado%s: %v", contexto, err)
+	}
+}
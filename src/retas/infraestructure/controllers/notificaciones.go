@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"fmt"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"log"
+)
+
+// notificarMovimiento hace broadcast de "actualizacion" a la zona de la reta y avisa directamente
+// a los jugadores que fueron promovidos desde la lista de espera
+func notificarMovimiento(hub *adapters.Hub, movimiento *entities.MovimientoReta) {
+	broadcastMsg := entities.BroadcastMessage{
+		Status:            "actualizacion",
+		RetaID:            movimiento.RetaID,
+		Estado:            movimiento.Estado,
+		JugadoresActuales: movimiento.JugadoresActuales,
+		ListaJugadores:    movimiento.ListaJugadores,
+		ListaEspera:       movimiento.ListaEspera,
+	}
+	if err := hub.BroadcastToZone(movimiento.ZonaID, broadcastMsg); err != nil {
+		log.Printf("Error al hacer broadcast: %v", err)
+	}
+
+	notificarPromovidos(hub, movimiento)
+}
+
+// notificarCambioMensaje difunde al chat de la reta un mensaje editado ("mensaje_editado") o el marcador
+// de uno eliminado ("mensaje_eliminado") para que los clientes lo reemplacen por id
+func notificarCambioMensaje(hub *adapters.Hub, status string, mensaje *entities.Mensaje) {
+	cambioMsg := entities.BroadcastMessage{
+		Status:      status,
+		RetaID:      mensaje.RetaID,
+		MensajeChat: mensaje,
+	}
+	if err := hub.BroadcastToReta(mensaje.RetaID, cambioMsg); err != nil {
+		log.Printf("Error al hacer broadcast de %s: %v", status, err)
+	}
+}
+
+// notificarSancion avisa al chat de la reta y a las conexiones del usuario sancionado que se aplicó
+// ("usuario_sancionado") o se levantó ("sancion_retirada") una sanción
+func notificarSancion(hub *adapters.Hub, status string, sancion *entities.Sancion) {
+	sancionMsg := entities.BroadcastMessage{
+		Status:  status,
+		RetaID:  sancion.RetaID,
+		Sancion: sancion,
+	}
+	if err := hub.BroadcastToReta(sancion.RetaID, sancionMsg); err != nil {
+		log.Printf("Error al hacer broadcast de %s: %v", status, err)
+	}
+	if err := hub.SendToUser(sancion.UsuarioID, sancionMsg); err != nil {
+		log.Printf("Error al notificar %s al usuario: %v", status, err)
+	}
+}
+
+// notificarLectura envía la confirmación "mensajes_leidos" al chat de la reta y "no_leidos" a todas
+// las conexiones del lector para que actualicen su contador
+func notificarLectura(hub *adapters.Hub, lectura *entities.LecturaChat, nombre string) {
+	leidoMsg := entities.BroadcastMessage{
+		Status:      "mensajes_leidos",
+		RetaID:      lectura.RetaID,
+		UltimoLeido: lectura.UltimoLeido,
+		Usuario: &entities.UsuarioPresente{
+			UsuarioID: lectura.UsuarioID,
+			Nombre:    nombre,
+		},
+	}
+	if err := hub.BroadcastToReta(lectura.RetaID, leidoMsg); err != nil {
+		log.Printf("Error al hacer broadcast de lectura: %v", err)
+	}
+
+	noLeidosMsg := entities.BroadcastMessage{
+		Status:  "no_leidos",
+		RetaID:  lectura.RetaID,
+		Lectura: lectura,
+	}
+	if err := hub.SendToUser(lectura.UsuarioID, noLeidosMsg); err != nil {
+		log.Printf("Error al notificar mensajes no leídos: %v", err)
+	}
+}
+
+// notificarPromovidos avisa directamente a cada jugador que entró desde la lista de espera
+func notificarPromovidos(hub *adapters.Hub, movimiento *entities.MovimientoReta) {
+	for _, promovido := range movimiento.Promovidos {
+		promovidoMsg := entities.BroadcastMessage{
+			Status:  "promovido",
+			RetaID:  movimiento.RetaID,
+			Mensaje: "Se liberó un lugar: ya estás inscrito en la reta",
+		}
+		if err := hub.SendToUser(promovido.UsuarioID, promovidoMsg); err != nil {
+			log.Printf("Error al notificar jugador promovido: %v", err)
+		}
+	}
+}
+
+// notificarNuevaReta hace broadcast de "nueva_reta" a la zona con el creador como primer jugador
+func notificarNuevaReta(hub *adapters.Hub, reta *entities.Reta, primerJugador *entities.Jugador) *entities.RetaInfo {
+	info := entities.NewRetaInfo(reta, []entities.Jugador{*primerJugador}, []entities.Jugador{})
+
+	broadcastMsg := entities.BroadcastMessage{
+		Status: "nueva_reta",
+		Reta:   info,
+	}
+	if err := hub.BroadcastToZone(reta.ZonaID, broadcastMsg); err != nil {
+		log.Printf("Error al hacer broadcast: %v", err)
+	}
+
+	return info
+}
+
+// notificarRetaActualizada hace broadcast de "reta_actualizada" con los datos completos de la reta editada
+func notificarRetaActualizada(hub *adapters.Hub, reta *entities.Reta, movimiento *entities.MovimientoReta) {
+	broadcastMsg := entities.BroadcastMessage{
+		Status: "reta_actualizada",
+		RetaID: reta.ID,
+		Reta:   entities.NewRetaInfo(reta, movimiento.ListaJugadores, movimiento.ListaEspera),
+	}
+	if err := hub.BroadcastToZone(reta.ZonaID, broadcastMsg); err != nil {
+		log.Printf("Error al hacer broadcast: %v", err)
+	}
+
+	notificarPromovidos(hub, movimiento)
+}
+
+// notificarRetaCancelada hace broadcast de "reta_cancelada" a la zona de la reta
+func notificarRetaCancelada(hub *adapters.Hub, reta *entities.Reta) {
+	notificarCancelacion(hub, reta, "La reta \""+reta.Titulo+"\" fue cancelada por su creador")
+}
+
+// notificarRetaCanceladaPorModeracion hace broadcast de "reta_cancelada" con el motivo de la moderación
+func notificarRetaCanceladaPorModeracion(hub *adapters.Hub, reta *entities.Reta, motivo string) {
+	notificarCancelacion(hub, reta, "La reta \""+reta.Titulo+"\" fue cancelada por moderación: "+motivo)
+}
+
+func notificarCancelacion(hub *adapters.Hub, reta *entities.Reta, mensaje string) {
+	broadcastMsg := entities.BroadcastMessage{
+		Status:  "reta_cancelada",
+		RetaID:  reta.ID,
+		Estado:  reta.Estado,
+		Mensaje: mensaje,
+	}
+	if err := hub.BroadcastToZone(reta.ZonaID, broadcastMsg); err != nil {
+		log.Printf("Error al hacer broadcast: %v", err)
+	}
+}
+
+// notificarResultadoSerie difunde lo que pasó con las retas de una serie: "nueva_reta" por cada reta
+// creada, "reta_actualizada" por cada editada y "reta_cancelada" (con el motivo) por cada cancelada
+func notificarResultadoSerie(hub *adapters.Hub, resultado *entities.ResultadoSerie, motivoCancelacion string) {
+	for _, creada := range resultado.Creadas {
+		notificarOcurrenciaCreada(hub, creada)
+	}
+	for _, actualizada := range resultado.Actualizadas {
+		notificarRetaActualizada(hub, actualizada.Reta, actualizada.Movimiento)
+	}
+	for i := range resultado.Canceladas {
+		reta := &resultado.Canceladas[i]
+		notificarCancelacion(hub, reta, "La reta \""+reta.Titulo+"\" fue cancelada: "+motivoCancelacion)
+	}
+}
+
+// notificarOcurrenciaCreada hace broadcast de "nueva_reta" a la zona y avisa con "inscrito_por_serie"
+// a cada suscriptor que quedó inscrito o en la lista de espera
+func notificarOcurrenciaCreada(hub *adapters.Hub, creada entities.OcurrenciaActualizada) {
+	reta, movimiento := creada.Reta, creada.Movimiento
+	info := entities.NewRetaInfo(reta, movimiento.ListaJugadores, movimiento.ListaEspera)
+
+	broadcastMsg := entities.BroadcastMessage{
+		Status: "nueva_reta",
+		Reta:   info,
+	}
+	if err := hub.BroadcastToZone(reta.ZonaID, broadcastMsg); err != nil {
+		log.Printf("Error al hacer broadcast: %v", err)
+	}
+
+	avisos := make(map[string]string)
+	for _, jugador := range movimiento.ListaJugadores {
+		if jugador.UsuarioID != reta.CreadorID {
+			avisos[jugador.UsuarioID] = "Quedaste inscrito en \"" + reta.Titulo + "\" por ser jugador habitual de la serie"
+		}
+	}
+	for _, jugador := range movimiento.ListaEspera {
+		avisos[jugador.UsuarioID] = fmt.Sprintf("La reta \"%s\" de tu serie se llenó: quedaste en la posición %d de la lista de espera",
+			reta.Titulo, jugador.Posicion)
+	}
+
+	for usuarioID, mensaje := range avisos {
+		avisoMsg := entities.BroadcastMessage{
+			Status:  "inscrito_por_serie",
+			RetaID:  reta.ID,
+			Reta:    info,
+			Mensaje: mensaje,
+		}
+		if err := hub.SendToUser(usuarioID, avisoMsg); err != nil {
+			log.Printf("Error al notificar inscripción por serie: %v", err)
+		}
+	}
+}
+
+// posicionEnEspera retorna la posición del usuario en la lista de espera del movimiento (0 si no está)
+func posicionEnEspera(movimiento *entities.MovimientoReta, usuarioID string) int {
+	for _, jugador := range movimiento.ListaEspera {
+		if jugador.UsuarioID == usuarioID {
+			return jugador.Posicion
+		}
+	}
+	return 0
+}
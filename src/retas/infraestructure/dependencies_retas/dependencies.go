@@ -3,40 +3,165 @@ package dependenciesretas
 import (
 	"games-football-api/src/core"
 	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/domain/repositories"
 	"games-football-api/src/retas/infraestructure/adapters"
 	"games-football-api/src/retas/infraestructure/controllers"
 	"games-football-api/src/retas/infraestructure/routers"
+	"games-football-api/src/retas/infraestructure/schedulers"
 	"log"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-func InitRetas(r *gin.Engine) {
+// InitRetas registra el módulo de retas y retorna el hub para que los demás módulos puedan cerrar
+// las conexiones en tiempo real de un usuario (ej. al banearlo)
+func InitRetas(r *gin.Engine) core.DesconectadorSesiones {
 	// Inicializar la conexión a la base de datos
 	db, err := core.NewMySQL()
 	if err != nil {
 		log.Fatalf("Error al conectar a la base de datos: %v", err)
 	}
 
+	// Inicializar el validador de tokens (mismo JWT_SECRET que el módulo de usuarios)
+	jwtManager, err := core.NewJWTManager()
+	if err != nil {
+		log.Fatalf("Error al configurar JWT: %v", err)
+	}
+
+	// Crear el broker (local o Redis) que comparte los eventos entre réplicas de la API
+	broker, err := adapters.NewBrokerDesdeEnv()
+	if err != nil {
+		log.Fatalf("Error al configurar el broker de eventos: %v", err)
+	}
+
 	// Crear el Hub de WebSocket
-	hub := adapters.NewHub()
+	hub := adapters.NewHub(broker)
 	go hub.Run() // Ejecutar el hub en un goroutine
 
 	// Crear el repositorio
 	retaRepo := adapters.NewMySQLRetaRepository(db)
 
+	// Cadena de moderación que revisan los mensajes nuevos y editados antes de guardarse
+	moderacion, err := cadenaModeracionDesdeEnv(retaRepo)
+	if err != nil {
+		log.Fatalf("Error al configurar la moderación del chat: %v", err)
+	}
+
 	// Crear los casos de uso
 	unirseUseCase := application.NewUnirseRetaUseCase(retaRepo)
+	salirUseCase := application.NewSalirRetaUseCase(retaRepo)
 	crearRetaUseCase := application.NewCrearRetaUseCase(retaRepo)
+	editarRetaUseCase := application.NewEditarRetaUseCase(retaRepo)
+	cancelarRetaUseCase := application.NewCancelarRetaUseCase(retaRepo)
 	obtenerRetasUseCase := application.NewObtenerRetasPorZonaUseCase(retaRepo)
-	enviarMensajeUseCase := application.NewEnviarMensajeUseCase(retaRepo)
+	validarZonaUseCase := application.NewValidarZonaUseCase(retaRepo)
+	verificarCuentaUseCase := application.NewVerificarCuentaUseCase(retaRepo)
+	cercanasUseCase := application.NewBuscarRetasCercanasUseCase(retaRepo)
+	obtenerRetaUseCase := application.NewObtenerRetaUseCase(retaRepo)
+	enviarMensajeUseCase := application.NewEnviarMensajeUseCase(retaRepo, moderacion)
 	historialChatUseCase := application.NewObtenerHistorialChatUseCase(retaRepo)
+	accesoChatUseCase := application.NewVerificarAccesoChatUseCase(retaRepo)
+	marcarLeidoUseCase := application.NewMarcarLeidoUseCase(retaRepo)
+	editarMensajeUseCase := application.NewEditarMensajeUseCase(retaRepo, moderacion)
+	eliminarMensajeUseCase := application.NewEliminarMensajeUseCase(retaRepo)
+	sancionarUsuarioUseCase := application.NewSancionarUsuarioUseCase(retaRepo)
+	quitarSancionUseCase := application.NewQuitarSancionUseCase(retaRepo)
+	obtenerSancionesUseCase := application.NewObtenerSancionesUseCase(retaRepo)
+	actualizarEstadosUseCase := application.NewActualizarEstadosRetasUseCase(retaRepo)
+	cancelarRetaAdminUseCase := application.NewCancelarRetaAdminUseCase(retaRepo)
+	purgarMensajesUseCase := application.NewPurgarMensajesUseCase(retaRepo)
+	crearSerieUseCase := application.NewCrearSerieUseCase(retaRepo)
+	obtenerSeriesUseCase := application.NewObtenerSeriesPorZonaUseCase(retaRepo)
+	obtenerSerieUseCase := application.NewObtenerSerieUseCase(retaRepo)
+	editarSerieUseCase := application.NewEditarSerieUseCase(retaRepo)
+	cancelarSerieUseCase := application.NewCancelarSerieUseCase(retaRepo)
+	suscribirSerieUseCase := application.NewSuscribirSerieUseCase(retaRepo)
+	desuscribirSerieUseCase := application.NewDesuscribirSerieUseCase(retaRepo)
+
+	// Scheduler que mueve las retas a en_juego / finalizada según su fecha_hora
+	intervalo, err := core.DuracionDesdeEnv("RETAS_SCHEDULER_INTERVALO", time.Minute)
+	if err != nil {
+		log.Fatalf("Error al configurar el scheduler de retas: %v", err)
+	}
+	estadosScheduler := schedulers.NewEstadosRetaScheduler(hub, actualizarEstadosUseCase, intervalo)
+	go estadosScheduler.Run()
 
-	// Crear el controller
-	wsController := controllers.NewWebSocketController(hub, unirseUseCase, crearRetaUseCase, obtenerRetasUseCase, enviarMensajeUseCase, historialChatUseCase)
+	// Scheduler que crea con anticipación las retas de las series recurrentes
+	horizonteDias, err := core.EnteroDesdeEnv("RETAS_SERIES_HORIZONTE_DIAS", 14)
+	if err != nil {
+		log.Fatalf("Error al configurar el horizonte de las series: %v", err)
+	}
+	intervaloSeries, err := core.DuracionDesdeEnv("RETAS_SERIES_INTERVALO", 15*time.Minute)
+	if err != nil {
+		log.Fatalf("Error al configurar el scheduler de series: %v", err)
+	}
+	materializarSeriesUseCase := application.NewMaterializarSeriesUseCase(retaRepo, time.Duration(horizonteDias)*24*time.Hour)
+	seriesScheduler := schedulers.NewSeriesRetasScheduler(hub, materializarSeriesUseCase, intervaloSeries)
+	go seriesScheduler.Run()
+
+	// Límites de tasa y tamaño de las conexiones WebSocket
+	limitesWS, err := controllers.LimitesWebSocketDesdeEnv()
+	if err != nil {
+		log.Fatalf("Error al configurar los límites de WebSocket: %v", err)
+	}
+
+	// Crear los controllers
+	wsController := controllers.NewWebSocketController(hub, jwtManager, unirseUseCase, salirUseCase, crearRetaUseCase, editarRetaUseCase, cancelarRetaUseCase, obtenerRetasUseCase, validarZonaUseCase, verificarCuentaUseCase, cercanasUseCase, enviarMensajeUseCase, historialChatUseCase, accesoChatUseCase, marcarLeidoUseCase, editarMensajeUseCase, eliminarMensajeUseCase, sancionarUsuarioUseCase, quitarSancionUseCase, limitesWS)
+
+	listarController := controllers.NewListarRetasController(obtenerRetasUseCase)
+	cercanasController := controllers.NewRetasCercanasController(cercanasUseCase)
+	obtenerController := controllers.NewObtenerRetaController(obtenerRetaUseCase)
+	mensajesController := controllers.NewMensajesRetaController(historialChatUseCase)
+	crearController := controllers.NewCrearRetaController(hub, crearRetaUseCase)
+	unirseController := controllers.NewUnirseRetaController(hub, unirseUseCase)
+	salirController := controllers.NewSalirRetaController(hub, salirUseCase)
+	editarController := controllers.NewEditarRetaController(hub, editarRetaUseCase)
+	cancelarController := controllers.NewCancelarRetaController(hub, cancelarRetaUseCase)
+	presenciaController := controllers.NewPresenciaController(hub, accesoChatUseCase)
+	marcarLeidoController := controllers.NewMarcarLeidoController(hub, marcarLeidoUseCase)
+	editarMensajeController := controllers.NewEditarMensajeController(hub, editarMensajeUseCase)
+	eliminarMensajeController := controllers.NewEliminarMensajeController(hub, eliminarMensajeUseCase)
+	sancionesController := controllers.NewSancionesRetaController(hub, sancionarUsuarioUseCase, quitarSancionUseCase, obtenerSancionesUseCase)
+	adminRetasController := controllers.NewAdminRetasController(hub, cancelarRetaAdminUseCase, purgarMensajesUseCase)
+	seriesController := controllers.NewSeriesRetasController(hub, crearSerieUseCase, materializarSeriesUseCase, obtenerSeriesUseCase, obtenerSerieUseCase, editarSerieUseCase, cancelarSerieUseCase)
+	suscripcionesSerieController := controllers.NewSuscripcionesSerieController(suscribirSerieUseCase, desuscribirSerieUseCase, hub)
 
 	// Registrar las rutas
-	routers.RetasRouter(r, wsController)
+	authMiddleware := core.AuthMiddleware(jwtManager)
+	routers.RetasRouter(r, authMiddleware, wsController, listarController, obtenerController, mensajesController, crearController, unirseController, salirController, editarController, cancelarController, presenciaController, marcarLeidoController, editarMensajeController, eliminarMensajeController, sancionesController, cercanasController)
+	routers.AdminRetasRouter(r, authMiddleware, core.NewCuentasMySQL(db), adminRetasController)
+	routers.SeriesRouter(r, authMiddleware, seriesController, suscripcionesSerieController)
 
 	log.Println("Módulo de Retas inicializado correctamente")
+	return hub
+}
+
+// cadenaModeracionDesdeEnv arma la cadena de moderación del chat. MODERACION_PALABRAS agrega palabras
+// (separadas por comas, "*" al final para prefijos) a la lista base, MODERACION_PERMITIR_ENLACES=true
+// quita el bloqueo de enlaces y MODERACION_VENTANA_REPETIDOS es la ventana del filtro de repetidos
+func cadenaModeracionDesdeEnv(retaRepo repositories.IRetaRepository) (*application.CadenaModeracion, error) {
+	palabras := append([]string{}, application.PalabrasOfensivasPorDefecto...)
+	if extra := os.Getenv("MODERACION_PALABRAS"); extra != "" {
+		palabras = append(palabras, strings.Split(extra, ",")...)
+	}
+
+	ventana, err := core.DuracionDesdeEnv("MODERACION_VENTANA_REPETIDOS", time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	reglas := []application.ReglaModeracion{
+		application.NewReglaLongitud(entities.LongitudMaximaMensaje),
+		application.NewReglaPalabras(palabras),
+	}
+	if os.Getenv("MODERACION_PERMITIR_ENLACES") != "true" {
+		reglas = append(reglas, application.NewReglaEnlaces())
+	}
+	reglas = append(reglas, application.NewReglaRepetidos(retaRepo, ventana))
+
+	return application.NewCadenaModeracion(reglas...), nil
 }
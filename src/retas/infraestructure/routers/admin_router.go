@@ -0,0 +1,22 @@
+package routers
+
+import (
+	"expvar"
+	"games-football-api/src/core"
+	"games-football-api/src/retas/infraestructure/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminRetasRouter registra las rutas de moderación de retas y chats (moderadores y admins) y las
+// métricas del proceso (solo admins)
+func AdminRetasRouter(r *gin.Engine, authMiddleware gin.HandlerFunc, cuentas core.LectorCuentas, adminRetasController *controllers.AdminRetasController) {
+	adminGroup := r.Group("/api/admin/retas", authMiddleware, core.RequiereRol(cuentas, core.RolModerador))
+	{
+		adminGroup.POST("/:id/cancelar", adminRetasController.HandleCancelar)
+		adminGroup.DELETE("/:id/mensajes", adminRetasController.HandlePurgarMensajes)
+	}
+
+	// Métricas del proceso en formato JSON (memoria, línea de comandos y rechazos por límite de los WebSockets)
+	r.GET("/api/admin/debug/vars", authMiddleware, core.RequiereRol(cuentas, core.RolAdmin), gin.WrapH(expvar.Handler()))
+}
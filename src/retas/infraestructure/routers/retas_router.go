@@ -6,10 +6,32 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func RetasRouter(r *gin.Engine, wsController *controllers.WebSocketController) {
+func RetasRouter(r *gin.Engine, authMiddleware gin.HandlerFunc, wsController *controllers.WebSocketController, listarController *controllers.ListarRetasController, obtenerController *controllers.ObtenerRetaController, mensajesController *controllers.MensajesRetaController, crearController *controllers.CrearRetaController, unirseController *controllers.UnirseRetaController, salirController *controllers.SalirRetaController, editarController *controllers.EditarRetaController, cancelarController *controllers.CancelarRetaController, presenciaController *controllers.PresenciaController, marcarLeidoController *controllers.MarcarLeidoController, editarMensajeController *controllers.EditarMensajeController, eliminarMensajeController *controllers.EliminarMensajeController, sancionesController *controllers.SancionesRetaController, cercanasController *controllers.RetasCercanasController) {
 	retasGroup := r.Group("/ws")
 	{
 		retasGroup.GET("/retas", wsController.HandleWebSocket)
 		retasGroup.GET("/retas/chat", wsController.HandleChat)
 	}
+
+	// API REST de retas (requiere access token)
+	apiGroup := r.Group("/api/retas", authMiddleware)
+	{
+		apiGroup.GET("", listarController.HandleListar)
+		apiGroup.POST("", crearController.HandleCrear)
+		apiGroup.GET("/cercanas", cercanasController.HandleBuscar)
+		apiGroup.GET("/presencia", presenciaController.HandlePresenciaZona)
+		apiGroup.GET("/:id", obtenerController.HandleObtener)
+		apiGroup.GET("/:id/mensajes", mensajesController.HandleListar)
+		apiGroup.PUT("/:id/mensajes/:mensaje_id", editarMensajeController.HandleEditar)
+		apiGroup.DELETE("/:id/mensajes/:mensaje_id", eliminarMensajeController.HandleEliminar)
+		apiGroup.GET("/:id/presencia", presenciaController.HandlePresenciaReta)
+		apiGroup.POST("/:id/leido", marcarLeidoController.HandleMarcarLeido)
+		apiGroup.GET("/:id/sanciones", sancionesController.HandleListar)
+		apiGroup.POST("/:id/sanciones", sancionesController.HandleSancionar)
+		apiGroup.DELETE("/:id/sanciones/:usuario_id", sancionesController.HandleQuitar)
+		apiGroup.PUT("/:id", editarController.HandleEditar)
+		apiGroup.POST("/:id/unirse", unirseController.HandleUnirse)
+		apiGroup.POST("/:id/salir", salirController.HandleSalir)
+		apiGroup.POST("/:id/cancelar", cancelarController.HandleCancelar)
+	}
 }
@@ -0,0 +1,22 @@
+package routers
+
+import (
+	"games-football-api/src/retas/infraestructure/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SeriesRouter registra las rutas de las series de retas recurrentes (requiere access token). Cada
+// fecha de una serie es una reta normal que se edita o cancela por separado con las rutas de /api/retas
+func SeriesRouter(r *gin.Engine, authMiddleware gin.HandlerFunc, seriesController *controllers.SeriesRetasController, suscripcionesController *controllers.SuscripcionesSerieController) {
+	seriesGroup := r.Group("/api/series", authMiddleware)
+	{
+		seriesGroup.GET("", seriesController.HandleListar)
+		seriesGroup.POST("", seriesController.HandleCrear)
+		seriesGroup.GET("/:id", seriesController.HandleObtener)
+		seriesGroup.PUT("/:id", seriesController.HandleEditar)
+		seriesGroup.POST("/:id/cancelar", seriesController.HandleCancelar)
+		seriesGroup.POST("/:id/suscribirse", suscripcionesController.HandleSuscribir)
+		seriesGroup.POST("/:id/desuscribirse", suscripcionesController.HandleDesuscribir)
+	}
+}
@@ -0,0 +1,54 @@
+package schedulers
+
+import (
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"log"
+	"time"
+)
+
+// EstadosRetaScheduler revisa periódicamente las retas y avanza su estado según la fecha_hora
+type EstadosRetaScheduler struct {
+	hub                      *adapters.Hub
+	actualizarEstadosUseCase *application.ActualizarEstadosRetasUseCase
+	intervalo                time.Duration
+}
+
+func NewEstadosRetaScheduler(hub *adapters.Hub, actualizarEstadosUseCase *application.ActualizarEstadosRetasUseCase, intervalo time.Duration) *EstadosRetaScheduler {
+	return &EstadosRetaScheduler{
+		hub:                      hub,
+		actualizarEstadosUseCase: actualizarEstadosUseCase,
+		intervalo:                intervalo,
+	}
+}
+
+// Run ejecuta el scheduler en un goroutine
+func (s *EstadosRetaScheduler) Run() {
+	ticker := time.NewTicker(s.intervalo)
+	defer ticker.Stop()
+
+	for {
+		s.ejecutar()
+		<-ticker.C
+	}
+}
+
+// ejecutar aplica las transiciones pendientes y notifica a la zona de cada reta actualizada
+func (s *EstadosRetaScheduler) ejecutar() {
+	retas, err := s.actualizarEstadosUseCase.Execute(time.Now())
+	if err != nil {
+		log.Printf("Error al actualizar estados de retas: %v", err)
+	}
+
+	for _, reta := range retas {
+		broadcastMsg := entities.BroadcastMessage{
+			Status: "estado_reta",
+			RetaID: reta.ID,
+			Estado: reta.Estado,
+		}
+		if err := s.hub.BroadcastToZone(reta.ZonaID, broadcastMsg); err != nil {
+			log.Printf("Error al hacer broadcast de estado: %v", err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package schedulers
+
+import (
+	"fmt"
+	"games-football-api/src/retas/application"
+	"games-football-api/src/retas/domain/entities"
+	"games-football-api/src/retas/infraestructure/adapters"
+	"log"
+	"time"
+)
+
+// SeriesRetasScheduler crea periódicamente las retas de las series recurrentes que entran al horizonte
+type SeriesRetasScheduler struct {
+	hub                 *adapters.Hub
+	materializarUseCase *application.MaterializarSeriesUseCase
+	intervalo           time.Duration
+}
+
+func NewSeriesRetasScheduler(hub *adapters.Hub, materializarUseCase *application.MaterializarSeriesUseCase, intervalo time.Duration) *SeriesRetasScheduler {
+	return &SeriesRetasScheduler{
+		hub:                 hub,
+		materializarUseCase: materializarUseCase,
+		intervalo:           intervalo,
+	}
+}
+
+// Run ejecuta el scheduler en un goroutine
+func (s *SeriesRetasScheduler) Run() {
+	ticker := time.NewTicker(s.intervalo)
+	defer ticker.Stop()
+
+	for {
+		s.ejecutar()
+		<-ticker.C
+	}
+}
+
+// ejecutar crea las retas pendientes, las anuncia a su zona y avisa a los suscriptores inscritos
+func (s *SeriesRetasScheduler) ejecutar() {
+	resultado, err := s.materializarUseCase.Execute(time.Now())
+	if err != nil {
+		log.Printf("Error al materializar series de retas: %v", err)
+		return
+	}
+
+	for _, conflicto := range resultado.Conflictos {
+		log.Printf("No se pudo crear la reta de la serie del %s: %s", conflicto.FechaHora, conflicto.Mensaje)
+	}
+
+	for _, creada := range resultado.Creadas {
+		s.notificar(creada)
+	}
+}
+
+// notificar hace broadcast de "nueva_reta" a la zona y envía "inscrito_por_serie" a los suscriptores
+// (mismos mensajes que al crear la serie por REST)
+func (s *SeriesRetasScheduler) notificar(creada entities.OcurrenciaActualizada) {
+	reta, movimiento := creada.Reta, creada.Movimiento
+	info := entities.NewRetaInfo(reta, movimiento.ListaJugadores, movimiento.ListaEspera)
+
+	broadcastMsg := entities.BroadcastMessage{
+		Status: "nueva_reta",
+		Reta:   info,
+	}
+	if err := s.hub.BroadcastToZone(reta.ZonaID, broadcastMsg); err != nil {
+		log.Printf("Error al hacer broadcast de reta de serie: %v", err)
+	}
+
+	avisos := make(map[string]string)
+	for _, jugador := range movimiento.ListaJugadores {
+		if jugador.UsuarioID != reta.CreadorID {
+			avisos[jugador.UsuarioID] = "Quedaste inscrito en \"" + reta.Titulo + "\" por ser jugador habitual de la serie"
+		}
+	}
+	for _, jugador := range movimiento.ListaEspera {
+		avisos[jugador.UsuarioID] = fmt.Sprintf("La reta \"%s\" de tu serie se llenó: quedaste en la posición %d de la lista de espera",
+			reta.Titulo, jugador.Posicion)
+	}
+
+	for usuarioID, mensaje := range avisos {
+		avisoMsg := entities.BroadcastMessage{
+			Status:  "inscrito_por_serie",
+			RetaID:  reta.ID,
+			Reta:    info,
+			Mensaje: mensaje,
+		}
+		if err := s.hub.SendToUser(usuarioID, avisoMsg); err != nil {
+			log.Printf("Error al notificar inscripción por serie: %v", err)
+		}
+	}
+}
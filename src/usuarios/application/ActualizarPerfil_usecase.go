@@ -0,0 +1,34 @@
+package application
+
+import (
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+)
+
+type ActualizarPerfilUseCase struct {
+	usuarioRepo repositories.IUsuarioRepository
+}
+
+func NewActualizarPerfilUseCase(usuarioRepo repositories.IUsuarioRepository) *ActualizarPerfilUseCase {
+	return &ActualizarPerfilUseCase{
+		usuarioRepo: usuarioRepo,
+	}
+}
+
+// Execute aplica los cambios al perfil del usuario. El nombre nuevo aparece de inmediato en las listas
+// de jugadores y en el chat, que lo leen de la tabla usuarios
+func (uc *ActualizarPerfilUseCase) Execute(usuarioID string, cambios entities.CambiosPerfil) (*entities.Usuario, error) {
+	usuario, err := uc.usuarioRepo.ObtenerPorID(usuarioID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cambios.Aplicar(usuario); err != nil {
+		return nil, err
+	}
+
+	if err := uc.usuarioRepo.ActualizarPerfil(usuario); err != nil {
+		return nil, err
+	}
+	return usuario, nil
+}
@@ -0,0 +1,53 @@
+package application
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+)
+
+// Tamaño de página de los listados del panel de administración
+const (
+	LimiteAdminPorDefecto = 20
+	LimiteAdminMaximo     = 100
+)
+
+// normalizarPagina aplica los valores por defecto y el máximo a la paginación de los listados
+func normalizarPagina(pagina, limite int) (int, int) {
+	if pagina <= 0 {
+		pagina = 1
+	}
+	if limite <= 0 {
+		limite = LimiteAdminPorDefecto
+	}
+	if limite > LimiteAdminMaximo {
+		limite = LimiteAdminMaximo
+	}
+	return pagina, limite
+}
+
+// accionAdmin carga al actor y al usuario objetivo de una acción administrativa y verifica que
+// el actor tenga un rol mayor al del objetivo. El rol del actor se lee de la base de datos y no
+// del token, para que un moderador degradado no conserve sus permisos hasta que el token expire
+func accionAdmin(usuarioRepo repositories.IUsuarioRepository, actorID, objetivoID, motivo string) (actor, objetivo *entities.Usuario, err error) {
+	if err := core.ValidarMotivo(motivo); err != nil {
+		return nil, nil, err
+	}
+	if actorID == objetivoID {
+		return nil, nil, entities.ErrAccionSobreSiMismo
+	}
+
+	actor, err = usuarioRepo.ObtenerPorID(actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	objetivo, err = usuarioRepo.ObtenerPorID(objetivoID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if core.NivelRol(actor.Rol) <= core.NivelRol(objetivo.Rol) {
+		return nil, nil, entities.ErrRolInsuficiente
+	}
+	return actor, objetivo, nil
+}
@@ -0,0 +1,62 @@
+package application
+
+import (
+	"fmt"
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+	"log"
+	"strings"
+	"time"
+)
+
+type BanearUsuarioUseCase struct {
+	usuarioRepo repositories.IUsuarioRepository
+	sesiones    core.DesconectadorSesiones
+}
+
+func NewBanearUsuarioUseCase(usuarioRepo repositories.IUsuarioRepository, sesiones core.DesconectadorSesiones) *BanearUsuarioUseCase {
+	return &BanearUsuarioUseCase{
+		usuarioRepo: usuarioRepo,
+		sesiones:    sesiones,
+	}
+}
+
+// Execute suspende la cuenta del usuario durante la duración indicada (0 o menos = permanente), revoca
+// sus refresh tokens y cierra sus WebSockets. El baneo, la revocación y la auditoría se guardan juntos.
+// El access token vigente sigue sirviendo en REST hasta que expire; el WebSocket revisa el baneo en la
+// base de datos al conectar
+func (uc *BanearUsuarioUseCase) Execute(actorID, usuarioID string, duracion time.Duration, motivo, ip string) (*entities.Usuario, error) {
+	_, usuario, err := accionAdmin(uc.usuarioRepo, actorID, usuarioID, motivo)
+	if err != nil {
+		return nil, err
+	}
+
+	motivo = strings.TrimSpace(motivo)
+	hasta := entities.BaneoPermanente
+	detalle := "permanente: " + motivo
+	if duracion > 0 {
+		hasta = time.Now().Add(duracion)
+		detalle = fmt.Sprintf("hasta %s: %s", hasta.Format(time.RFC3339), motivo)
+	}
+
+	err = uc.usuarioRepo.Banear(usuarioID, &hasta, motivo, &entities.EventoAuditoria{
+		Evento:   entities.EventoBanearUsuario,
+		ActorID:  actorID,
+		Objetivo: usuarioID,
+		IP:       ip,
+		Detalle:  detalle,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// El baneo ya quedó guardado: si el aviso no llega, el usuario no puede volver a conectarse
+	if err := uc.sesiones.DesconectarUsuario(usuarioID, "cuenta_suspendida", "Tu cuenta fue suspendida: "+motivo); err != nil {
+		log.Printf("Error al cerrar las conexiones del usuario %s: %v", usuarioID, err)
+	}
+
+	usuario.BaneadoHasta = &hasta
+	usuario.MotivoBaneo = motivo
+	return usuario, nil
+}
@@ -0,0 +1,50 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+)
+
+type CambiarPasswordUseCase struct {
+	usuarioRepo repositories.IUsuarioRepository
+	tokenRepo   repositories.IRefreshTokenRepository
+	jwtManager  *core.JWTManager
+}
+
+func NewCambiarPasswordUseCase(usuarioRepo repositories.IUsuarioRepository, tokenRepo repositories.IRefreshTokenRepository, jwtManager *core.JWTManager) *CambiarPasswordUseCase {
+	return &CambiarPasswordUseCase{
+		usuarioRepo: usuarioRepo,
+		tokenRepo:   tokenRepo,
+		jwtManager:  jwtManager,
+	}
+}
+
+// Execute cambia la password si la actual es correcta, cierra todas las sesiones del usuario
+// y emite tokens nuevos para la sesión desde la que se hizo el cambio
+func (uc *CambiarPasswordUseCase) Execute(usuarioID, actual, nueva string) (*entities.Tokens, error) {
+	if actual == "" || nueva == "" {
+		return nil, errors.New("password_actual y password_nueva son requeridos")
+	}
+	if actual == nueva {
+		return nil, entities.ErrPasswordRepetida
+	}
+	if err := entities.ValidarPassword(nueva); err != nil {
+		return nil, err
+	}
+
+	if err := uc.usuarioRepo.CambiarPassword(usuarioID, actual, nueva); err != nil {
+		return nil, err
+	}
+
+	if err := uc.tokenRepo.RevocarTodosDeUsuario(usuarioID); err != nil {
+		return nil, err
+	}
+
+	usuario, err := uc.usuarioRepo.ObtenerPorID(usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	return emitirTokens(uc.jwtManager, uc.tokenRepo, usuario)
+}
@@ -0,0 +1,54 @@
+package application
+
+import (
+	"fmt"
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+	"strings"
+)
+
+type CambiarRolUseCase struct {
+	usuarioRepo repositories.IUsuarioRepository
+}
+
+func NewCambiarRolUseCase(usuarioRepo repositories.IUsuarioRepository) *CambiarRolUseCase {
+	return &CambiarRolUseCase{
+		usuarioRepo: usuarioRepo,
+	}
+}
+
+// Execute asigna un nuevo rol al usuario y lo registra en la auditoría en la misma transacción. El cambio
+// llega al token del usuario en su siguiente refresh
+func (uc *CambiarRolUseCase) Execute(actorID, usuarioID, rol, motivo, ip string) (*entities.Usuario, error) {
+	if !core.RolValido(rol) {
+		return nil, entities.ErrRolInvalido
+	}
+
+	actor, usuario, err := accionAdmin(uc.usuarioRepo, actorID, usuarioID, motivo)
+	if err != nil {
+		return nil, err
+	}
+	// Como en accionAdmin, el rol del actor es el de la base de datos: un admin degradado ya no
+	// puede cambiar roles aunque su token todavía diga admin
+	if actor.Rol != core.RolAdmin {
+		return nil, entities.ErrSoloAdmin
+	}
+	if core.NivelRol(rol) > core.NivelRol(actor.Rol) {
+		return nil, entities.ErrRolMayorAlTuyo
+	}
+
+	err = uc.usuarioRepo.CambiarRol(usuarioID, rol, &entities.EventoAuditoria{
+		Evento:   entities.EventoCambiarRol,
+		ActorID:  actorID,
+		Objetivo: usuarioID,
+		IP:       ip,
+		Detalle:  fmt.Sprintf("%s -> %s: %s", usuario.Rol, rol, strings.TrimSpace(motivo)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usuario.Rol = rol
+	return usuario, nil
+}
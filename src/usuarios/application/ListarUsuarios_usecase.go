@@ -0,0 +1,29 @@
+package application
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+	"strings"
+)
+
+type ListarUsuariosUseCase struct {
+	usuarioRepo repositories.IUsuarioRepository
+}
+
+func NewListarUsuariosUseCase(usuarioRepo repositories.IUsuarioRepository) *ListarUsuariosUseCase {
+	return &ListarUsuariosUseCase{
+		usuarioRepo: usuarioRepo,
+	}
+}
+
+// Execute retorna una página (desde 1) de usuarios que cumplen los filtros y el total de coincidencias
+func (uc *ListarUsuariosUseCase) Execute(filtro entities.FiltroUsuarios, pagina, limite int) ([]*entities.Usuario, int, error) {
+	filtro.Busqueda = strings.TrimSpace(filtro.Busqueda)
+	if filtro.Rol != "" && !core.RolValido(filtro.Rol) {
+		return nil, 0, entities.ErrRolInvalido
+	}
+
+	pagina, limite = normalizarPagina(pagina, limite)
+	return uc.usuarioRepo.ListarUsuarios(filtro, pagina, limite)
+}
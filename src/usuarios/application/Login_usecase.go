@@ -2,29 +2,67 @@ package application
 
 import (
 	"errors"
+	"games-football-api/src/core"
 	"games-football-api/src/usuarios/domain/entities"
 	"games-football-api/src/usuarios/domain/repositories"
+	"time"
 )
 
 type LoginUseCase struct {
 	usuarioRepo repositories.IUsuarioRepository
+	tokenRepo   repositories.IRefreshTokenRepository
+	jwtManager  *core.JWTManager
+	protector   *protectorLogin
 }
 
-func NewLoginUseCase(usuarioRepo repositories.IUsuarioRepository) *LoginUseCase {
+func NewLoginUseCase(usuarioRepo repositories.IUsuarioRepository, tokenRepo repositories.IRefreshTokenRepository, intentoRepo repositories.IIntentoLoginRepository, auditoriaRepo repositories.IAuditoriaRepository, jwtManager *core.JWTManager, politica PoliticaBloqueoLogin) *LoginUseCase {
 	return &LoginUseCase{
 		usuarioRepo: usuarioRepo,
+		tokenRepo:   tokenRepo,
+		jwtManager:  jwtManager,
+		protector: &protectorLogin{
+			intentoRepo:   intentoRepo,
+			auditoriaRepo: auditoriaRepo,
+			politica:      politica,
+		},
 	}
 }
 
-func (uc *LoginUseCase) Execute(username, password string) (*entities.Usuario, error) {
+// Execute valida las credenciales y emite el par de tokens de la sesión. Si el username o la IP
+// acumularon demasiados fallos, rechaza el intento sin comparar la password
+func (uc *LoginUseCase) Execute(username, password, ip string) (*entities.Usuario, *entities.Tokens, error) {
 	if username == "" || password == "" {
-		return nil, errors.New("username y password son requeridos")
+		return nil, nil, errors.New("username y password son requeridos")
+	}
+
+	if err := uc.protector.verificar(username, ip); err != nil {
+		return nil, nil, err
 	}
 
 	usuario, err := uc.usuarioRepo.Login(username, password)
+	if errors.Is(err, entities.ErrCredencialesInvalidas) {
+		if errFallo := uc.protector.registrarFallo(username, ip); errFallo != nil {
+			return nil, nil, errFallo
+		}
+		return nil, nil, err
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := uc.protector.limpiar(username); err != nil {
+		return nil, nil, err
+	}
+
+	// El baneo se revisa después de validar la password para no revelar qué cuentas están suspendidas
+	if usuario.Baneado(time.Now()) {
+		return nil, nil, entities.NewUsuarioBaneadoError(usuario)
+	}
+
+	tokens, err := emitirTokens(uc.jwtManager, uc.tokenRepo, usuario)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return usuario, nil
+	return usuario, tokens, nil
 }
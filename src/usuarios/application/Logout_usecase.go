@@ -0,0 +1,41 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/domain/repositories"
+)
+
+type LogoutUseCase struct {
+	tokenRepo repositories.IRefreshTokenRepository
+}
+
+func NewLogoutUseCase(tokenRepo repositories.IRefreshTokenRepository) *LogoutUseCase {
+	return &LogoutUseCase{
+		tokenRepo: tokenRepo,
+	}
+}
+
+// Execute revoca el refresh token de la sesión; solo el dueño del token puede cerrarla
+func (uc *LogoutUseCase) Execute(usuarioID, refreshToken string) error {
+	if refreshToken == "" {
+		return errors.New("refresh_token es requerido")
+	}
+
+	tokenHash := core.HashToken(refreshToken)
+
+	registro, err := uc.tokenRepo.ObtenerPorHash(tokenHash)
+	if err != nil {
+		return err
+	}
+	if registro.UsuarioID != usuarioID {
+		return errors.New("refresh token inválido")
+	}
+
+	// Cerrar una sesión ya revocada no es un error
+	if registro.RevocadoEn != nil {
+		return nil
+	}
+
+	return uc.tokenRepo.Revocar(tokenHash)
+}
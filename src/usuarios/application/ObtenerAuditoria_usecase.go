@@ -0,0 +1,22 @@
+package application
+
+import (
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+)
+
+type ObtenerAuditoriaUseCase struct {
+	auditoriaRepo repositories.IAuditoriaRepository
+}
+
+func NewObtenerAuditoriaUseCase(auditoriaRepo repositories.IAuditoriaRepository) *ObtenerAuditoriaUseCase {
+	return &ObtenerAuditoriaUseCase{
+		auditoriaRepo: auditoriaRepo,
+	}
+}
+
+// Execute retorna una página (desde 1) de eventos de auditoría y el total de coincidencias
+func (uc *ObtenerAuditoriaUseCase) Execute(filtro entities.FiltroAuditoria, pagina, limite int) ([]*entities.EventoAuditoria, int, error) {
+	pagina, limite = normalizarPagina(pagina, limite)
+	return uc.auditoriaRepo.Listar(filtro, pagina, limite)
+}
@@ -0,0 +1,26 @@
+package application
+
+import (
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+)
+
+type ObtenerEstadisticasUseCase struct {
+	usuarioRepo      repositories.IUsuarioRepository
+	estadisticasRepo repositories.IEstadisticasRepository
+}
+
+func NewObtenerEstadisticasUseCase(usuarioRepo repositories.IUsuarioRepository, estadisticasRepo repositories.IEstadisticasRepository) *ObtenerEstadisticasUseCase {
+	return &ObtenerEstadisticasUseCase{
+		usuarioRepo:      usuarioRepo,
+		estadisticasRepo: estadisticasRepo,
+	}
+}
+
+// Execute actualiza el resumen incremental del usuario y lo retorna
+func (uc *ObtenerEstadisticasUseCase) Execute(usuarioID string) (*entities.EstadisticasUsuario, error) {
+	if _, err := uc.usuarioRepo.ObtenerPorID(usuarioID); err != nil {
+		return nil, err
+	}
+	return uc.estadisticasRepo.ActualizarEstadisticas(usuarioID)
+}
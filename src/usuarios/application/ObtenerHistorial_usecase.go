@@ -0,0 +1,42 @@
+package application
+
+import (
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+)
+
+// Tamaño de página del historial de retas
+const (
+	LimiteHistorialPorDefecto = 20
+	LimiteHistorialMaximo     = 50
+)
+
+type ObtenerHistorialUseCase struct {
+	usuarioRepo      repositories.IUsuarioRepository
+	estadisticasRepo repositories.IEstadisticasRepository
+}
+
+func NewObtenerHistorialUseCase(usuarioRepo repositories.IUsuarioRepository, estadisticasRepo repositories.IEstadisticasRepository) *ObtenerHistorialUseCase {
+	return &ObtenerHistorialUseCase{
+		usuarioRepo:      usuarioRepo,
+		estadisticasRepo: estadisticasRepo,
+	}
+}
+
+// Execute retorna una página (desde 1) de las retas pasadas del usuario
+func (uc *ObtenerHistorialUseCase) Execute(usuarioID string, pagina, limite int) (*entities.PaginaHistorial, error) {
+	if pagina <= 0 {
+		pagina = 1
+	}
+	if limite <= 0 {
+		limite = LimiteHistorialPorDefecto
+	}
+	if limite > LimiteHistorialMaximo {
+		limite = LimiteHistorialMaximo
+	}
+
+	if _, err := uc.usuarioRepo.ObtenerPorID(usuarioID); err != nil {
+		return nil, err
+	}
+	return uc.estadisticasRepo.ObtenerHistorial(usuarioID, pagina, limite)
+}
@@ -0,0 +1,21 @@
+package application
+
+import (
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+)
+
+type ObtenerPerfilUseCase struct {
+	usuarioRepo repositories.IUsuarioRepository
+}
+
+func NewObtenerPerfilUseCase(usuarioRepo repositories.IUsuarioRepository) *ObtenerPerfilUseCase {
+	return &ObtenerPerfilUseCase{
+		usuarioRepo: usuarioRepo,
+	}
+}
+
+// Execute retorna el perfil completo del usuario; el controller decide si expone la vista pública
+func (uc *ObtenerPerfilUseCase) Execute(usuarioID string) (*entities.Usuario, error) {
+	return uc.usuarioRepo.ObtenerPorID(usuarioID)
+}
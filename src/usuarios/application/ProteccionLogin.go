@@ -0,0 +1,127 @@
+package application
+
+import (
+	"fmt"
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+	"log"
+	"strings"
+	"time"
+)
+
+// PoliticaBloqueoLogin define cuántos fallos se toleran y cuánto dura el bloqueo. Al llegar al umbral
+// la clave se bloquea BloqueoBase, y cada fallo extra dentro de la Ventana duplica el bloqueo hasta BloqueoMaximo
+type PoliticaBloqueoLogin struct {
+	FallosUsuario int
+	FallosIP      int
+	Ventana       time.Duration
+	BloqueoBase   time.Duration
+	BloqueoMaximo time.Duration
+}
+
+// PoliticaBloqueoLoginPorDefecto: 5 fallos por username o 20 por IP en una hora bloquean de 1 a 30 minutos
+var PoliticaBloqueoLoginPorDefecto = PoliticaBloqueoLogin{
+	FallosUsuario: 5,
+	FallosIP:      20,
+	Ventana:       time.Hour,
+	BloqueoBase:   time.Minute,
+	BloqueoMaximo: 30 * time.Minute,
+}
+
+// duracionBloqueo retorna el bloqueo que corresponde a los fallos acumulados (0 si aún no llega al umbral)
+func (p PoliticaBloqueoLogin) duracionBloqueo(fallos, umbral int) time.Duration {
+	if fallos < umbral {
+		return 0
+	}
+
+	bloqueo := p.BloqueoBase
+	for i := umbral; i < fallos && bloqueo < p.BloqueoMaximo; i++ {
+		bloqueo *= 2
+	}
+	if bloqueo > p.BloqueoMaximo {
+		bloqueo = p.BloqueoMaximo
+	}
+	return bloqueo
+}
+
+// protectorLogin lleva los fallos por username y por IP y bloquea las claves que superan la política
+type protectorLogin struct {
+	intentoRepo   repositories.IIntentoLoginRepository
+	auditoriaRepo repositories.IAuditoriaRepository
+	politica      PoliticaBloqueoLogin
+}
+
+// claveUsuario normaliza el username igual que la collation de la tabla usuarios (sin distinguir mayúsculas)
+func claveUsuario(username string) string {
+	return "usuario:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+func claveIP(ip string) string {
+	return "ip:" + ip
+}
+
+// verificar retorna un LoginBloqueadoError si el username o la IP siguen bloqueados.
+// Los usernames inexistentes se bloquean igual, así que la respuesta no revela si existen
+func (p *protectorLogin) verificar(username, ip string) error {
+	ahora := time.Now()
+	var restante time.Duration
+	for _, clave := range []string{claveUsuario(username), claveIP(ip)} {
+		intento, err := p.intentoRepo.ObtenerIntento(clave)
+		if err != nil {
+			return err
+		}
+		if bloqueado, r := intento.Bloqueado(ahora); bloqueado && r > restante {
+			restante = r
+		}
+	}
+
+	if restante > 0 {
+		return &entities.LoginBloqueadoError{Restante: restante}
+	}
+	return nil
+}
+
+// registrarFallo suma el fallo al username y a la IP y bloquea la clave que llegue a su umbral
+func (p *protectorLogin) registrarFallo(username, ip string) error {
+	claves := []struct {
+		clave  string
+		umbral int
+	}{
+		{claveUsuario(username), p.politica.FallosUsuario},
+		{claveIP(ip), p.politica.FallosIP},
+	}
+
+	for _, c := range claves {
+		fallos, err := p.intentoRepo.RegistrarFallo(c.clave, p.politica.Ventana)
+		if err != nil {
+			return err
+		}
+
+		bloqueo := p.politica.duracionBloqueo(fallos, c.umbral)
+		if bloqueo == 0 {
+			continue
+		}
+		if err := p.intentoRepo.Bloquear(c.clave, time.Now().Add(bloqueo)); err != nil {
+			return err
+		}
+
+		evento := &entities.EventoAuditoria{
+			Evento:   entities.EventoBloqueoLogin,
+			Objetivo: c.clave,
+			IP:       ip,
+			Detalle:  fmt.Sprintf("%d intentos fallidos, bloqueado por %s", fallos, bloqueo),
+		}
+		// La auditoría no debe impedir el bloqueo
+		if err := p.auditoriaRepo.Registrar(evento); err != nil {
+			log.Printf("Error al auditar bloqueo de login de %s: %v", c.clave, err)
+		}
+		log.Printf("Login bloqueado para %s: %s", c.clave, evento.Detalle)
+	}
+	return nil
+}
+
+// limpiar borra los fallos del username tras un login exitoso. Los de la IP se conservan para que
+// una cuenta propia no sirva para reiniciar el contador de un ataque desde la misma IP
+func (p *protectorLogin) limpiar(username string) error {
+	return p.intentoRepo.LimpiarIntentos(claveUsuario(username))
+}
@@ -0,0 +1,40 @@
+package application
+
+import (
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+	"strings"
+)
+
+type QuitarBaneoUseCase struct {
+	usuarioRepo repositories.IUsuarioRepository
+}
+
+func NewQuitarBaneoUseCase(usuarioRepo repositories.IUsuarioRepository) *QuitarBaneoUseCase {
+	return &QuitarBaneoUseCase{
+		usuarioRepo: usuarioRepo,
+	}
+}
+
+// Execute levanta la suspensión de la cuenta del usuario y lo registra en la auditoría en la misma transacción
+func (uc *QuitarBaneoUseCase) Execute(actorID, usuarioID, motivo, ip string) (*entities.Usuario, error) {
+	_, usuario, err := accionAdmin(uc.usuarioRepo, actorID, usuarioID, motivo)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.usuarioRepo.QuitarBaneo(usuarioID, &entities.EventoAuditoria{
+		Evento:   entities.EventoQuitarBaneo,
+		ActorID:  actorID,
+		Objetivo: usuarioID,
+		IP:       ip,
+		Detalle:  strings.TrimSpace(motivo),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usuario.BaneadoHasta = nil
+	usuario.MotivoBaneo = ""
+	return usuario, nil
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+	"time"
+)
+
+type RefreshUseCase struct {
+	usuarioRepo repositories.IUsuarioRepository
+	tokenRepo   repositories.IRefreshTokenRepository
+	jwtManager  *core.JWTManager
+}
+
+func NewRefreshUseCase(usuarioRepo repositories.IUsuarioRepository, tokenRepo repositories.IRefreshTokenRepository, jwtManager *core.JWTManager) *RefreshUseCase {
+	return &RefreshUseCase{
+		usuarioRepo: usuarioRepo,
+		tokenRepo:   tokenRepo,
+		jwtManager:  jwtManager,
+	}
+}
+
+// Execute rota el refresh token: revoca el recibido y emite un par nuevo
+func (uc *RefreshUseCase) Execute(refreshToken string) (*entities.Usuario, *entities.Tokens, error) {
+	if refreshToken == "" {
+		return nil, nil, errors.New("refresh_token es requerido")
+	}
+
+	tokenHash := core.HashToken(refreshToken)
+
+	registro, err := uc.tokenRepo.ObtenerPorHash(tokenHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !registro.Vigente() {
+		return nil, nil, errors.New("refresh token inválido")
+	}
+
+	// Revocar antes de emitir evita que el mismo refresh token se use dos veces
+	if err := uc.tokenRepo.Revocar(tokenHash); err != nil {
+		return nil, nil, err
+	}
+
+	usuario, err := uc.usuarioRepo.ObtenerPorID(registro.UsuarioID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if usuario.Baneado(time.Now()) {
+		return nil, nil, entities.NewUsuarioBaneadoError(usuario)
+	}
+
+	tokens, err := emitirTokens(uc.jwtManager, uc.tokenRepo, usuario)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return usuario, tokens, nil
+}
@@ -16,12 +16,17 @@ func NewRegisterUseCase(usuarioRepo repositories.IUsuarioRepository) *RegisterUs
 	}
 }
 
-func (uc *RegisterUseCase) Execute(username, password, nombre string) (*entities.Usuario, error) {
+func (uc *RegisterUseCase) Execute(username, password, nombre, email string) (*entities.Usuario, error) {
 	if username == "" || password == "" || nombre == "" {
 		return nil, errors.New("username, password y nombre son requeridos")
 	}
 
-	usuario, err := uc.usuarioRepo.Register(username, password, nombre)
+	email, err := entities.NormalizarEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	usuario, err := uc.usuarioRepo.Register(username, password, nombre, email)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+)
+
+type RestablecerPasswordUseCase struct {
+	usuarioRepo      repositories.IUsuarioRepository
+	recuperacionRepo repositories.ITokenRecuperacionRepository
+	tokenRepo        repositories.IRefreshTokenRepository
+	intentoRepo      repositories.IIntentoLoginRepository
+}
+
+func NewRestablecerPasswordUseCase(usuarioRepo repositories.IUsuarioRepository, recuperacionRepo repositories.ITokenRecuperacionRepository, tokenRepo repositories.IRefreshTokenRepository, intentoRepo repositories.IIntentoLoginRepository) *RestablecerPasswordUseCase {
+	return &RestablecerPasswordUseCase{
+		usuarioRepo:      usuarioRepo,
+		recuperacionRepo: recuperacionRepo,
+		tokenRepo:        tokenRepo,
+		intentoRepo:      intentoRepo,
+	}
+}
+
+// Execute consume el token de recuperación y guarda la nueva password. Se cierran todas las sesiones
+// del usuario y se borran sus logins fallidos para que pueda entrar de inmediato
+func (uc *RestablecerPasswordUseCase) Execute(token, nueva string) error {
+	if token == "" || nueva == "" {
+		return errors.New("token y password son requeridos")
+	}
+	// Validar antes de consumir el token: una password rechazada no debe gastar el enlace
+	if err := entities.ValidarPassword(nueva); err != nil {
+		return err
+	}
+
+	tokenHash := core.HashToken(token)
+	registro, err := uc.recuperacionRepo.ObtenerPorHash(tokenHash)
+	if err != nil {
+		return err
+	}
+	if !registro.Vigente() {
+		return entities.ErrTokenRecuperacionInvalido
+	}
+
+	usuario, err := uc.usuarioRepo.ObtenerPorID(registro.UsuarioID)
+	if err != nil {
+		return err
+	}
+
+	// El token se consume junto con el cambio de password: si dos peticiones usan el mismo token solo una pasa
+	if err := uc.usuarioRepo.RestablecerPassword(usuario.ID, tokenHash, nueva); err != nil {
+		return err
+	}
+
+	if err := uc.tokenRepo.RevocarTodosDeUsuario(usuario.ID); err != nil {
+		return err
+	}
+	return uc.intentoRepo.LimpiarIntentos(claveUsuario(usuario.Username))
+}
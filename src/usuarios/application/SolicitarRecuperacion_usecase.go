@@ -0,0 +1,88 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+	"log"
+	"time"
+)
+
+// solicitudesPorEmail limita los correos de recuperación que puede recibir una dirección
+var solicitudesPorEmail = core.Tasa{Rafaga: 3, Periodo: 15 * time.Minute}
+
+type SolicitarRecuperacionUseCase struct {
+	usuarioRepo      repositories.IUsuarioRepository
+	recuperacionRepo repositories.ITokenRecuperacionRepository
+	mailer           core.Mailer
+	ttl              time.Duration
+	urlRestablecer   string
+	limitador        *core.LimitadorPorClave
+}
+
+// NewSolicitarRecuperacionUseCase recibe la vigencia del token y la URL del frontend a la que se agrega ?token=
+func NewSolicitarRecuperacionUseCase(usuarioRepo repositories.IUsuarioRepository, recuperacionRepo repositories.ITokenRecuperacionRepository, mailer core.Mailer, ttl time.Duration, urlRestablecer string) *SolicitarRecuperacionUseCase {
+	return &SolicitarRecuperacionUseCase{
+		usuarioRepo:      usuarioRepo,
+		recuperacionRepo: recuperacionRepo,
+		mailer:           mailer,
+		ttl:              ttl,
+		urlRestablecer:   urlRestablecer,
+		limitador:        core.NewLimitadorPorClave(solicitudesPorEmail),
+	}
+}
+
+// Execute envía un enlace de recuperación si el email pertenece a un usuario. El resultado es el mismo
+// exista o no (y el correo sale en segundo plano), para no revelar qué emails están registrados
+func (uc *SolicitarRecuperacionUseCase) Execute(email string) error {
+	email, err := entities.NormalizarEmail(email)
+	if err != nil {
+		return err
+	}
+	if email == "" {
+		return errors.New("email es requerido")
+	}
+
+	if ok, _ := uc.limitador.Permitir(email); !ok {
+		log.Printf("Recuperación de password omitida para %s: demasiadas solicitudes", email)
+		return nil
+	}
+
+	usuario, err := uc.usuarioRepo.ObtenerPorEmail(email)
+	if errors.Is(err, entities.ErrUsuarioNoEncontrado) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Solo el último enlace enviado sirve
+	if err := uc.recuperacionRepo.InvalidarDeUsuario(usuario.ID); err != nil {
+		return err
+	}
+
+	token, err := core.GenerarTokenAleatorio()
+	if err != nil {
+		return err
+	}
+	registro := entities.NewTokenRecuperacion(usuario.ID, core.HashToken(token), time.Now().Add(uc.ttl))
+	if err := uc.recuperacionRepo.Guardar(registro); err != nil {
+		return err
+	}
+
+	correo := core.Correo{
+		Para:   email,
+		Asunto: "Recupera tu password de Games Football",
+		Cuerpo: fmt.Sprintf("Hola %s,\n\nPara elegir una nueva password abre este enlace:\n\n%s?token=%s\n\nEl enlace vence en %s y solo se puede usar una vez. Si no lo pediste, ignora este correo.\n",
+			usuario.Nombre, uc.urlRestablecer, token, uc.ttl),
+	}
+	go func() {
+		if err := uc.mailer.Enviar(correo); err != nil {
+			log.Printf("Error al enviar correo de recuperación a %s: %v", email, err)
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package application
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/domain/entities"
+	"games-football-api/src/usuarios/domain/repositories"
+	"time"
+)
+
+// emitirTokens genera un access token firmado y un refresh token persistido para el usuario
+func emitirTokens(jwtManager *core.JWTManager, tokenRepo repositories.IRefreshTokenRepository, usuario *entities.Usuario) (*entities.Tokens, error) {
+	accessToken, err := jwtManager.Generar(usuario.ID, usuario.Username, usuario.Nombre, usuario.Rol)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := core.GenerarTokenAleatorio()
+	if err != nil {
+		return nil, err
+	}
+
+	// Solo se persiste el hash del refresh token
+	registro := entities.NewRefreshToken(usuario.ID, core.HashToken(refreshToken), time.Now().Add(jwtManager.RefreshTTL()))
+	if err := tokenRepo.Guardar(registro); err != nil {
+		return nil, err
+	}
+
+	return &entities.Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(jwtManager.AccessTTL().Seconds()),
+	}, nil
+}
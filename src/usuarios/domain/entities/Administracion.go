@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Errores de las acciones administrativas
+var (
+	ErrUsuarioBaneado     = errors.New("tu cuenta está suspendida")
+	ErrRolInvalido        = errors.New("rol inválido: usa usuario, moderador o admin")
+	ErrAccionSobreSiMismo = errors.New("no puedes aplicarte esta acción a ti mismo")
+	ErrRolInsuficiente    = errors.New("solo puedes aplicar esta acción a usuarios con un rol menor al tuyo")
+	ErrSoloAdmin          = errors.New("solo un admin puede cambiar roles")
+	ErrRolMayorAlTuyo     = errors.New("no puedes asignar un rol mayor al tuyo")
+)
+
+// BaneoPermanente es la fecha que se guarda en baneado_hasta cuando el baneo no tiene fin
+var BaneoPermanente = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// Eventos de auditoría de las acciones administrativas sobre usuarios
+const (
+	EventoBanearUsuario = "banear_usuario"
+	EventoQuitarBaneo   = "quitar_baneo"
+	EventoCambiarRol    = "cambiar_rol"
+)
+
+// UsuarioBaneadoError se retorna al iniciar sesión o refrescar el token con la cuenta suspendida
+type UsuarioBaneadoError struct {
+	Hasta  time.Time
+	Motivo string
+}
+
+// NewUsuarioBaneadoError arma el error con los datos del baneo vigente del usuario
+func NewUsuarioBaneadoError(u *Usuario) *UsuarioBaneadoError {
+	return &UsuarioBaneadoError{
+		Hasta:  *u.BaneadoHasta,
+		Motivo: u.MotivoBaneo,
+	}
+}
+
+// Permanente indica si el baneo no tiene fecha de fin
+func (e *UsuarioBaneadoError) Permanente() bool {
+	return !e.Hasta.Before(BaneoPermanente)
+}
+
+func (e *UsuarioBaneadoError) Error() string {
+	if e.Permanente() {
+		return ErrUsuarioBaneado.Error()
+	}
+	return fmt.Sprintf("%s hasta %s", ErrUsuarioBaneado.Error(), e.Hasta.Format(time.RFC3339))
+}
+
+func (e *UsuarioBaneadoError) Unwrap() error {
+	return ErrUsuarioBaneado
+}
+
+// FiltroUsuarios son los filtros del listado de usuarios del panel de administración
+type FiltroUsuarios struct {
+	Busqueda     string // Coincidencia parcial en username, nombre o email
+	Rol          string
+	SoloBaneados bool
+}
+
+// FiltroAuditoria son los filtros del listado de eventos de auditoría
+type FiltroAuditoria struct {
+	Evento   string
+	ActorID  string
+	Objetivo string
+}
@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+// Eventos de auditoría de seguridad
+const (
+	EventoBloqueoLogin = "bloqueo_login"
+)
+
+// EventoAuditoria es un registro de seguridad o administración: qué pasó, quién lo hizo y sobre qué
+type EventoAuditoria struct {
+	ID       string    `json:"id"`
+	Evento   string    `json:"evento"`
+	ActorID  string    `json:"actor_id,omitempty"`
+	Objetivo string    `json:"objetivo"`
+	IP       string    `json:"ip,omitempty"`
+	Detalle  string    `json:"detalle,omitempty"`
+	CreadoEn time.Time `json:"creado_en"`
+}
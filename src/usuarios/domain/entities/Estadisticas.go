@@ -0,0 +1,59 @@
+package entities
+
+import "time"
+
+// VentanaBajaTardia: salir de una reta con menos de este tiempo de anticipación cuenta como falta
+const VentanaBajaTardia = 24 * time.Hour
+
+// ZonaFrecuente es una zona donde el usuario ha jugado y cuántas retas jugó ahí
+type ZonaFrecuente struct {
+	ZonaID       string `json:"zona_id"`
+	Nombre       string `json:"nombre"`
+	RetasJugadas int    `json:"retas_jugadas"`
+}
+
+// EstadisticasUsuario resume la actividad de un usuario
+type EstadisticasUsuario struct {
+	UsuarioID        string `json:"usuario_id"`
+	RetasCreadas     int    `json:"retas_creadas"`
+	RetasUnidas      int    `json:"retas_unidas"`  // Veces que se unió a retas de otros (aunque después saliera)
+	RetasJugadas     int    `json:"retas_jugadas"` // Retas finalizadas en las que seguía inscrito
+	BajasTardias     int    `json:"bajas_tardias"` // Salidas con menos de 24 h de anticipación
+	MensajesEnviados int    `json:"mensajes_enviados"`
+
+	// Asistencia es el porcentaje de retas jugadas sobre jugadas + bajas tardías (nil sin datos)
+	Asistencia *float64 `json:"asistencia"`
+
+	ZonasFavoritas []ZonaFrecuente `json:"zonas_favoritas"`
+	ActualizadoEn  time.Time       `json:"actualizado_en"`
+}
+
+// CalcularAsistencia llena Asistencia a partir de las retas jugadas y las bajas tardías
+func (e *EstadisticasUsuario) CalcularAsistencia() {
+	total := e.RetasJugadas + e.BajasTardias
+	if total == 0 {
+		e.Asistencia = nil
+		return
+	}
+	porcentaje := float64(e.RetasJugadas) * 100 / float64(total)
+	porcentaje = float64(int(porcentaje*10+0.5)) / 10
+	e.Asistencia = &porcentaje
+}
+
+// RetaHistorial es una reta pasada (finalizada o cancelada) en la que participó el usuario
+type RetaHistorial struct {
+	RetaID    string    `json:"reta_id"`
+	ZonaID    string    `json:"zona_id"`
+	Titulo    string    `json:"titulo"`
+	FechaHora time.Time `json:"fecha_hora"`
+	Estado    string    `json:"estado"`
+	Jugadores int       `json:"jugadores"`
+	Creador   bool      `json:"creador"`
+}
+
+// PaginaHistorial es una página del historial de retas de un usuario
+type PaginaHistorial struct {
+	Retas  []RetaHistorial `json:"retas"`
+	Pagina int             `json:"pagina"`
+	HayMas bool            `json:"hay_mas"`
+}
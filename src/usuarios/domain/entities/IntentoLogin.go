@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrCredencialesInvalidas se retorna tanto si el username no existe como si la password no coincide
+var ErrCredencialesInvalidas = errors.New("credenciales inválidas")
+
+// IntentoLogin acumula los logins fallidos de una clave ("usuario:<username>" o "ip:<ip>")
+type IntentoLogin struct {
+	Clave          string
+	Fallos         int
+	UltimoFallo    time.Time
+	BloqueadoHasta *time.Time
+}
+
+// Bloqueado indica si la clave sigue bloqueada y cuánto falta para que se libere
+func (i *IntentoLogin) Bloqueado(ahora time.Time) (bool, time.Duration) {
+	if i == nil || i.BloqueadoHasta == nil || !ahora.Before(*i.BloqueadoHasta) {
+		return false, 0
+	}
+	return true, i.BloqueadoHasta.Sub(ahora)
+}
+
+// LoginBloqueadoError indica que el login se rechazó sin revisar la password por exceso de intentos fallidos.
+// No distingue entre usernames existentes e inexistentes
+type LoginBloqueadoError struct {
+	Restante time.Duration
+}
+
+func (e *LoginBloqueadoError) Error() string {
+	return fmt.Sprintf("demasiados intentos fallidos, intenta de nuevo en %d segundos", e.Segundos())
+}
+
+// Segundos retorna el tiempo restante del bloqueo redondeado hacia arriba
+func (e *LoginBloqueadoError) Segundos() int {
+	return int(math.Ceil(e.Restante.Seconds()))
+}
@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+// Tokens representa el par de tokens que se entrega al iniciar sesión o al refrescar
+type Tokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// RefreshToken representa un refresh token persistido (solo se guarda su hash)
+type RefreshToken struct {
+	ID         string
+	UsuarioID  string
+	TokenHash  string
+	ExpiraEn   time.Time
+	RevocadoEn *time.Time
+}
+
+func NewRefreshToken(usuarioID, tokenHash string, expiraEn time.Time) *RefreshToken {
+	return &RefreshToken{
+		UsuarioID: usuarioID,
+		TokenHash: tokenHash,
+		ExpiraEn:  expiraEn,
+	}
+}
+
+// Vigente indica si el refresh token no ha sido revocado ni ha expirado
+func (t *RefreshToken) Vigente() bool {
+	return t.RevocadoEn == nil && time.Now().Before(t.ExpiraEn)
+}
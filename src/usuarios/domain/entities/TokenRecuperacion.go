@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTokenRecuperacionInvalido no distingue entre token desconocido, expirado o ya usado
+var ErrTokenRecuperacionInvalido = errors.New("el enlace de recuperación es inválido o expiró")
+
+// TokenRecuperacion es un token de un solo uso para restablecer la password (solo se guarda su hash)
+type TokenRecuperacion struct {
+	ID        string
+	UsuarioID string
+	TokenHash string
+	ExpiraEn  time.Time
+	UsadoEn   *time.Time
+}
+
+func NewTokenRecuperacion(usuarioID, tokenHash string, expiraEn time.Time) *TokenRecuperacion {
+	return &TokenRecuperacion{
+		UsuarioID: usuarioID,
+		TokenHash: tokenHash,
+		ExpiraEn:  expiraEn,
+	}
+}
+
+// Vigente indica si el token no se ha usado ni ha expirado
+func (t *TokenRecuperacion) Vigente() bool {
+	return t.UsadoEn == nil && time.Now().Before(t.ExpiraEn)
+}
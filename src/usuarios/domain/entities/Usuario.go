@@ -1,9 +1,168 @@
 package entities
 
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Errores del perfil de usuario
+var (
+	ErrUsuarioNoEncontrado  = errors.New("usuario no encontrado")
+	ErrNombreVacio          = errors.New("nombre no puede estar vacío")
+	ErrPosicionInvalida     = errors.New("posicion inválida: usa portero, defensa, medio o delantero")
+	ErrNivelInvalido        = errors.New("nivel inválido: usa principiante, intermedio o avanzado")
+	ErrTelefonoInvalido     = errors.New("telefono inválido: usa de 10 a 15 dígitos, con + opcional al inicio")
+	ErrZonaFavoritaInvalida = errors.New("la zona favorita no existe")
+	ErrPasswordActual       = errors.New("la password actual no es correcta")
+	ErrPasswordRepetida     = errors.New("la nueva password debe ser distinta de la actual")
+	ErrPasswordLarga        = errors.New("la password no puede tener más de 72 bytes")
+	ErrEmailInvalido        = errors.New("email inválido")
+	ErrEmailRegistrado      = errors.New("el email ya está registrado")
+)
+
+// Posiciones de juego preferidas
+var posiciones = []string{"portero", "defensa", "medio", "delantero"}
+
+// Niveles de juego
+var niveles = []string{"principiante", "intermedio", "avanzado"}
+
+var formatoTelefono = regexp.MustCompile(`^\+?[0-9]{10,15}$`)
+
 // Usuario representa a un usuario registrado en el sistema
 type Usuario struct {
 	ID       string `json:"id"`
 	Username string `json:"username"`
 	Password string `json:"-"`
 	Nombre   string `json:"nombre"`
+
+	// Rol (usuario, moderador o admin) y suspensión de la cuenta
+	Rol          string     `json:"rol"`
+	BaneadoHasta *time.Time `json:"baneado_hasta,omitempty"`
+	MotivoBaneo  string     `json:"motivo_baneo,omitempty"`
+
+	// Datos opcionales del perfil; el email es necesario para recuperar la password
+	Email        string `json:"email,omitempty"`
+	Posicion     string `json:"posicion,omitempty"`
+	Nivel        string `json:"nivel,omitempty"`
+	ZonaFavorita string `json:"zona_favorita,omitempty"`
+	Telefono     string `json:"telefono,omitempty"`
+}
+
+// Baneado indica si la cuenta está suspendida en este momento
+func (u *Usuario) Baneado(ahora time.Time) bool {
+	return u.BaneadoHasta != nil && ahora.Before(*u.BaneadoHasta)
+}
+
+// PerfilPublico es lo que ven los demás usuarios: sin username ni teléfono
+type PerfilPublico struct {
+	ID           string `json:"id"`
+	Nombre       string `json:"nombre"`
+	Posicion     string `json:"posicion,omitempty"`
+	Nivel        string `json:"nivel,omitempty"`
+	ZonaFavorita string `json:"zona_favorita,omitempty"`
+}
+
+// PerfilPublico retorna la vista pública del usuario
+func (u *Usuario) PerfilPublico() *PerfilPublico {
+	return &PerfilPublico{
+		ID:           u.ID,
+		Nombre:       u.Nombre,
+		Posicion:     u.Posicion,
+		Nivel:        u.Nivel,
+		ZonaFavorita: u.ZonaFavorita,
+	}
+}
+
+// CambiosPerfil son los campos que se quieren modificar del perfil; nil deja el valor actual
+// y una cadena vacía borra los opcionales
+type CambiosPerfil struct {
+	Nombre       *string
+	Email        *string
+	Posicion     *string
+	Nivel        *string
+	ZonaFavorita *string
+	Telefono     *string
+}
+
+// Aplicar valida los cambios y los copia al usuario
+func (c CambiosPerfil) Aplicar(u *Usuario) error {
+	if c.Nombre != nil {
+		nombre := strings.TrimSpace(*c.Nombre)
+		if nombre == "" {
+			return ErrNombreVacio
+		}
+		u.Nombre = nombre
+	}
+	if c.Email != nil {
+		email, err := NormalizarEmail(*c.Email)
+		if err != nil {
+			return err
+		}
+		u.Email = email
+	}
+	if c.Posicion != nil {
+		if *c.Posicion != "" && !contiene(posiciones, *c.Posicion) {
+			return ErrPosicionInvalida
+		}
+		u.Posicion = *c.Posicion
+	}
+	if c.Nivel != nil {
+		if *c.Nivel != "" && !contiene(niveles, *c.Nivel) {
+			return ErrNivelInvalido
+		}
+		u.Nivel = *c.Nivel
+	}
+	if c.ZonaFavorita != nil {
+		u.ZonaFavorita = strings.TrimSpace(*c.ZonaFavorita)
+	}
+	if c.Telefono != nil {
+		// Se guardan solo los dígitos (y el + inicial): se aceptan espacios, guiones y paréntesis
+		telefono := strings.Map(func(r rune) rune {
+			if strings.ContainsRune(" -()", r) {
+				return -1
+			}
+			return r
+		}, *c.Telefono)
+		if telefono != "" && !formatoTelefono.MatchString(telefono) {
+			return ErrTelefonoInvalido
+		}
+		u.Telefono = telefono
+	}
+	return nil
+}
+
+// NormalizarEmail valida un email opcional y lo retorna en minúsculas (vacío si no se envió)
+func NormalizarEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return "", nil
+	}
+	direccion, err := mail.ParseAddress(email)
+	if err != nil || direccion.Address != email {
+		return "", ErrEmailInvalido
+	}
+	return email, nil
+}
+
+// LongitudMaximaPassword es el máximo de bytes que bcrypt acepta al hashear
+const LongitudMaximaPassword = 72
+
+// ValidarPassword verifica que la password se pueda hashear antes de tocar la base de datos
+func ValidarPassword(password string) error {
+	if len(password) > LongitudMaximaPassword {
+		return ErrPasswordLarga
+	}
+	return nil
+}
+
+func contiene(valores []string, valor string) bool {
+	for _, v := range valores {
+		if v == valor {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"games-football-api/src/usuarios/domain/entities"
+)
+
+// IAuditoriaRepository define la interfaz para guardar eventos de auditoría
+type IAuditoriaRepository interface {
+	// Registrar guarda un evento de auditoría
+	Registrar(evento *entities.EventoAuditoria) error
+
+	// Listar retorna una página de eventos que cumplen los filtros, del más reciente al más antiguo,
+	// y el total de coincidencias
+	Listar(filtro entities.FiltroAuditoria, pagina, limite int) ([]*entities.EventoAuditoria, int, error)
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"games-football-api/src/usuarios/domain/entities"
+)
+
+// IEstadisticasRepository define la interfaz para las estadísticas e historial de los usuarios
+type IEstadisticasRepository interface {
+	// ActualizarEstadisticas suma al resumen del usuario lo ocurrido desde la última actualización y lo retorna
+	ActualizarEstadisticas(usuarioID string) (*entities.EstadisticasUsuario, error)
+
+	// ObtenerHistorial retorna una página de retas pasadas del usuario, de la más reciente a la más antigua
+	ObtenerHistorial(usuarioID string, pagina, limite int) (*entities.PaginaHistorial, error)
+}
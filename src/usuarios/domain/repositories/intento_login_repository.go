@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"games-football-api/src/usuarios/domain/entities"
+	"time"
+)
+
+// IIntentoLoginRepository define la interfaz para llevar la cuenta de logins fallidos
+type IIntentoLoginRepository interface {
+	// ObtenerIntento retorna los fallos de una clave (nil si no tiene)
+	ObtenerIntento(clave string) (*entities.IntentoLogin, error)
+
+	// RegistrarFallo suma un fallo a la clave y retorna el total; si el último fallo es más viejo
+	// que ventana, la cuenta vuelve a empezar
+	RegistrarFallo(clave string, ventana time.Duration) (int, error)
+
+	// Bloquear impide los logins de la clave hasta la fecha dada
+	Bloquear(clave string, hasta time.Time) error
+
+	// LimpiarIntentos borra los fallos de la clave (login exitoso)
+	LimpiarIntentos(clave string) error
+}
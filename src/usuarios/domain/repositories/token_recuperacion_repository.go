@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"games-football-api/src/usuarios/domain/entities"
+)
+
+// ITokenRecuperacionRepository define la interfaz para persistir los tokens de recuperación de password
+type ITokenRecuperacionRepository interface {
+	// Guardar persiste un nuevo token de recuperación
+	Guardar(token *entities.TokenRecuperacion) error
+
+	// ObtenerPorHash busca un token por el hash del valor enviado por correo
+	ObtenerPorHash(tokenHash string) (*entities.TokenRecuperacion, error)
+
+	// InvalidarDeUsuario marca como usados los tokens pendientes del usuario
+	InvalidarDeUsuario(usuarioID string) error
+}
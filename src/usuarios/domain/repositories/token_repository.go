@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"games-football-api/src/usuarios/domain/entities"
+)
+
+// IRefreshTokenRepository define la interfaz para persistir refresh tokens
+type IRefreshTokenRepository interface {
+	// Guardar persiste un nuevo refresh token
+	Guardar(token *entities.RefreshToken) error
+
+	// ObtenerPorHash busca un refresh token por el hash del valor entregado al cliente
+	ObtenerPorHash(tokenHash string) (*entities.RefreshToken, error)
+
+	// Revocar marca un refresh token como revocado; falla si ya estaba revocado
+	Revocar(tokenHash string) error
+
+	// RevocarTodosDeUsuario revoca todos los refresh tokens vigentes de un usuario
+	RevocarTodosDeUsuario(usuarioID string) error
+}
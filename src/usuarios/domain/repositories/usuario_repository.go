@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"games-football-api/src/usuarios/domain/entities"
+	"time"
 )
 
 // IUsuarioRepository define la interfaz para operaciones de usuarios
@@ -9,6 +10,36 @@ type IUsuarioRepository interface {
 	// Login busca un usuario por username y password, retorna el usuario si hace match
 	Login(username, password string) (*entities.Usuario, error)
 
-	// Register crea un nuevo usuario y retorna el usuario creado
-	Register(username, password, nombre string) (*entities.Usuario, error)
+	// Register crea un nuevo usuario y retorna el usuario creado; email es opcional
+	Register(username, password, nombre, email string) (*entities.Usuario, error)
+
+	// ObtenerPorID busca un usuario por su id con todos los datos de su perfil
+	ObtenerPorID(id string) (*entities.Usuario, error)
+
+	// ActualizarPerfil guarda nombre, posición, nivel, zona favorita y teléfono del usuario
+	ActualizarPerfil(usuario *entities.Usuario) error
+
+	// ObtenerPorEmail busca un usuario por su email
+	ObtenerPorEmail(email string) (*entities.Usuario, error)
+
+	// RestablecerPassword guarda una password nueva sin pedir la actual (recuperación por email) y consume
+	// el token de recuperación en la misma transacción; falla con ErrTokenRecuperacionInvalido si el token
+	// ya se había usado
+	RestablecerPassword(id, tokenHash, nueva string) error
+
+	// CambiarPassword reemplaza la password si la actual coincide con el hash almacenado
+	CambiarPassword(id, actual, nueva string) error
+
+	// ListarUsuarios retorna una página de usuarios que cumplen los filtros y el total de coincidencias
+	ListarUsuarios(filtro entities.FiltroUsuarios, pagina, limite int) ([]*entities.Usuario, int, error)
+
+	// Banear suspende la cuenta hasta la fecha indicada (nil = permanente), revoca sus refresh tokens
+	// y guarda el evento de auditoría, todo en una transacción
+	Banear(id string, hasta *time.Time, motivo string, evento *entities.EventoAuditoria) error
+
+	// QuitarBaneo levanta la suspensión de la cuenta y guarda el evento de auditoría en una transacción
+	QuitarBaneo(id string, evento *entities.EventoAuditoria) error
+
+	// CambiarRol asigna un nuevo rol al usuario y guarda el evento de auditoría en una transacción
+	CambiarRol(id, rol string, evento *entities.EventoAuditoria) error
 }
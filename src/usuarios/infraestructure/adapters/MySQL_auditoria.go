@@ -0,0 +1,93 @@
+package adapters
+
+import (
+	"database/sql"
+	"fmt"
+	"games-football-api/src/usuarios/domain/entities"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MySQLAuditoriaRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLAuditoriaRepository(db *sql.DB) *MySQLAuditoriaRepository {
+	return &MySQLAuditoriaRepository{
+		db: db,
+	}
+}
+
+// ejecutor es lo que comparten *sql.DB y *sql.Tx para los INSERT/UPDATE que se hacen dentro o fuera de una transacción
+type ejecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// Registrar guarda un evento en la tabla auditoria
+func (repo *MySQLAuditoriaRepository) Registrar(evento *entities.EventoAuditoria) error {
+	return insertarEventoAuditoria(repo.db, evento)
+}
+
+// insertarEventoAuditoria asigna id y fecha al evento y lo guarda con el ejecutor indicado
+func insertarEventoAuditoria(db ejecutor, evento *entities.EventoAuditoria) error {
+	evento.ID = uuid.New().String()
+	evento.CreadoEn = time.Now()
+
+	query := "INSERT INTO auditoria (id, evento, actor_id, objetivo, ip, detalle, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+	_, err := db.Exec(query, evento.ID, evento.Evento, nullSiVacio(evento.ActorID), evento.Objetivo, nullSiVacio(evento.IP), evento.Detalle, evento.CreadoEn)
+	if err != nil {
+		return fmt.Errorf("error al registrar evento de auditoría: %w", err)
+	}
+	return nil
+}
+
+// Listar retorna una página de eventos de auditoría filtrados por evento, actor u objetivo
+func (repo *MySQLAuditoriaRepository) Listar(filtro entities.FiltroAuditoria, pagina, limite int) ([]*entities.EventoAuditoria, int, error) {
+	condiciones := []string{"1 = 1"}
+	args := []interface{}{}
+	if filtro.Evento != "" {
+		condiciones = append(condiciones, "evento = ?")
+		args = append(args, filtro.Evento)
+	}
+	if filtro.ActorID != "" {
+		condiciones = append(condiciones, "actor_id = ?")
+		args = append(args, filtro.ActorID)
+	}
+	if filtro.Objetivo != "" {
+		condiciones = append(condiciones, "objetivo = ?")
+		args = append(args, filtro.Objetivo)
+	}
+	where := " WHERE " + strings.Join(condiciones, " AND ")
+
+	var total int
+	if err := repo.db.QueryRow("SELECT COUNT(*) FROM auditoria"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("error al contar eventos de auditoría: %w", err)
+	}
+
+	query := "SELECT id, evento, actor_id, objetivo, ip, detalle, created_at FROM auditoria" + where + " ORDER BY created_at DESC, id LIMIT ? OFFSET ?"
+	rows, err := repo.db.Query(query, append(args, limite, (pagina-1)*limite)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error al listar eventos de auditoría: %w", err)
+	}
+	defer rows.Close()
+
+	eventos := []*entities.EventoAuditoria{}
+	for rows.Next() {
+		var evento entities.EventoAuditoria
+		var actorID, ip sql.NullString
+		if err := rows.Scan(&evento.ID, &evento.Evento, &actorID, &evento.Objetivo, &ip, &evento.Detalle, &evento.CreadoEn); err != nil {
+			return nil, 0, fmt.Errorf("error al leer evento de auditoría: %w", err)
+		}
+		evento.ActorID = actorID.String
+		evento.IP = ip.String
+		eventos = append(eventos, &evento)
+	}
+	return eventos, total, rows.Err()
+}
+
+// nullSiVacio guarda NULL en lugar de una cadena vacía
+func nullSiVacio(valor string) sql.NullString {
+	return sql.NullString{String: valor, Valid: valor != ""}
+}
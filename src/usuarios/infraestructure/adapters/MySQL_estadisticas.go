@@ -0,0 +1,216 @@
+package adapters
+
+import (
+	"database/sql"
+	"fmt"
+	"games-football-api/src/usuarios/domain/entities"
+	"time"
+)
+
+// margenConteo deja fuera las filas más recientes: una transacción que aún no hace commit puede
+// tener un created_at anterior al corte y quedaría fuera para siempre si se contara hasta NOW()
+const margenConteo = 5 * time.Second
+
+// zonasFavoritas es cuántas zonas se muestran en las estadísticas
+const zonasFavoritas = 3
+
+type MySQLEstadisticasRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLEstadisticasRepository(db *sql.DB) *MySQLEstadisticasRepository {
+	return &MySQLEstadisticasRepository{
+		db: db,
+	}
+}
+
+// ActualizarEstadisticas lee el resumen de estadisticas_usuario y le suma solo las filas nuevas de
+// retas, reta_jugadores, reta_bajas y mensajes_reta desde sus marcas (contado_hasta y jugadas_hasta).
+// La fila del resumen se bloquea con FOR UPDATE para que dos peticiones no cuenten lo mismo dos veces
+func (repo *MySQLEstadisticasRepository) ActualizarEstadisticas(usuarioID string) (*entities.EstadisticasUsuario, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	insertQuery := "INSERT IGNORE INTO estadisticas_usuario (usuario_id, contado_hasta, jugadas_hasta) VALUES (?, '1000-01-01', '1000-01-01')"
+	if _, err := tx.Exec(insertQuery, usuarioID); err != nil {
+		return nil, fmt.Errorf("error al crear resumen de estadísticas: %w", err)
+	}
+
+	est := entities.EstadisticasUsuario{UsuarioID: usuarioID}
+	var contadoHasta, jugadasHasta time.Time
+	selectQuery := `
+		SELECT retas_creadas, retas_unidas, retas_jugadas, bajas_tardias, mensajes_enviados, contado_hasta, jugadas_hasta
+		FROM estadisticas_usuario WHERE usuario_id = ? FOR UPDATE
+	`
+	err = tx.QueryRow(selectQuery, usuarioID).Scan(&est.RetasCreadas, &est.RetasUnidas, &est.RetasJugadas, &est.BajasTardias, &est.MensajesEnviados, &contadoHasta, &jugadasHasta)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer resumen de estadísticas: %w", err)
+	}
+
+	// Cortes calculados con el reloj de MySQL, el mismo que llena created_at
+	var corte time.Time
+	var pendienteMasAntigua sql.NullTime
+	cortesQuery := `
+		SELECT NOW(3) - INTERVAL ? MICROSECOND,
+			(SELECT MIN(fecha_hora) FROM retas WHERE estado IN ('abierta', 'llena', 'en_juego'))
+	`
+	if err := tx.QueryRow(cortesQuery, margenConteo.Microseconds()).Scan(&corte, &pendienteMasAntigua); err != nil {
+		return nil, fmt.Errorf("error al calcular cortes de estadísticas: %w", err)
+	}
+
+	// Conteos por fecha de creación en el rango (contado_hasta, corte]
+	conteos := []struct {
+		destino *int
+		query   string
+		args    []interface{}
+	}{
+		{&est.RetasCreadas, "SELECT COUNT(*) FROM retas WHERE creador_id = ? AND created_at > ? AND created_at <= ?",
+			[]interface{}{usuarioID, contadoHasta, corte}},
+		// Uniones vigentes a retas de otros usuarios...
+		{&est.RetasUnidas, `
+			SELECT COUNT(*) FROM reta_jugadores rj
+			INNER JOIN retas r ON r.id = rj.reta_id
+			WHERE rj.usuario_id = ? AND r.creador_id <> rj.usuario_id AND rj.created_at > ? AND rj.created_at <= ?`,
+			[]interface{}{usuarioID, contadoHasta, corte}},
+		// ...más las que ya se borraron de reta_jugadores porque el usuario salió
+		{&est.RetasUnidas, "SELECT COUNT(*) FROM reta_bajas WHERE usuario_id = ? AND unido_en > ? AND unido_en <= ?",
+			[]interface{}{usuarioID, contadoHasta, corte}},
+		{&est.BajasTardias, `
+			SELECT COUNT(*) FROM reta_bajas b
+			INNER JOIN retas r ON r.id = b.reta_id
+			WHERE b.usuario_id = ? AND b.salio_en > ? AND b.salio_en <= ? AND b.salio_en >= r.fecha_hora - INTERVAL ? SECOND`,
+			[]interface{}{usuarioID, contadoHasta, corte, int(entities.VentanaBajaTardia.Seconds())}},
+		{&est.MensajesEnviados, "SELECT COUNT(*) FROM mensajes_reta WHERE usuario_id = ? AND creado_en > ? AND creado_en <= ?",
+			[]interface{}{usuarioID, contadoHasta, corte}},
+	}
+	for _, conteo := range conteos {
+		var nuevos int
+		if err := tx.QueryRow(conteo.query, conteo.args...).Scan(&nuevos); err != nil {
+			return nil, fmt.Errorf("error al contar estadísticas: %w", err)
+		}
+		*conteo.destino += nuevos
+	}
+
+	// Las retas jugadas se cuentan por fecha_hora solo hasta la reta pendiente más antigua: antes de ese
+	// punto todas las retas están finalizadas o canceladas y sus jugadores ya no cambian
+	corteJugadas := corte
+	if pendienteMasAntigua.Valid && pendienteMasAntigua.Time.Before(corteJugadas) {
+		corteJugadas = pendienteMasAntigua.Time
+	}
+	if corteJugadas.After(jugadasHasta) {
+		filtroJugadas := `
+			FROM reta_jugadores rj
+			INNER JOIN retas r ON r.id = rj.reta_id
+			WHERE rj.usuario_id = ? AND r.estado = 'finalizada' AND r.fecha_hora >= ? AND r.fecha_hora < ?
+		`
+		var nuevas int
+		if err := tx.QueryRow("SELECT COUNT(*) "+filtroJugadas, usuarioID, jugadasHasta, corteJugadas).Scan(&nuevas); err != nil {
+			return nil, fmt.Errorf("error al contar retas jugadas: %w", err)
+		}
+		est.RetasJugadas += nuevas
+
+		zonasQuery := `
+			INSERT INTO estadisticas_usuario_zonas (usuario_id, zona_id, retas_jugadas)
+			SELECT rj.usuario_id, r.zona_id, COUNT(*) ` + filtroJugadas + `
+			GROUP BY rj.usuario_id, r.zona_id
+			ON DUPLICATE KEY UPDATE retas_jugadas = retas_jugadas + VALUES(retas_jugadas)
+		`
+		if _, err := tx.Exec(zonasQuery, usuarioID, jugadasHasta, corteJugadas); err != nil {
+			return nil, fmt.Errorf("error al actualizar zonas jugadas: %w", err)
+		}
+		jugadasHasta = corteJugadas
+	}
+
+	updateQuery := `
+		UPDATE estadisticas_usuario
+		SET retas_creadas = ?, retas_unidas = ?, retas_jugadas = ?, bajas_tardias = ?, mensajes_enviados = ?,
+			contado_hasta = ?, jugadas_hasta = ?, actualizado_en = NOW(3)
+		WHERE usuario_id = ?
+	`
+	_, err = tx.Exec(updateQuery, est.RetasCreadas, est.RetasUnidas, est.RetasJugadas, est.BajasTardias, est.MensajesEnviados, corte, jugadasHasta, usuarioID)
+	if err != nil {
+		return nil, fmt.Errorf("error al guardar resumen de estadísticas: %w", err)
+	}
+
+	est.ZonasFavoritas, err = zonasFrecuentes(tx, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error al hacer commit: %w", err)
+	}
+
+	est.ActualizadoEn = time.Now()
+	est.CalcularAsistencia()
+	return &est, nil
+}
+
+// zonasFrecuentes retorna las zonas donde el usuario ha jugado más retas
+func zonasFrecuentes(tx *sql.Tx, usuarioID string) ([]entities.ZonaFrecuente, error) {
+	query := `
+		SELECT ez.zona_id, COALESCE(z.nombre, ez.zona_id), ez.retas_jugadas
+		FROM estadisticas_usuario_zonas ez
+		LEFT JOIN zonas z ON z.id = ez.zona_id
+		WHERE ez.usuario_id = ?
+		ORDER BY ez.retas_jugadas DESC, ez.zona_id
+		LIMIT ?
+	`
+	rows, err := tx.Query(query, usuarioID, zonasFavoritas)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar zonas favoritas: %w", err)
+	}
+	defer rows.Close()
+
+	zonas := []entities.ZonaFrecuente{}
+	for rows.Next() {
+		var zona entities.ZonaFrecuente
+		if err := rows.Scan(&zona.ZonaID, &zona.Nombre, &zona.RetasJugadas); err != nil {
+			return nil, fmt.Errorf("error al leer zona favorita: %w", err)
+		}
+		zonas = append(zonas, zona)
+	}
+	return zonas, rows.Err()
+}
+
+// ObtenerHistorial retorna las retas finalizadas o canceladas en las que el usuario sigue inscrito.
+// Se pide un registro de más para saber si hay otra página
+func (repo *MySQLEstadisticasRepository) ObtenerHistorial(usuarioID string, pagina, limite int) (*entities.PaginaHistorial, error) {
+	query := `
+		SELECT r.id, r.zona_id, r.titulo, r.fecha_hora, r.estado, r.jugadores_actuales, r.creador_id = rj.usuario_id
+		FROM reta_jugadores rj
+		INNER JOIN retas r ON r.id = rj.reta_id
+		WHERE rj.usuario_id = ? AND r.estado IN ('finalizada', 'cancelada')
+		ORDER BY r.fecha_hora DESC, r.id DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := repo.db.Query(query, usuarioID, limite+1, (pagina-1)*limite)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar historial: %w", err)
+	}
+	defer rows.Close()
+
+	resultado := &entities.PaginaHistorial{
+		Retas:  []entities.RetaHistorial{},
+		Pagina: pagina,
+	}
+	for rows.Next() {
+		var reta entities.RetaHistorial
+		if err := rows.Scan(&reta.RetaID, &reta.ZonaID, &reta.Titulo, &reta.FechaHora, &reta.Estado, &reta.Jugadores, &reta.Creador); err != nil {
+			return nil, fmt.Errorf("error al leer historial: %w", err)
+		}
+		resultado.Retas = append(resultado.Retas, reta)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al leer historial: %w", err)
+	}
+
+	if len(resultado.Retas) > limite {
+		resultado.Retas = resultado.Retas[:limite]
+		resultado.HayMas = true
+	}
+	return resultado, nil
+}
@@ -0,0 +1,89 @@
+package adapters
+
+import (
+	"database/sql"
+	"fmt"
+	"games-football-api/src/usuarios/domain/entities"
+	"time"
+)
+
+type MySQLIntentoLoginRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLIntentoLoginRepository(db *sql.DB) *MySQLIntentoLoginRepository {
+	return &MySQLIntentoLoginRepository{
+		db: db,
+	}
+}
+
+// ObtenerIntento busca los fallos de una clave; retorna nil si no tiene
+func (repo *MySQLIntentoLoginRepository) ObtenerIntento(clave string) (*entities.IntentoLogin, error) {
+	query := "SELECT clave, fallos, ultimo_fallo, bloqueado_hasta FROM intentos_login WHERE clave = ?"
+
+	var intento entities.IntentoLogin
+	var bloqueadoHasta sql.NullTime
+	err := repo.db.QueryRow(query, clave).Scan(&intento.Clave, &intento.Fallos, &intento.UltimoFallo, &bloqueadoHasta)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error al consultar intentos de login: %w", err)
+	}
+
+	if bloqueadoHasta.Valid {
+		intento.BloqueadoHasta = &bloqueadoHasta.Time
+	}
+	return &intento, nil
+}
+
+// RegistrarFallo incrementa los fallos de la clave en una sola sentencia para que los logins concurrentes
+// no se pisen; si el último fallo quedó fuera de la ventana, la cuenta se reinicia en 1
+func (repo *MySQLIntentoLoginRepository) RegistrarFallo(clave string, ventana time.Duration) (int, error) {
+	ahora := time.Now()
+	limite := ahora.Add(-ventana)
+
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO intentos_login (clave, fallos, ultimo_fallo)
+		VALUES (?, 1, ?)
+		ON DUPLICATE KEY UPDATE
+			fallos = IF(ultimo_fallo < ?, 1, fallos + 1),
+			ultimo_fallo = VALUES(ultimo_fallo)
+	`
+	if _, err := tx.Exec(query, clave, ahora, limite); err != nil {
+		return 0, fmt.Errorf("error al registrar intento de login: %w", err)
+	}
+
+	var fallos int
+	if err := tx.QueryRow("SELECT fallos FROM intentos_login WHERE clave = ?", clave).Scan(&fallos); err != nil {
+		return 0, fmt.Errorf("error al consultar intentos de login: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("error al confirmar transacción: %w", err)
+	}
+	return fallos, nil
+}
+
+// Bloquear fija hasta cuándo se rechazan los logins de la clave
+func (repo *MySQLIntentoLoginRepository) Bloquear(clave string, hasta time.Time) error {
+	query := "UPDATE intentos_login SET bloqueado_hasta = ? WHERE clave = ?"
+	if _, err := repo.db.Exec(query, hasta, clave); err != nil {
+		return fmt.Errorf("error al bloquear login: %w", err)
+	}
+	return nil
+}
+
+// LimpiarIntentos borra los fallos de la clave
+func (repo *MySQLIntentoLoginRepository) LimpiarIntentos(clave string) error {
+	if _, err := repo.db.Exec("DELETE FROM intentos_login WHERE clave = ?", clave); err != nil {
+		return fmt.Errorf("error al limpiar intentos de login: %w", err)
+	}
+	return nil
+}
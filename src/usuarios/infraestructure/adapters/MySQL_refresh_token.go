@@ -0,0 +1,89 @@
+package adapters
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"games-football-api/src/usuarios/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+type MySQLRefreshTokenRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLRefreshTokenRepository(db *sql.DB) *MySQLRefreshTokenRepository {
+	return &MySQLRefreshTokenRepository{
+		db: db,
+	}
+}
+
+// Guardar persiste un nuevo refresh token (solo el hash)
+func (repo *MySQLRefreshTokenRepository) Guardar(token *entities.RefreshToken) error {
+	token.ID = uuid.New().String()
+
+	query := "INSERT INTO refresh_tokens (id, usuario_id, token_hash, expira_en) VALUES (?, ?, ?, ?)"
+	_, err := repo.db.Exec(query, token.ID, token.UsuarioID, token.TokenHash, token.ExpiraEn)
+	if err != nil {
+		return fmt.Errorf("error al guardar refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// ObtenerPorHash busca un refresh token por su hash
+func (repo *MySQLRefreshTokenRepository) ObtenerPorHash(tokenHash string) (*entities.RefreshToken, error) {
+	query := "SELECT id, usuario_id, token_hash, expira_en, revocado_en FROM refresh_tokens WHERE token_hash = ?"
+
+	var token entities.RefreshToken
+	var revocadoEn sql.NullTime
+	err := repo.db.QueryRow(query, tokenHash).Scan(&token.ID, &token.UsuarioID, &token.TokenHash, &token.ExpiraEn, &revocadoEn)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("refresh token inválido")
+		}
+		return nil, fmt.Errorf("error al consultar refresh token: %w", err)
+	}
+
+	if revocadoEn.Valid {
+		token.RevocadoEn = &revocadoEn.Time
+	}
+
+	return &token, nil
+}
+
+// Revocar marca el token como revocado; si ya estaba revocado retorna error para evitar reutilizarlo
+func (repo *MySQLRefreshTokenRepository) Revocar(tokenHash string) error {
+	query := "UPDATE refresh_tokens SET revocado_en = NOW() WHERE token_hash = ? AND revocado_en IS NULL"
+	result, err := repo.db.Exec(query, tokenHash)
+	if err != nil {
+		return fmt.Errorf("error al revocar refresh token: %w", err)
+	}
+
+	afectadas, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al revocar refresh token: %w", err)
+	}
+	if afectadas == 0 {
+		return errors.New("refresh token inválido")
+	}
+
+	return nil
+}
+
+// RevocarTodosDeUsuario revoca todas las sesiones vigentes de un usuario
+func (repo *MySQLRefreshTokenRepository) RevocarTodosDeUsuario(usuarioID string) error {
+	return revocarSesionesDeUsuario(repo.db, usuarioID)
+}
+
+// revocarSesionesDeUsuario revoca los refresh tokens vigentes del usuario con el ejecutor indicado
+func revocarSesionesDeUsuario(db ejecutor, usuarioID string) error {
+	query := "UPDATE refresh_tokens SET revocado_en = NOW() WHERE usuario_id = ? AND revocado_en IS NULL"
+	_, err := db.Exec(query, usuarioID)
+	if err != nil {
+		return fmt.Errorf("error al revocar sesiones: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package adapters
+
+import (
+	"database/sql"
+	"fmt"
+	"games-football-api/src/usuarios/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+type MySQLTokenRecuperacionRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLTokenRecuperacionRepository(db *sql.DB) *MySQLTokenRecuperacionRepository {
+	return &MySQLTokenRecuperacionRepository{
+		db: db,
+	}
+}
+
+// Guardar persiste un nuevo token de recuperación (solo el hash)
+func (repo *MySQLTokenRecuperacionRepository) Guardar(token *entities.TokenRecuperacion) error {
+	token.ID = uuid.New().String()
+
+	query := "INSERT INTO password_resets (id, usuario_id, token_hash, expira_en) VALUES (?, ?, ?, ?)"
+	if _, err := repo.db.Exec(query, token.ID, token.UsuarioID, token.TokenHash, token.ExpiraEn); err != nil {
+		return fmt.Errorf("error al guardar token de recuperación: %w", err)
+	}
+	return nil
+}
+
+// ObtenerPorHash busca un token de recuperación por su hash
+func (repo *MySQLTokenRecuperacionRepository) ObtenerPorHash(tokenHash string) (*entities.TokenRecuperacion, error) {
+	query := "SELECT id, usuario_id, token_hash, expira_en, usado_en FROM password_resets WHERE token_hash = ?"
+
+	var token entities.TokenRecuperacion
+	var usadoEn sql.NullTime
+	err := repo.db.QueryRow(query, tokenHash).Scan(&token.ID, &token.UsuarioID, &token.TokenHash, &token.ExpiraEn, &usadoEn)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrTokenRecuperacionInvalido
+		}
+		return nil, fmt.Errorf("error al consultar token de recuperación: %w", err)
+	}
+
+	if usadoEn.Valid {
+		token.UsadoEn = &usadoEn.Time
+	}
+	return &token, nil
+}
+
+// InvalidarDeUsuario marca como usados los tokens pendientes del usuario
+func (repo *MySQLTokenRecuperacionRepository) InvalidarDeUsuario(usuarioID string) error {
+	query := "UPDATE password_resets SET usado_en = NOW() WHERE usuario_id = ? AND usado_en IS NULL"
+	if _, err := repo.db.Exec(query, usuarioID); err != nil {
+		return fmt.Errorf("error al invalidar tokens de recuperación: %w", err)
+	}
+	return nil
+}
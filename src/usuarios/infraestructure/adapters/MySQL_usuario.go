@@ -4,13 +4,19 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"games-football-api/src/core"
 	"games-football-api/src/usuarios/domain/entities"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// hashSinUsuario se compara cuando el username no existe para que la respuesta tarde lo mismo que con
+// una password incorrecta y no revele qué usernames están registrados
+var hashSinUsuario, _ = bcrypt.GenerateFromPassword([]byte("usuario-inexistente"), bcrypt.DefaultCost)
+
 type MySQLUsuarioRepository struct {
 	db *sql.DB
 }
@@ -23,29 +29,29 @@ func NewMySQLUsuarioRepository(db *sql.DB) *MySQLUsuarioRepository {
 
 // Login busca un usuario por username y compara el hash de la password
 func (repo *MySQLUsuarioRepository) Login(username, password string) (*entities.Usuario, error) {
-	query := "SELECT id, username, password, nombre FROM usuarios WHERE username = ?"
+	query := "SELECT " + columnasUsuario + ", password FROM usuarios WHERE username = ?"
 	row := repo.db.QueryRow(query, username)
 
-	var usuario entities.Usuario
 	var hashedPassword string
-	err := row.Scan(&usuario.ID, &usuario.Username, &hashedPassword, &usuario.Nombre)
+	usuario, err := escanearUsuario(row, &hashedPassword)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("credenciales inválidas")
+			bcrypt.CompareHashAndPassword(hashSinUsuario, []byte(password))
+			return nil, entities.ErrCredencialesInvalidas
 		}
 		return nil, fmt.Errorf("error al consultar usuario: %w", err)
 	}
 
 	// Comparar la password ingresada con el hash almacenado
 	if err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password)); err != nil {
-		return nil, errors.New("credenciales inválidas")
+		return nil, entities.ErrCredencialesInvalidas
 	}
 
-	return &usuario, nil
+	return usuario, nil
 }
 
 // Register crea un nuevo usuario con password hasheada en la base de datos
-func (repo *MySQLUsuarioRepository) Register(username, password, nombre string) (*entities.Usuario, error) {
+func (repo *MySQLUsuarioRepository) Register(username, password, nombre, email string) (*entities.Usuario, error) {
 	id := uuid.New().String()
 
 	// Hashear la password con bcrypt (cost por defecto = 10)
@@ -54,10 +60,13 @@ func (repo *MySQLUsuarioRepository) Register(username, password, nombre string)
 		return nil, fmt.Errorf("error al hashear password: %w", err)
 	}
 
-	query := "INSERT INTO usuarios (id, username, password, nombre) VALUES (?, ?, ?, ?)"
-	_, err = repo.db.Exec(query, id, username, string(hashedPassword), nombre)
+	query := "INSERT INTO usuarios (id, username, password, nombre, email) VALUES (?, ?, ?, ?, ?)"
+	_, err = repo.db.Exec(query, id, username, string(hashedPassword), nombre, nullSiVacio(email))
 	if err != nil {
 		if strings.Contains(err.Error(), "Duplicate") {
+			if strings.Contains(err.Error(), "email") {
+				return nil, entities.ErrEmailRegistrado
+			}
 			return nil, errors.New("el username ya está registrado")
 		}
 		return nil, fmt.Errorf("error al registrar usuario: %w", err)
@@ -67,5 +76,256 @@ func (repo *MySQLUsuarioRepository) Register(username, password, nombre string)
 		ID:       id,
 		Username: username,
 		Nombre:   nombre,
+		Rol:      core.RolUsuario,
+		Email:    email,
 	}, nil
 }
+
+// columnasUsuario son las columnas que lee escanearUsuario
+const columnasUsuario = "id, username, nombre, rol, baneado_hasta, motivo_baneo, email, posicion, nivel, zona_favorita, telefono"
+
+// escanearUsuario lee una fila con columnasUsuario seguida de las columnas extra indicadas
+func escanearUsuario(row interface{ Scan(...interface{}) error }, extra ...interface{}) (*entities.Usuario, error) {
+	var usuario entities.Usuario
+	var baneadoHasta sql.NullTime
+	var motivoBaneo, email, posicion, nivel, zonaFavorita, telefono sql.NullString
+
+	destinos := append([]interface{}{&usuario.ID, &usuario.Username, &usuario.Nombre, &usuario.Rol, &baneadoHasta, &motivoBaneo, &email, &posicion, &nivel, &zonaFavorita, &telefono}, extra...)
+	if err := row.Scan(destinos...); err != nil {
+		return nil, err
+	}
+
+	if baneadoHasta.Valid {
+		usuario.BaneadoHasta = &baneadoHasta.Time
+	}
+	usuario.MotivoBaneo = motivoBaneo.String
+	usuario.Email = email.String
+	usuario.Posicion = posicion.String
+	usuario.Nivel = nivel.String
+	usuario.ZonaFavorita = zonaFavorita.String
+	usuario.Telefono = telefono.String
+	return &usuario, nil
+}
+
+// ObtenerPorID busca un usuario por su id
+func (repo *MySQLUsuarioRepository) ObtenerPorID(id string) (*entities.Usuario, error) {
+	query := "SELECT " + columnasUsuario + " FROM usuarios WHERE id = ?"
+
+	usuario, err := escanearUsuario(repo.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrUsuarioNoEncontrado
+		}
+		return nil, fmt.Errorf("error al consultar usuario: %w", err)
+	}
+
+	return usuario, nil
+}
+
+// ActualizarPerfil guarda los datos editables del perfil; los opcionales vacíos quedan en NULL
+func (repo *MySQLUsuarioRepository) ActualizarPerfil(usuario *entities.Usuario) error {
+	query := "UPDATE usuarios SET nombre = ?, email = ?, posicion = ?, nivel = ?, zona_favorita = ?, telefono = ? WHERE id = ?"
+	result, err := repo.db.Exec(query, usuario.Nombre, nullSiVacio(usuario.Email), nullSiVacio(usuario.Posicion), nullSiVacio(usuario.Nivel), nullSiVacio(usuario.ZonaFavorita), nullSiVacio(usuario.Telefono), usuario.ID)
+	if err != nil {
+		// La zona favorita tiene llave foránea a zonas
+		if strings.Contains(err.Error(), "foreign key constraint") {
+			return entities.ErrZonaFavoritaInvalida
+		}
+		if strings.Contains(err.Error(), "Duplicate") {
+			return entities.ErrEmailRegistrado
+		}
+		return fmt.Errorf("error al actualizar perfil: %w", err)
+	}
+
+	if filas, _ := result.RowsAffected(); filas == 0 {
+		// Sin cambios reales MySQL reporta 0 filas; se distingue de un usuario inexistente
+		if _, err := repo.ObtenerPorID(usuario.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ObtenerPorEmail busca un usuario por su email (guardado en minúsculas)
+func (repo *MySQLUsuarioRepository) ObtenerPorEmail(email string) (*entities.Usuario, error) {
+	query := "SELECT " + columnasUsuario + " FROM usuarios WHERE email = ?"
+
+	usuario, err := escanearUsuario(repo.db.QueryRow(query, email))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrUsuarioNoEncontrado
+		}
+		return nil, fmt.Errorf("error al consultar usuario: %w", err)
+	}
+
+	return usuario, nil
+}
+
+// RestablecerPassword hashea la nueva password y, en una sola transacción, consume el token de
+// recuperación (solo si sigue pendiente, así dos peticiones simultáneas no pueden usarlo) y guarda el hash.
+// Si algo falla el token sigue disponible
+func (repo *MySQLUsuarioRepository) RestablecerPassword(id, tokenHash, nueva string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(nueva), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("error al hashear password: %w", err)
+	}
+
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var result sql.Result
+	result, err = tx.Exec("UPDATE password_resets SET usado_en = NOW() WHERE token_hash = ? AND usuario_id = ? AND usado_en IS NULL", tokenHash, id)
+	if err != nil {
+		return fmt.Errorf("error al usar token de recuperación: %w", err)
+	}
+	if filas, _ := result.RowsAffected(); filas == 0 {
+		tx.Rollback()
+		return entities.ErrTokenRecuperacionInvalido
+	}
+
+	if _, err = tx.Exec("UPDATE usuarios SET password = ? WHERE id = ?", string(hash), id); err != nil {
+		return fmt.Errorf("error al restablecer password: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error al hacer commit: %w", err)
+	}
+	return nil
+}
+
+// CambiarPassword verifica la password actual y guarda el hash de la nueva
+func (repo *MySQLUsuarioRepository) CambiarPassword(id, actual, nueva string) error {
+	var hashedPassword string
+	err := repo.db.QueryRow("SELECT password FROM usuarios WHERE id = ?", id).Scan(&hashedPassword)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entities.ErrUsuarioNoEncontrado
+		}
+		return fmt.Errorf("error al consultar usuario: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(actual)); err != nil {
+		return entities.ErrPasswordActual
+	}
+
+	nuevoHash, err := bcrypt.GenerateFromPassword([]byte(nueva), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("error al hashear password: %w", err)
+	}
+
+	if _, err := repo.db.Exec("UPDATE usuarios SET password = ? WHERE id = ?", string(nuevoHash), id); err != nil {
+		return fmt.Errorf("error al cambiar password: %w", err)
+	}
+	return nil
+}
+
+// ListarUsuarios retorna una página de usuarios ordenada por username y el total que cumple los filtros
+func (repo *MySQLUsuarioRepository) ListarUsuarios(filtro entities.FiltroUsuarios, pagina, limite int) ([]*entities.Usuario, int, error) {
+	condiciones := []string{"1 = 1"}
+	args := []interface{}{}
+	if filtro.Busqueda != "" {
+		patron := "%" + filtro.Busqueda + "%"
+		condiciones = append(condiciones, "(username LIKE ? OR nombre LIKE ? OR email LIKE ?)")
+		args = append(args, patron, patron, patron)
+	}
+	if filtro.Rol != "" {
+		condiciones = append(condiciones, "rol = ?")
+		args = append(args, filtro.Rol)
+	}
+	if filtro.SoloBaneados {
+		// baneado_hasta se guarda en la zona de la API, no en la de la sesión de MySQL
+		condiciones = append(condiciones, "baneado_hasta > ?")
+		args = append(args, time.Now())
+	}
+	where := " WHERE " + strings.Join(condiciones, " AND ")
+
+	var total int
+	if err := repo.db.QueryRow("SELECT COUNT(*) FROM usuarios"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("error al contar usuarios: %w", err)
+	}
+
+	query := "SELECT " + columnasUsuario + " FROM usuarios" + where + " ORDER BY username LIMIT ? OFFSET ?"
+	rows, err := repo.db.Query(query, append(args, limite, (pagina-1)*limite)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error al listar usuarios: %w", err)
+	}
+	defer rows.Close()
+
+	usuarios := []*entities.Usuario{}
+	for rows.Next() {
+		usuario, err := escanearUsuario(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error al leer usuario: %w", err)
+		}
+		usuarios = append(usuarios, usuario)
+	}
+	return usuarios, total, rows.Err()
+}
+
+// Banear suspende la cuenta hasta la fecha indicada (nil = permanente) y cierra sus sesiones
+func (repo *MySQLUsuarioRepository) Banear(id string, hasta *time.Time, motivo string, evento *entities.EventoAuditoria) error {
+	fin := entities.BaneoPermanente
+	if hasta != nil {
+		fin = *hasta
+	}
+	return repo.accionAuditada(id, evento, true, "UPDATE usuarios SET baneado_hasta = ?, motivo_baneo = ? WHERE id = ?", fin, motivo, id)
+}
+
+// QuitarBaneo levanta la suspensión de la cuenta
+func (repo *MySQLUsuarioRepository) QuitarBaneo(id string, evento *entities.EventoAuditoria) error {
+	return repo.accionAuditada(id, evento, false, "UPDATE usuarios SET baneado_hasta = NULL, motivo_baneo = NULL WHERE id = ?", id)
+}
+
+// CambiarRol guarda el nuevo rol del usuario
+func (repo *MySQLUsuarioRepository) CambiarRol(id, rol string, evento *entities.EventoAuditoria) error {
+	return repo.accionAuditada(id, evento, false, "UPDATE usuarios SET rol = ? WHERE id = ?", rol, id)
+}
+
+// accionAuditada ejecuta el UPDATE de una acción administrativa sobre un usuario, revoca sus refresh
+// tokens si se pide y guarda el evento de auditoría en la misma transacción: si algo falla no queda
+// ni el cambio ni el registro
+func (repo *MySQLUsuarioRepository) accionAuditada(id string, evento *entities.EventoAuditoria, revocarSesiones bool, query string, args ...interface{}) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("error al actualizar usuario: %w", err)
+	}
+
+	// Un UPDATE sin cambios afecta 0 filas; se distingue de un usuario inexistente
+	if filas, _ := result.RowsAffected(); filas == 0 {
+		var existe int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM usuarios WHERE id = ?", id).Scan(&existe); err != nil {
+			return fmt.Errorf("error al consultar usuario: %w", err)
+		}
+		if existe == 0 {
+			return entities.ErrUsuarioNoEncontrado
+		}
+	}
+
+	if revocarSesiones {
+		if err := revocarSesionesDeUsuario(tx, id); err != nil {
+			return err
+		}
+	}
+	if err := insertarEventoAuditoria(tx, evento); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar transacción: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/application"
+	"games-football-api/src/usuarios/domain/entities"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminUsuariosController struct {
+	listarUsuariosUseCase *application.ListarUsuariosUseCase
+	banearUsuarioUseCase  *application.BanearUsuarioUseCase
+	quitarBaneoUseCase    *application.QuitarBaneoUseCase
+	cambiarRolUseCase     *application.CambiarRolUseCase
+}
+
+func NewAdminUsuariosController(listarUsuariosUseCase *application.ListarUsuariosUseCase, banearUsuarioUseCase *application.BanearUsuarioUseCase, quitarBaneoUseCase *application.QuitarBaneoUseCase, cambiarRolUseCase *application.CambiarRolUseCase) *AdminUsuariosController {
+	return &AdminUsuariosController{
+		listarUsuariosUseCase: listarUsuariosUseCase,
+		banearUsuarioUseCase:  banearUsuarioUseCase,
+		quitarBaneoUseCase:    quitarBaneoUseCase,
+		cambiarRolUseCase:     cambiarRolUseCase,
+	}
+}
+
+// BanearUsuarioRequest representa el cuerpo de la petición de baneo; sin duracion_horas el baneo es permanente
+type BanearUsuarioRequest struct {
+	Motivo        string `json:"motivo" binding:"required"`
+	DuracionHoras int    `json:"duracion_horas" binding:"min=0"`
+}
+
+// MotivoRequest representa el cuerpo de las acciones administrativas que solo piden el motivo
+type MotivoRequest struct {
+	Motivo string `json:"motivo" binding:"required"`
+}
+
+// CambiarRolRequest representa el cuerpo de la petición de cambio de rol
+type CambiarRolRequest struct {
+	Rol    string `json:"rol" binding:"required"`
+	Motivo string `json:"motivo" binding:"required"`
+}
+
+// HandleListar maneja la petición GET que lista los usuarios.
+// Query params: q (busca en username, nombre y email), rol, baneados=true, pagina, limite (máx 100)
+func (ac *AdminUsuariosController) HandleListar(c *gin.Context) {
+	pagina, err := enteroDesdeQuery(c, "pagina")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+	limite, err := enteroDesdeQuery(c, "limite")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	filtro := entities.FiltroUsuarios{
+		Busqueda:     c.Query("q"),
+		Rol:          c.Query("rol"),
+		SoloBaneados: c.Query("baneados") == "true",
+	}
+	usuarios, total, err := ac.listarUsuariosUseCase.Execute(filtro, pagina, limite)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"usuarios": usuarios,
+		"total":    total,
+	})
+}
+
+// HandleBanear maneja la petición POST que suspende la cuenta de un usuario
+func (ac *AdminUsuariosController) HandleBanear(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req BanearUsuarioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: motivo; duracion_horas debe ser 0 o mayor",
+		})
+		return
+	}
+
+	duracion := time.Duration(req.DuracionHoras) * time.Hour
+	usuario, err := ac.banearUsuarioUseCase.Execute(claims.UsuarioID, c.Param("id"), duracion, req.Motivo, c.ClientIP())
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Usuario baneado; se cerraron sus sesiones y sus conexiones en tiempo real",
+		"usuario": usuario,
+	})
+}
+
+// HandleQuitarBaneo maneja la petición DELETE que levanta la suspensión de un usuario
+func (ac *AdminUsuariosController) HandleQuitarBaneo(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req MotivoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: motivo",
+		})
+		return
+	}
+
+	usuario, err := ac.quitarBaneoUseCase.Execute(claims.UsuarioID, c.Param("id"), req.Motivo, c.ClientIP())
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Baneo levantado",
+		"usuario": usuario,
+	})
+}
+
+// HandleCambiarRol maneja la petición PUT que asigna un nuevo rol a un usuario
+func (ac *AdminUsuariosController) HandleCambiarRol(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req CambiarRolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: rol, motivo",
+		})
+		return
+	}
+
+	usuario, err := ac.cambiarRolUseCase.Execute(claims.UsuarioID, c.Param("id"), req.Rol, req.Motivo, c.ClientIP())
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Rol actualizado; se aplica en el siguiente refresh del usuario",
+		"usuario": usuario,
+	})
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"games-football-api/src/usuarios/application"
+	"games-football-api/src/usuarios/domain/entities"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditoriaController struct {
+	obtenerAuditoriaUseCase *application.ObtenerAuditoriaUseCase
+}
+
+func NewAuditoriaController(obtenerAuditoriaUseCase *application.ObtenerAuditoriaUseCase) *AuditoriaController {
+	return &AuditoriaController{
+		obtenerAuditoriaUseCase: obtenerAuditoriaUseCase,
+	}
+}
+
+// HandleListar maneja la petición GET que lista los eventos de auditoría.
+// Query params: evento, actor_id, objetivo, pagina, limite (máx 100)
+func (ac *AuditoriaController) HandleListar(c *gin.Context) {
+	pagina, err := enteroDesdeQuery(c, "pagina")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+	limite, err := enteroDesdeQuery(c, "limite")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	filtro := entities.FiltroAuditoria{
+		Evento:   c.Query("evento"),
+		ActorID:  c.Query("actor_id"),
+		Objetivo: c.Query("objetivo"),
+	}
+	eventos, total, err := ac.obtenerAuditoriaUseCase.Execute(filtro, pagina, limite)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"eventos": eventos,
+		"total":   total,
+	})
+}
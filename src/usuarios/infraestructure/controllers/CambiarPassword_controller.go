@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/application"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CambiarPasswordController struct {
+	cambiarPasswordUseCase *application.CambiarPasswordUseCase
+}
+
+func NewCambiarPasswordController(cambiarPasswordUseCase *application.CambiarPasswordUseCase) *CambiarPasswordController {
+	return &CambiarPasswordController{
+		cambiarPasswordUseCase: cambiarPasswordUseCase,
+	}
+}
+
+// CambiarPasswordRequest representa el cuerpo de la petición de cambio de password
+type CambiarPasswordRequest struct {
+	PasswordActual string `json:"password_actual" binding:"required"`
+	PasswordNueva  string `json:"password_nueva" binding:"required"`
+}
+
+// HandleCambiarPassword maneja la petición PUT que cambia la password del usuario autenticado
+func (cc *CambiarPasswordController) HandleCambiarPassword(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req CambiarPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: password_actual, password_nueva",
+		})
+		return
+	}
+
+	tokens, err := cc.cambiarPasswordUseCase.Execute(claims.UsuarioID, req.PasswordActual, req.PasswordNueva)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.Header("Authorization", tokens.TokenType+" "+tokens.AccessToken)
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Password actualizada; se cerraron las demás sesiones",
+		"tokens":  tokens,
+	})
+}
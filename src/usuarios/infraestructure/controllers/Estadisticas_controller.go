@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"errors"
+	"games-football-api/src/usuarios/application"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EstadisticasController struct {
+	obtenerEstadisticasUseCase *application.ObtenerEstadisticasUseCase
+	obtenerHistorialUseCase    *application.ObtenerHistorialUseCase
+}
+
+func NewEstadisticasController(obtenerEstadisticasUseCase *application.ObtenerEstadisticasUseCase, obtenerHistorialUseCase *application.ObtenerHistorialUseCase) *EstadisticasController {
+	return &EstadisticasController{
+		obtenerEstadisticasUseCase: obtenerEstadisticasUseCase,
+		obtenerHistorialUseCase:    obtenerHistorialUseCase,
+	}
+}
+
+// HandleEstadisticas maneja la petición GET que retorna las estadísticas de un usuario
+func (ec *EstadisticasController) HandleEstadisticas(c *gin.Context) {
+	estadisticas, err := ec.obtenerEstadisticasUseCase.Execute(c.Param("id"))
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":       "success",
+		"estadisticas": estadisticas,
+	})
+}
+
+// HandleHistorial maneja la petición GET que lista las retas pasadas de un usuario.
+// Query params: pagina (desde 1), limite (máx 50)
+func (ec *EstadisticasController) HandleHistorial(c *gin.Context) {
+	pagina, err := enteroDesdeQuery(c, "pagina")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+	limite, err := enteroDesdeQuery(c, "limite")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	historial, err := ec.obtenerHistorialUseCase.Execute(c.Param("id"), pagina, limite)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"retas":   historial.Retas,
+		"pagina":  historial.Pagina,
+		"hay_mas": historial.HayMas,
+	})
+}
+
+// enteroDesdeQuery lee un query param entero positivo opcional (0 si no viene)
+func enteroDesdeQuery(c *gin.Context, nombre string) (int, error) {
+	valor := c.Query(nombre)
+	if valor == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(valor)
+	if err != nil || n <= 0 {
+		return 0, errors.New(nombre + " debe ser un número mayor a 0")
+	}
+	return n, nil
+}
@@ -1,8 +1,11 @@
 package controllers
 
 import (
+	"errors"
 	"games-football-api/src/usuarios/application"
+	"games-football-api/src/usuarios/domain/entities"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -34,7 +37,20 @@ func (lc *LoginController) HandleLogin(c *gin.Context) {
 		return
 	}
 
-	usuario, err := lc.loginUseCase.Execute(req.Username, req.Password)
+	usuario, tokens, err := lc.loginUseCase.Execute(req.Username, req.Password, c.ClientIP())
+	var bloqueo *entities.LoginBloqueadoError
+	if errors.As(err, &bloqueo) {
+		c.Header("Retry-After", strconv.Itoa(bloqueo.Segundos()))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"status":        "error",
+			"mensaje":       bloqueo.Error(),
+			"reintentar_en": bloqueo.Segundos(),
+		})
+		return
+	}
+	if responderBaneo(c, err) {
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"status":  "error",
@@ -43,9 +59,11 @@ func (lc *LoginController) HandleLogin(c *gin.Context) {
 		return
 	}
 
+	c.Header("Authorization", tokens.TokenType+" "+tokens.AccessToken)
 	c.JSON(http.StatusOK, gin.H{
 		"status":  "success",
 		"mensaje": "Login exitoso",
 		"usuario": usuario,
+		"tokens":  tokens,
 	})
 }
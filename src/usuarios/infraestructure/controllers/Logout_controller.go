@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/application"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LogoutController struct {
+	logoutUseCase *application.LogoutUseCase
+}
+
+func NewLogoutController(logoutUseCase *application.LogoutUseCase) *LogoutController {
+	return &LogoutController{
+		logoutUseCase: logoutUseCase,
+	}
+}
+
+// LogoutRequest representa el cuerpo de la petición de logout
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// HandleLogout maneja la petición POST que cierra la sesión (requiere AuthMiddleware)
+func (lc *LogoutController) HandleLogout(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: refresh_token",
+		})
+		return
+	}
+
+	if err := lc.logoutUseCase.Execute(claims.UsuarioID, req.RefreshToken); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Sesión cerrada",
+	})
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/application"
+	"games-football-api/src/usuarios/domain/entities"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PerfilController struct {
+	obtenerPerfilUseCase    *application.ObtenerPerfilUseCase
+	actualizarPerfilUseCase *application.ActualizarPerfilUseCase
+}
+
+func NewPerfilController(obtenerPerfilUseCase *application.ObtenerPerfilUseCase, actualizarPerfilUseCase *application.ActualizarPerfilUseCase) *PerfilController {
+	return &PerfilController{
+		obtenerPerfilUseCase:    obtenerPerfilUseCase,
+		actualizarPerfilUseCase: actualizarPerfilUseCase,
+	}
+}
+
+// ActualizarPerfilRequest representa el cuerpo de PUT /me; los campos que no se envían no cambian
+type ActualizarPerfilRequest struct {
+	Nombre       *string `json:"nombre"`
+	Email        *string `json:"email"`
+	Posicion     *string `json:"posicion"`
+	Nivel        *string `json:"nivel"`
+	ZonaFavorita *string `json:"zona_favorita"`
+	Telefono     *string `json:"telefono"`
+}
+
+// HandleObtenerMio maneja la petición GET que retorna el perfil completo del usuario autenticado
+func (pc *PerfilController) HandleObtenerMio(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	usuario, err := pc.obtenerPerfilUseCase.Execute(claims.UsuarioID)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"usuario": usuario,
+	})
+}
+
+// HandleActualizarMio maneja la petición PUT que edita el perfil del usuario autenticado
+func (pc *PerfilController) HandleActualizarMio(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req ActualizarPerfilRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Formato de perfil inválido",
+		})
+		return
+	}
+
+	usuario, err := pc.actualizarPerfilUseCase.Execute(claims.UsuarioID, entities.CambiosPerfil{
+		Nombre:       req.Nombre,
+		Email:        req.Email,
+		Posicion:     req.Posicion,
+		Nivel:        req.Nivel,
+		ZonaFavorita: req.ZonaFavorita,
+		Telefono:     req.Telefono,
+	})
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Perfil actualizado",
+		"usuario": usuario,
+	})
+}
+
+// HandleObtenerPublico maneja la petición GET que retorna el perfil público de otro usuario
+func (pc *PerfilController) HandleObtenerPublico(c *gin.Context) {
+	usuario, err := pc.obtenerPerfilUseCase.Execute(c.Param("id"))
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"usuario": usuario.PerfilPublico(),
+	})
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"games-football-api/src/usuarios/application"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecuperarPasswordController struct {
+	solicitarUseCase   *application.SolicitarRecuperacionUseCase
+	restablecerUseCase *application.RestablecerPasswordUseCase
+}
+
+func NewRecuperarPasswordController(solicitarUseCase *application.SolicitarRecuperacionUseCase, restablecerUseCase *application.RestablecerPasswordUseCase) *RecuperarPasswordController {
+	return &RecuperarPasswordController{
+		solicitarUseCase:   solicitarUseCase,
+		restablecerUseCase: restablecerUseCase,
+	}
+}
+
+// OlvidePasswordRequest representa el cuerpo de la petición de recuperación
+type OlvidePasswordRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+// RestablecerPasswordRequest representa el cuerpo de la petición que fija la nueva password
+type RestablecerPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// HandleOlvide maneja la petición POST que envía el enlace de recuperación.
+// Responde igual exista o no el email
+func (rc *RecuperarPasswordController) HandleOlvide(c *gin.Context) {
+	var req OlvidePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: email",
+		})
+		return
+	}
+
+	if err := rc.solicitarUseCase.Execute(req.Email); err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Si el email está registrado, recibirás un enlace para restablecer tu password",
+	})
+}
+
+// HandleRestablecer maneja la petición POST que cambia la password con el token recibido por correo
+func (rc *RecuperarPasswordController) HandleRestablecer(c *gin.Context) {
+	var req RestablecerPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: token, password",
+		})
+		return
+	}
+
+	if err := rc.restablecerUseCase.Execute(req.Token, req.Password); err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Password restablecida; inicia sesión con la nueva password",
+	})
+}
@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"games-football-api/src/usuarios/application"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RefreshController struct {
+	refreshUseCase *application.RefreshUseCase
+}
+
+func NewRefreshController(refreshUseCase *application.RefreshUseCase) *RefreshController {
+	return &RefreshController{
+		refreshUseCase: refreshUseCase,
+	}
+}
+
+// RefreshRequest representa el cuerpo de la petición de refresh
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// HandleRefresh maneja la petición POST que renueva el par de tokens
+func (rc *RefreshController) HandleRefresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: refresh_token",
+		})
+		return
+	}
+
+	usuario, tokens, err := rc.refreshUseCase.Execute(req.RefreshToken)
+	if responderBaneo(c, err) {
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.Header("Authorization", tokens.TokenType+" "+tokens.AccessToken)
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Sesión renovada",
+		"usuario": usuario,
+		"tokens":  tokens,
+	})
+}
@@ -22,6 +22,7 @@ type RegisterRequest struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
 	Nombre   string `json:"nombre" binding:"required"`
+	Email    string `json:"email"`
 }
 
 // HandleRegister maneja la petición POST de registro
@@ -35,7 +36,7 @@ func (rc *RegisterController) HandleRegister(c *gin.Context) {
 		return
 	}
 
-	usuario, err := rc.registerUseCase.Execute(req.Username, req.Password, req.Nombre)
+	usuario, err := rc.registerUseCase.Execute(req.Username, req.Password, req.Nombre, req.Email)
 	if err != nil {
 		c.JSON(http.StatusConflict, gin.H{
 			"status":  "error",
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/domain/entities"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// statusPorError traduce los errores de dominio del perfil y de administración a códigos HTTP
+func statusPorError(err error) int {
+	switch {
+	case errors.Is(err, entities.ErrUsuarioNoEncontrado):
+		return http.StatusNotFound
+	case errors.Is(err, entities.ErrTokenRecuperacionInvalido):
+		return http.StatusGone
+	case errors.Is(err, entities.ErrPasswordActual), errors.Is(err, entities.ErrUsuarioBaneado), errors.Is(err, entities.ErrAccionSobreSiMismo),
+		errors.Is(err, entities.ErrRolInsuficiente), errors.Is(err, entities.ErrSoloAdmin), errors.Is(err, entities.ErrRolMayorAlTuyo):
+		return http.StatusForbidden
+	case errors.Is(err, entities.ErrEmailRegistrado):
+		return http.StatusConflict
+	case errors.Is(err, entities.ErrZonaFavoritaInvalida):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, entities.ErrNombreVacio), errors.Is(err, entities.ErrEmailInvalido), errors.Is(err, entities.ErrPosicionInvalida), errors.Is(err, entities.ErrNivelInvalido),
+		errors.Is(err, entities.ErrTelefonoInvalido), errors.Is(err, entities.ErrPasswordRepetida), errors.Is(err, entities.ErrPasswordLarga), errors.Is(err, core.ErrMotivoRequerido),
+		errors.Is(err, core.ErrMotivoLargo),
+		errors.Is(err, entities.ErrRolInvalido):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// responderBaneo responde 403 con los datos de la suspensión si err es un UsuarioBaneadoError
+func responderBaneo(c *gin.Context, err error) bool {
+	var baneo *entities.UsuarioBaneadoError
+	if !errors.As(err, &baneo) {
+		return false
+	}
+
+	respuesta := gin.H{
+		"status":  "error",
+		"mensaje": baneo.Error(),
+		"motivo":  baneo.Motivo,
+	}
+	if !baneo.Permanente() {
+		respuesta["baneado_hasta"] = baneo.Hasta
+	}
+	c.JSON(http.StatusForbidden, respuesta)
+	return true
+}
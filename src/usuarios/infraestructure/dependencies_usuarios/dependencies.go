@@ -1,36 +1,121 @@
 package dependenciesusuarios
 
 import (
+	"errors"
 	"games-football-api/src/core"
 	"games-football-api/src/usuarios/application"
 	"games-football-api/src/usuarios/infraestructure/adapters"
 	"games-football-api/src/usuarios/infraestructure/controllers"
 	"games-football-api/src/usuarios/infraestructure/routers"
 	"log"
+	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-func InitUsuarios(r *gin.Engine) {
+func InitUsuarios(r *gin.Engine, sesiones core.DesconectadorSesiones) {
 	// Inicializar la conexión a la base de datos
 	db, err := core.NewMySQL()
 	if err != nil {
 		log.Fatalf("Error al conectar a la base de datos: %v", err)
 	}
 
-	// Crear el repositorio
+	// Inicializar el firmador de tokens
+	jwtManager, err := core.NewJWTManager()
+	if err != nil {
+		log.Fatalf("Error al configurar JWT: %v", err)
+	}
+
+	// Crear los repositorios
 	usuarioRepo := adapters.NewMySQLUsuarioRepository(db)
+	tokenRepo := adapters.NewMySQLRefreshTokenRepository(db)
+	intentoRepo := adapters.NewMySQLIntentoLoginRepository(db)
+	auditoriaRepo := adapters.NewMySQLAuditoriaRepository(db)
+	recuperacionRepo := adapters.NewMySQLTokenRecuperacionRepository(db)
+	estadisticasRepo := adapters.NewMySQLEstadisticasRepository(db)
+
+	// Mailer para los correos de recuperación (log, archivo o smtp)
+	mailer, err := core.NewMailerDesdeEnv()
+	if err != nil {
+		log.Fatalf("Error al configurar el mailer: %v", err)
+	}
+	recuperacionTTL, err := core.DuracionDesdeEnv("PASSWORD_RESET_TTL", 30*time.Minute)
+	if err != nil {
+		log.Fatalf("Error al configurar la recuperación de password: %v", err)
+	}
+	urlRestablecer := os.Getenv("PASSWORD_RESET_URL")
+	if urlRestablecer == "" {
+		urlRestablecer = "http://localhost:3000/restablecer-password"
+	}
+
+	// Política de bloqueo por logins fallidos
+	politica, err := politicaBloqueoDesdeEnv()
+	if err != nil {
+		log.Fatalf("Error al configurar el bloqueo de login: %v", err)
+	}
 
 	// Crear los casos de uso
-	loginUseCase := application.NewLoginUseCase(usuarioRepo)
+	loginUseCase := application.NewLoginUseCase(usuarioRepo, tokenRepo, intentoRepo, auditoriaRepo, jwtManager, politica)
 	registerUseCase := application.NewRegisterUseCase(usuarioRepo)
+	refreshUseCase := application.NewRefreshUseCase(usuarioRepo, tokenRepo, jwtManager)
+	logoutUseCase := application.NewLogoutUseCase(tokenRepo)
+	obtenerPerfilUseCase := application.NewObtenerPerfilUseCase(usuarioRepo)
+	actualizarPerfilUseCase := application.NewActualizarPerfilUseCase(usuarioRepo)
+	cambiarPasswordUseCase := application.NewCambiarPasswordUseCase(usuarioRepo, tokenRepo, jwtManager)
+	solicitarRecuperacionUseCase := application.NewSolicitarRecuperacionUseCase(usuarioRepo, recuperacionRepo, mailer, recuperacionTTL, urlRestablecer)
+	restablecerPasswordUseCase := application.NewRestablecerPasswordUseCase(usuarioRepo, recuperacionRepo, tokenRepo, intentoRepo)
+	obtenerEstadisticasUseCase := application.NewObtenerEstadisticasUseCase(usuarioRepo, estadisticasRepo)
+	obtenerHistorialUseCase := application.NewObtenerHistorialUseCase(usuarioRepo, estadisticasRepo)
+	listarUsuariosUseCase := application.NewListarUsuariosUseCase(usuarioRepo)
+	banearUsuarioUseCase := application.NewBanearUsuarioUseCase(usuarioRepo, sesiones)
+	quitarBaneoUseCase := application.NewQuitarBaneoUseCase(usuarioRepo)
+	cambiarRolUseCase := application.NewCambiarRolUseCase(usuarioRepo)
+	obtenerAuditoriaUseCase := application.NewObtenerAuditoriaUseCase(auditoriaRepo)
 
 	// Crear los controladores
 	loginController := controllers.NewLoginController(loginUseCase)
 	registerController := controllers.NewRegisterController(registerUseCase)
+	refreshController := controllers.NewRefreshController(refreshUseCase)
+	logoutController := controllers.NewLogoutController(logoutUseCase)
+	perfilController := controllers.NewPerfilController(obtenerPerfilUseCase, actualizarPerfilUseCase)
+	cambiarPasswordController := controllers.NewCambiarPasswordController(cambiarPasswordUseCase)
+	recuperarPasswordController := controllers.NewRecuperarPasswordController(solicitarRecuperacionUseCase, restablecerPasswordUseCase)
+	estadisticasController := controllers.NewEstadisticasController(obtenerEstadisticasUseCase, obtenerHistorialUseCase)
+	adminUsuariosController := controllers.NewAdminUsuariosController(listarUsuariosUseCase, banearUsuarioUseCase, quitarBaneoUseCase, cambiarRolUseCase)
+	auditoriaController := controllers.NewAuditoriaController(obtenerAuditoriaUseCase)
 
 	// Registrar las rutas
-	routers.UsuariosRouter(r, loginController, registerController)
+	authMiddleware := core.AuthMiddleware(jwtManager)
+	routers.UsuariosRouter(r, authMiddleware, loginController, registerController, refreshController, logoutController, perfilController, cambiarPasswordController, recuperarPasswordController, estadisticasController)
+	routers.AdminUsuariosRouter(r, authMiddleware, core.NewCuentasMySQL(db), adminUsuariosController, auditoriaController)
 
 	log.Println("Módulo de Usuarios inicializado correctamente")
 }
+
+// politicaBloqueoDesdeEnv lee LOGIN_FALLOS_USUARIO, LOGIN_FALLOS_IP, LOGIN_VENTANA_FALLOS,
+// LOGIN_BLOQUEO_BASE y LOGIN_BLOQUEO_MAXIMO; las que no estén usan la política por defecto
+func politicaBloqueoDesdeEnv() (application.PoliticaBloqueoLogin, error) {
+	politica := application.PoliticaBloqueoLoginPorDefecto
+
+	var err error
+	if politica.FallosUsuario, err = core.EnteroDesdeEnv("LOGIN_FALLOS_USUARIO", politica.FallosUsuario); err != nil {
+		return politica, err
+	}
+	if politica.FallosIP, err = core.EnteroDesdeEnv("LOGIN_FALLOS_IP", politica.FallosIP); err != nil {
+		return politica, err
+	}
+	if politica.Ventana, err = core.DuracionDesdeEnv("LOGIN_VENTANA_FALLOS", politica.Ventana); err != nil {
+		return politica, err
+	}
+	if politica.BloqueoBase, err = core.DuracionDesdeEnv("LOGIN_BLOQUEO_BASE", politica.BloqueoBase); err != nil {
+		return politica, err
+	}
+	if politica.BloqueoMaximo, err = core.DuracionDesdeEnv("LOGIN_BLOQUEO_MAXIMO", politica.BloqueoMaximo); err != nil {
+		return politica, err
+	}
+	if politica.BloqueoBase > politica.BloqueoMaximo {
+		return politica, errors.New("LOGIN_BLOQUEO_BASE no puede ser mayor a LOGIN_BLOQUEO_MAXIMO")
+	}
+	return politica, nil
+}
@@ -0,0 +1,24 @@
+package routers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/usuarios/infraestructure/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminUsuariosRouter registra las rutas de administración de usuarios y auditoría. Los moderadores
+// pueden listar y banear usuarios; cambiar roles y ver la auditoría es solo para admins
+func AdminUsuariosRouter(r *gin.Engine, authMiddleware gin.HandlerFunc, cuentas core.LectorCuentas, adminUsuariosController *controllers.AdminUsuariosController, auditoriaController *controllers.AuditoriaController) {
+	adminGroup := r.Group("/api/admin", authMiddleware)
+	{
+		moderadores := core.RequiereRol(cuentas, core.RolModerador)
+		admins := core.RequiereRol(cuentas, core.RolAdmin)
+
+		adminGroup.GET("/usuarios", moderadores, adminUsuariosController.HandleListar)
+		adminGroup.POST("/usuarios/:id/ban", moderadores, adminUsuariosController.HandleBanear)
+		adminGroup.DELETE("/usuarios/:id/ban", moderadores, adminUsuariosController.HandleQuitarBaneo)
+		adminGroup.PUT("/usuarios/:id/rol", admins, adminUsuariosController.HandleCambiarRol)
+		adminGroup.GET("/auditoria", admins, auditoriaController.HandleListar)
+	}
+}
@@ -6,10 +6,24 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func UsuariosRouter(r *gin.Engine, loginController *controllers.LoginController, registerController *controllers.RegisterController) {
+func UsuariosRouter(r *gin.Engine, authMiddleware gin.HandlerFunc, loginController *controllers.LoginController, registerController *controllers.RegisterController, refreshController *controllers.RefreshController, logoutController *controllers.LogoutController, perfilController *controllers.PerfilController, cambiarPasswordController *controllers.CambiarPasswordController, recuperarPasswordController *controllers.RecuperarPasswordController, estadisticasController *controllers.EstadisticasController) {
 	usuariosGroup := r.Group("/api/usuarios")
 	{
 		usuariosGroup.POST("/login", loginController.HandleLogin)
 		usuariosGroup.POST("/register", registerController.HandleRegister)
+		usuariosGroup.POST("/refresh", refreshController.HandleRefresh)
+		usuariosGroup.POST("/logout", authMiddleware, logoutController.HandleLogout)
+
+		// Recuperación de password por email
+		usuariosGroup.POST("/password/forgot", recuperarPasswordController.HandleOlvide)
+		usuariosGroup.POST("/password/reset", recuperarPasswordController.HandleRestablecer)
+
+		// Perfil del usuario autenticado y perfiles públicos
+		usuariosGroup.GET("/me", authMiddleware, perfilController.HandleObtenerMio)
+		usuariosGroup.PUT("/me", authMiddleware, perfilController.HandleActualizarMio)
+		usuariosGroup.PUT("/me/password", authMiddleware, cambiarPasswordController.HandleCambiarPassword)
+		usuariosGroup.GET("/:id", authMiddleware, perfilController.HandleObtenerPublico)
+		usuariosGroup.GET("/:id/estadisticas", authMiddleware, estadisticasController.HandleEstadisticas)
+		usuariosGroup.GET("/:id/historial", authMiddleware, estadisticasController.HandleHistorial)
 	}
 }
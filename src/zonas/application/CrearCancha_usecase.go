@@ -0,0 +1,38 @@
+package application
+
+import (
+	"games-football-api/src/zonas/domain/entities"
+	"games-football-api/src/zonas/domain/repositories"
+)
+
+type CrearCanchaUseCase struct {
+	zonaRepo repositories.IZonaRepository
+}
+
+func NewCrearCanchaUseCase(zonaRepo repositories.IZonaRepository) *CrearCanchaUseCase {
+	return &CrearCanchaUseCase{
+		zonaRepo: zonaRepo,
+	}
+}
+
+// Execute crea una cancha en la zona y registra en la auditoría quién la creó
+func (uc *CrearCanchaUseCase) Execute(actorID, zonaID string, datos entities.DatosCancha) (*entities.Cancha, error) {
+	zona, err := uc.zonaRepo.ObtenerPorID(zonaID)
+	if err != nil {
+		return nil, err
+	}
+
+	cancha, err := entities.NewCancha(zona, datos)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.zonaRepo.CrearCancha(cancha); err != nil {
+		return nil, err
+	}
+	if err := uc.zonaRepo.RegistrarAuditoria(entities.EventoCrearCancha, actorID, cancha.ID, zona.ID+": "+cancha.Nombre); err != nil {
+		return nil, err
+	}
+
+	return cancha, nil
+}
@@ -0,0 +1,33 @@
+package application
+
+import (
+	"games-football-api/src/zonas/domain/entities"
+	"games-football-api/src/zonas/domain/repositories"
+)
+
+type CrearZonaUseCase struct {
+	zonaRepo repositories.IZonaRepository
+}
+
+func NewCrearZonaUseCase(zonaRepo repositories.IZonaRepository) *CrearZonaUseCase {
+	return &CrearZonaUseCase{
+		zonaRepo: zonaRepo,
+	}
+}
+
+// Execute crea una zona y registra en la auditoría quién la creó
+func (uc *CrearZonaUseCase) Execute(actorID, id, nombre string, latitud, longitud, radioKm float64) (*entities.Zona, error) {
+	zona, err := entities.NewZona(id, nombre, latitud, longitud, radioKm)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.zonaRepo.Crear(zona); err != nil {
+		return nil, err
+	}
+	if err := uc.zonaRepo.RegistrarAuditoria(entities.EventoCrearZona, actorID, zona.ID, zona.Nombre); err != nil {
+		return nil, err
+	}
+
+	return zona, nil
+}
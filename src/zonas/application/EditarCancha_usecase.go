@@ -0,0 +1,42 @@
+package application
+
+import (
+	"games-football-api/src/zonas/domain/entities"
+	"games-football-api/src/zonas/domain/repositories"
+)
+
+type EditarCanchaUseCase struct {
+	zonaRepo repositories.IZonaRepository
+}
+
+func NewEditarCanchaUseCase(zonaRepo repositories.IZonaRepository) *EditarCanchaUseCase {
+	return &EditarCanchaUseCase{
+		zonaRepo: zonaRepo,
+	}
+}
+
+// Execute reemplaza los datos de la cancha; la zona no cambia. Las retas ya apartadas conservan su
+// horario aunque el nuevo horario o capacidad ya no las admita
+func (uc *EditarCanchaUseCase) Execute(actorID, id string, datos entities.DatosCancha) (*entities.Cancha, error) {
+	cancha, err := uc.zonaRepo.ObtenerCancha(id)
+	if err != nil {
+		return nil, err
+	}
+	zona, err := uc.zonaRepo.ObtenerPorID(cancha.ZonaID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cancha.Actualizar(zona, datos); err != nil {
+		return nil, err
+	}
+
+	if err := uc.zonaRepo.ActualizarCancha(cancha); err != nil {
+		return nil, err
+	}
+	if err := uc.zonaRepo.RegistrarAuditoria(entities.EventoEditarCancha, actorID, cancha.ID, cancha.Nombre); err != nil {
+		return nil, err
+	}
+
+	return cancha, nil
+}
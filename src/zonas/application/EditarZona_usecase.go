@@ -0,0 +1,66 @@
+package application
+
+import (
+	"fmt"
+	"games-football-api/src/zonas/domain/entities"
+	"games-football-api/src/zonas/domain/repositories"
+	"strings"
+)
+
+type EditarZonaUseCase struct {
+	zonaRepo repositories.IZonaRepository
+}
+
+func NewEditarZonaUseCase(zonaRepo repositories.IZonaRepository) *EditarZonaUseCase {
+	return &EditarZonaUseCase{
+		zonaRepo: zonaRepo,
+	}
+}
+
+// Execute cambia el nombre, el centro o el radio de la zona (los nil se conservan); el id no cambia
+// porque lo usan las retas y los clientes conectados
+func (uc *EditarZonaUseCase) Execute(actorID, id string, nombre *string, latitud, longitud, radioKm *float64) (*entities.Zona, error) {
+	if nombre == nil && latitud == nil && longitud == nil && radioKm == nil {
+		return nil, entities.ErrZonaSinCambios
+	}
+
+	zona, err := uc.zonaRepo.ObtenerPorID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	cambios := []string{}
+	if nombre != nil {
+		anterior := zona.Nombre
+		if err := zona.Renombrar(*nombre); err != nil {
+			return nil, err
+		}
+		cambios = append(cambios, fmt.Sprintf("nombre: %s -> %s", anterior, zona.Nombre))
+	}
+	if latitud != nil || longitud != nil || radioKm != nil {
+		nuevaLatitud, nuevaLongitud, nuevoRadio := zona.Latitud, zona.Longitud, zona.RadioKm
+		if latitud != nil {
+			nuevaLatitud = *latitud
+		}
+		if longitud != nil {
+			nuevaLongitud = *longitud
+		}
+		if radioKm != nil {
+			nuevoRadio = *radioKm
+		}
+		anterior := fmt.Sprintf("%.6f,%.6f r=%.2fkm", zona.Latitud, zona.Longitud, zona.RadioKm)
+		if err := zona.Ubicar(nuevaLatitud, nuevaLongitud, nuevoRadio); err != nil {
+			return nil, err
+		}
+		cambios = append(cambios, fmt.Sprintf("ubicación: %s -> %.6f,%.6f r=%.2fkm", anterior, zona.Latitud, zona.Longitud, zona.RadioKm))
+	}
+
+	if err := uc.zonaRepo.Actualizar(zona); err != nil {
+		return nil, err
+	}
+	if err := uc.zonaRepo.RegistrarAuditoria(entities.EventoEditarZona, actorID, zona.ID, strings.Join(cambios, "; ")); err != nil {
+		return nil, err
+	}
+
+	return zona, nil
+}
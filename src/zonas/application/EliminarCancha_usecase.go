@@ -0,0 +1,31 @@
+package application
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/zonas/domain/entities"
+	"games-football-api/src/zonas/domain/repositories"
+	"strings"
+)
+
+type EliminarCanchaUseCase struct {
+	zonaRepo repositories.IZonaRepository
+}
+
+func NewEliminarCanchaUseCase(zonaRepo repositories.IZonaRepository) *EliminarCanchaUseCase {
+	return &EliminarCanchaUseCase{
+		zonaRepo: zonaRepo,
+	}
+}
+
+// Execute elimina una cancha sin retas pendientes y registra en la auditoría quién la eliminó y por qué
+func (uc *EliminarCanchaUseCase) Execute(actorID, id, motivo string) error {
+	motivo = strings.TrimSpace(motivo)
+	if err := core.ValidarMotivo(motivo); err != nil {
+		return err
+	}
+
+	if err := uc.zonaRepo.EliminarCancha(id); err != nil {
+		return err
+	}
+	return uc.zonaRepo.RegistrarAuditoria(entities.EventoEliminarCancha, actorID, id, motivo)
+}
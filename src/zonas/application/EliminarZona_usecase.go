@@ -0,0 +1,31 @@
+package application
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/zonas/domain/entities"
+	"games-football-api/src/zonas/domain/repositories"
+	"strings"
+)
+
+type EliminarZonaUseCase struct {
+	zonaRepo repositories.IZonaRepository
+}
+
+func NewEliminarZonaUseCase(zonaRepo repositories.IZonaRepository) *EliminarZonaUseCase {
+	return &EliminarZonaUseCase{
+		zonaRepo: zonaRepo,
+	}
+}
+
+// Execute elimina una zona sin retas y registra en la auditoría quién la eliminó y por qué
+func (uc *EliminarZonaUseCase) Execute(actorID, id, motivo string) error {
+	motivo = strings.TrimSpace(motivo)
+	if err := core.ValidarMotivo(motivo); err != nil {
+		return err
+	}
+
+	if err := uc.zonaRepo.Eliminar(id); err != nil {
+		return err
+	}
+	return uc.zonaRepo.RegistrarAuditoria(entities.EventoEliminarZona, actorID, id, motivo)
+}
@@ -0,0 +1,24 @@
+package application
+
+import (
+	"games-football-api/src/zonas/domain/entities"
+	"games-football-api/src/zonas/domain/repositories"
+)
+
+type ListarCanchasUseCase struct {
+	zonaRepo repositories.IZonaRepository
+}
+
+func NewListarCanchasUseCase(zonaRepo repositories.IZonaRepository) *ListarCanchasUseCase {
+	return &ListarCanchasUseCase{
+		zonaRepo: zonaRepo,
+	}
+}
+
+// Execute retorna las canchas de la zona; ErrZonaNoEncontrada si la zona no existe
+func (uc *ListarCanchasUseCase) Execute(zonaID string) ([]entities.Cancha, error) {
+	if _, err := uc.zonaRepo.ObtenerPorID(zonaID); err != nil {
+		return nil, err
+	}
+	return uc.zonaRepo.ListarCanchas(zonaID)
+}
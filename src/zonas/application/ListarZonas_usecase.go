@@ -0,0 +1,21 @@
+package application
+
+import (
+	"games-football-api/src/zonas/domain/entities"
+	"games-football-api/src/zonas/domain/repositories"
+)
+
+type ListarZonasUseCase struct {
+	zonaRepo repositories.IZonaRepository
+}
+
+func NewListarZonasUseCase(zonaRepo repositories.IZonaRepository) *ListarZonasUseCase {
+	return &ListarZonasUseCase{
+		zonaRepo: zonaRepo,
+	}
+}
+
+// Execute retorna todas las zonas ordenadas por nombre
+func (uc *ListarZonasUseCase) Execute() ([]entities.Zona, error) {
+	return uc.zonaRepo.Listar()
+}
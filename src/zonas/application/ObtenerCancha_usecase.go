@@ -0,0 +1,21 @@
+package application
+
+import (
+	"games-football-api/src/zonas/domain/entities"
+	"games-football-api/src/zonas/domain/repositories"
+)
+
+type ObtenerCanchaUseCase struct {
+	zonaRepo repositories.IZonaRepository
+}
+
+func NewObtenerCanchaUseCase(zonaRepo repositories.IZonaRepository) *ObtenerCanchaUseCase {
+	return &ObtenerCanchaUseCase{
+		zonaRepo: zonaRepo,
+	}
+}
+
+// Execute retorna una cancha por su id
+func (uc *ObtenerCanchaUseCase) Execute(id string) (*entities.Cancha, error) {
+	return uc.zonaRepo.ObtenerCancha(id)
+}
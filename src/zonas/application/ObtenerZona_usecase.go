@@ -0,0 +1,21 @@
+package application
+
+import (
+	"games-football-api/src/zonas/domain/entities"
+	"games-football-api/src/zonas/domain/repositories"
+)
+
+type ObtenerZonaUseCase struct {
+	zonaRepo repositories.IZonaRepository
+}
+
+func NewObtenerZonaUseCase(zonaRepo repositories.IZonaRepository) *ObtenerZonaUseCase {
+	return &ObtenerZonaUseCase{
+		zonaRepo: zonaRepo,
+	}
+}
+
+// Execute retorna una zona por su id
+func (uc *ObtenerZonaUseCase) Execute(id string) (*entities.Zona, error) {
+	return uc.zonaRepo.ObtenerPorID(id)
+}
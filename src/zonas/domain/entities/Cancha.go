@@ -0,0 +1,139 @@
+package entities
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Errores de dominio de las canchas
+var (
+	ErrCanchaNombreVacio    = errors.New("nombre no puede estar vacío")
+	ErrCanchaDireccionVacia = errors.New("direccion no puede estar vacía")
+	ErrCanchaTextoLargo     = errors.New("nombre y direccion no pueden superar los 255 caracteres")
+	ErrSuperficieInvalida   = errors.New("superficie inválida: usa pasto_natural, pasto_sintetico, tierra o concreto")
+	ErrCapacidadInvalida    = errors.New("capacidad debe estar entre 2 y 44 jugadores")
+	ErrHorarioInvalido      = errors.New("horario inválido: usa HH:MM y una hora_cierre posterior a la hora_apertura")
+	ErrCanchaUbicacion      = errors.New("envía latitud y longitud juntas, dentro del radio de la zona")
+	ErrCanchaConRetas       = errors.New("la cancha tiene retas pendientes; cancélalas antes de eliminarla")
+)
+
+// Eventos de auditoría de la administración de canchas
+const (
+	EventoCrearCancha    = "crear_cancha"
+	EventoEditarCancha   = "editar_cancha"
+	EventoEliminarCancha = "eliminar_cancha"
+)
+
+// Superficies de juego de una cancha
+const (
+	SuperficiePastoNatural   = "pasto_natural"
+	SuperficiePastoSintetico = "pasto_sintetico"
+	SuperficieTierra         = "tierra"
+	SuperficieConcreto       = "concreto"
+)
+
+// Límites de capacidad de una cancha (jugadores en el campo)
+const (
+	CapacidadMinima = 2
+	CapacidadMaxima = 44
+)
+
+const longitudMaximaTextoCancha = 255
+
+// Cancha es un campo de juego de una zona que las retas pueden apartar por horario; los datos que
+// usa retas para apartarla están en core.Cancha
+type Cancha struct {
+	core.Cancha
+	Direccion  string    `json:"direccion"`
+	Superficie string    `json:"superficie"`
+	CreadoEn   time.Time `json:"creado_en"`
+}
+
+// DatosCancha son los campos que un admin envía al crear o editar una cancha
+type DatosCancha struct {
+	Nombre       string
+	Direccion    string
+	Superficie   string
+	Capacidad    int
+	Latitud      *float64
+	Longitud     *float64
+	HoraApertura string
+	HoraCierre   string
+}
+
+// NewCancha valida los datos de una cancha nueva de la zona
+func NewCancha(zona *Zona, datos DatosCancha) (*Cancha, error) {
+	cancha := &Cancha{Cancha: core.Cancha{ZonaID: zona.ID}}
+	if err := cancha.Actualizar(zona, datos); err != nil {
+		return nil, err
+	}
+	return cancha, nil
+}
+
+// Actualizar valida y asigna todos los datos de la cancha; la ubicación, si se envía, debe estar
+// dentro del radio de su zona
+func (c *Cancha) Actualizar(zona *Zona, datos DatosCancha) error {
+	nombre := strings.TrimSpace(datos.Nombre)
+	direccion := strings.TrimSpace(datos.Direccion)
+	if nombre == "" {
+		return ErrCanchaNombreVacio
+	}
+	if direccion == "" {
+		return ErrCanchaDireccionVacia
+	}
+	if utf8.RuneCountInString(nombre) > longitudMaximaTextoCancha || utf8.RuneCountInString(direccion) > longitudMaximaTextoCancha {
+		return ErrCanchaTextoLargo
+	}
+
+	switch datos.Superficie {
+	case SuperficiePastoNatural, SuperficiePastoSintetico, SuperficieTierra, SuperficieConcreto:
+	default:
+		return ErrSuperficieInvalida
+	}
+	if datos.Capacidad < CapacidadMinima || datos.Capacidad > CapacidadMaxima {
+		return ErrCapacidadInvalida
+	}
+
+	apertura, errApertura := time.Parse("15:04", datos.HoraApertura)
+	cierre, errCierre := time.Parse("15:04", datos.HoraCierre)
+	if errApertura != nil || errCierre != nil || !cierre.After(apertura) {
+		return ErrHorarioInvalido
+	}
+
+	if (datos.Latitud == nil) != (datos.Longitud == nil) {
+		return ErrCanchaUbicacion
+	}
+	if datos.Latitud != nil && !zona.Contiene(*datos.Latitud, *datos.Longitud) {
+		return ErrCanchaUbicacion
+	}
+
+	c.Nombre = nombre
+	c.Direccion = direccion
+	c.Superficie = datos.Superficie
+	c.Capacidad = datos.Capacidad
+	c.Latitud = datos.Latitud
+	c.Longitud = datos.Longitud
+	c.HoraApertura = apertura.Format("15:04")
+	c.HoraCierre = cierre.Format("15:04")
+	return nil
+}
+
+// Contiene indica si el punto está dentro del radio de la zona (distancia del haversine al centro)
+func (z *Zona) Contiene(latitud, longitud float64) bool {
+	if latitud < -90 || latitud > 90 || longitud < -180 || longitud > 180 {
+		return false
+	}
+
+	const radioTierraKm = 6371.0
+	lat1 := z.Latitud * math.Pi / 180
+	lat2 := latitud * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (longitud - z.Longitud) * math.Pi / 180
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2*radioTierraKm*math.Asin(math.Min(1, math.Sqrt(h))) <= z.RadioKm
+}
@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Errores de dominio de las zonas
+var (
+	ErrZonaNoEncontrada = errors.New("zona no encontrada")
+	ErrZonaIDInvalido   = errors.New("id inválido: usa de 2 a 50 letras minúsculas, números o guiones bajos (ej. suchiapa_centro)")
+	ErrZonaNombreVacio  = errors.New("nombre no puede estar vacío")
+	ErrZonaNombreLargo  = errors.New("nombre no puede superar los 150 caracteres")
+	ErrZonaExistente    = errors.New("ya existe una zona con ese id")
+	ErrZonaConRetas     = errors.New("la zona tiene retas; no se puede eliminar")
+	ErrZonaCoordenadas  = errors.New("coordenadas inválidas: latitud entre -90 y 90, longitud entre -180 y 180")
+	ErrZonaRadio        = errors.New("radio_km debe estar entre 0.1 y 50")
+	ErrZonaSinCambios   = errors.New("envía al menos uno de: nombre, latitud, longitud, radio_km")
+)
+
+// Eventos de auditoría de la administración de zonas
+const (
+	EventoCrearZona    = "crear_zona"
+	EventoEditarZona   = "editar_zona"
+	EventoEliminarZona = "eliminar_zona"
+)
+
+const longitudMaximaNombre = 150
+
+// Límites del radio de una zona en kilómetros
+const (
+	RadioMinimoKm = 0.1
+	RadioMaximoKm = 50
+)
+
+var formatoZonaID = regexp.MustCompile(`^[a-z0-9_]{2,50}$`)
+
+// Zona es un área geográfica; las retas y los canales del WebSocket se agrupan por zona. El centro y
+// el radio delimitan dónde pueden estar sus canchas y ubican a las retas que no indican cancha
+type Zona struct {
+	ID       string    `json:"id"`
+	Nombre   string    `json:"nombre"`
+	Latitud  float64   `json:"latitud"`
+	Longitud float64   `json:"longitud"`
+	RadioKm  float64   `json:"radio_km"`
+	CreadoEn time.Time `json:"creado_en"`
+}
+
+// NewZona valida el id, el nombre y la ubicación de una zona nueva
+func NewZona(id, nombre string, latitud, longitud, radioKm float64) (*Zona, error) {
+	id = strings.TrimSpace(id)
+	if !formatoZonaID.MatchString(id) {
+		return nil, ErrZonaIDInvalido
+	}
+
+	zona := &Zona{ID: id}
+	if err := zona.Renombrar(nombre); err != nil {
+		return nil, err
+	}
+	if err := zona.Ubicar(latitud, longitud, radioKm); err != nil {
+		return nil, err
+	}
+	return zona, nil
+}
+
+// Ubicar valida y asigna el centro y el radio de la zona
+func (z *Zona) Ubicar(latitud, longitud, radioKm float64) error {
+	if latitud < -90 || latitud > 90 || longitud < -180 || longitud > 180 {
+		return ErrZonaCoordenadas
+	}
+	if radioKm < RadioMinimoKm || radioKm > RadioMaximoKm {
+		return ErrZonaRadio
+	}
+	z.Latitud = latitud
+	z.Longitud = longitud
+	z.RadioKm = radioKm
+	return nil
+}
+
+// Renombrar valida y asigna el nombre visible de la zona
+func (z *Zona) Renombrar(nombre string) error {
+	nombre = strings.TrimSpace(nombre)
+	if nombre == "" {
+		return ErrZonaNombreVacio
+	}
+	if utf8.RuneCountInString(nombre) > longitudMaximaNombre {
+		return ErrZonaNombreLargo
+	}
+	z.Nombre = nombre
+	return nil
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"games-football-api/src/zonas/domain/entities"
+)
+
+// IZonaRepository define la interfaz para persistir las zonas
+type IZonaRepository interface {
+	// Listar retorna todas las zonas ordenadas por nombre
+	Listar() ([]entities.Zona, error)
+
+	// ObtenerPorID busca una zona por su id
+	ObtenerPorID(id string) (*entities.Zona, error)
+
+	// Crear guarda una zona nueva; falla con ErrZonaExistente si el id ya está en uso
+	Crear(zona *entities.Zona) error
+
+	// Actualizar guarda el nombre, el centro y el radio de la zona
+	Actualizar(zona *entities.Zona) error
+
+	// Eliminar borra la zona; falla con ErrZonaConRetas si alguna reta la usa
+	Eliminar(id string) error
+
+	// ListarCanchas retorna las canchas de una zona ordenadas por nombre
+	ListarCanchas(zonaID string) ([]entities.Cancha, error)
+
+	// ObtenerCancha busca una cancha por su id; ErrCanchaNoEncontrada si no existe
+	ObtenerCancha(id string) (*entities.Cancha, error)
+
+	// CrearCancha guarda una cancha nueva y le asigna su id
+	CrearCancha(cancha *entities.Cancha) error
+
+	// ActualizarCancha guarda los datos de la cancha y mueve a su nueva ubicación las retas pendientes que la apartan
+	ActualizarCancha(cancha *entities.Cancha) error
+
+	// EliminarCancha borra la cancha; falla con ErrCanchaConRetas si alguna reta pendiente la aparta
+	EliminarCancha(id string) error
+
+	// RegistrarAuditoria guarda quién hizo una acción administrativa sobre una zona y por qué
+	RegistrarAuditoria(evento, actorID, objetivo, detalle string) error
+}
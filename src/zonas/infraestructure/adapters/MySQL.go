@@ -0,0 +1,133 @@
+package adapters
+
+import (
+	"database/sql"
+	"fmt"
+	"games-football-api/src/zonas/domain/entities"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MySQLZonaRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLZonaRepository(db *sql.DB) *MySQLZonaRepository {
+	return &MySQLZonaRepository{
+		db: db,
+	}
+}
+
+// Listar retorna todas las zonas ordenadas por nombre
+func (repo *MySQLZonaRepository) Listar() ([]entities.Zona, error) {
+	rows, err := repo.db.Query("SELECT id, nombre, latitud, longitud, radio_km, created_at FROM zonas ORDER BY nombre")
+	if err != nil {
+		return nil, fmt.Errorf("error al listar zonas: %w", err)
+	}
+	defer rows.Close()
+
+	zonas := []entities.Zona{}
+	for rows.Next() {
+		var zona entities.Zona
+		if err := rows.Scan(&zona.ID, &zona.Nombre, &zona.Latitud, &zona.Longitud, &zona.RadioKm, &zona.CreadoEn); err != nil {
+			return nil, fmt.Errorf("error al leer zona: %w", err)
+		}
+		zonas = append(zonas, zona)
+	}
+	return zonas, rows.Err()
+}
+
+// ObtenerPorID busca una zona por su id
+func (repo *MySQLZonaRepository) ObtenerPorID(id string) (*entities.Zona, error) {
+	var zona entities.Zona
+	query := "SELECT id, nombre, latitud, longitud, radio_km, created_at FROM zonas WHERE id = ?"
+	err := repo.db.QueryRow(query, id).Scan(&zona.ID, &zona.Nombre, &zona.Latitud, &zona.Longitud, &zona.RadioKm, &zona.CreadoEn)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrZonaNoEncontrada
+		}
+		return nil, fmt.Errorf("error al consultar zona: %w", err)
+	}
+	return &zona, nil
+}
+
+// Crear inserta una zona nueva
+func (repo *MySQLZonaRepository) Crear(zona *entities.Zona) error {
+	zona.CreadoEn = time.Now()
+
+	query := "INSERT INTO zonas (id, nombre, latitud, longitud, radio_km, created_at) VALUES (?, ?, ?, ?, ?, ?)"
+	_, err := repo.db.Exec(query, zona.ID, zona.Nombre, zona.Latitud, zona.Longitud, zona.RadioKm, zona.CreadoEn)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate") {
+			return entities.ErrZonaExistente
+		}
+		return fmt.Errorf("error al crear zona: %w", err)
+	}
+	return nil
+}
+
+// Actualizar guarda el nombre, el centro y el radio de la zona
+func (repo *MySQLZonaRepository) Actualizar(zona *entities.Zona) error {
+	query := "UPDATE zonas SET nombre = ?, latitud = ?, longitud = ?, radio_km = ? WHERE id = ?"
+	result, err := repo.db.Exec(query, zona.Nombre, zona.Latitud, zona.Longitud, zona.RadioKm, zona.ID)
+	if err != nil {
+		return fmt.Errorf("error al actualizar zona: %w", err)
+	}
+
+	if filas, _ := result.RowsAffected(); filas == 0 {
+		// Sin cambios reales MySQL reporta 0 filas; se distingue de una zona inexistente
+		if _, err := repo.ObtenerPorID(zona.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Eliminar borra la zona si ninguna reta la usa. La llave foránea de retas es ON DELETE CASCADE,
+// así que la revisión y el DELETE van en la misma transacción para no borrar retas por accidente
+func (repo *MySQLZonaRepository) Eliminar(id string) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	var existe string
+	if err := tx.QueryRow("SELECT id FROM zonas WHERE id = ? FOR UPDATE", id).Scan(&existe); err != nil {
+		if err == sql.ErrNoRows {
+			return entities.ErrZonaNoEncontrada
+		}
+		return fmt.Errorf("error al consultar zona: %w", err)
+	}
+
+	var retas int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM retas WHERE zona_id = ?", id).Scan(&retas); err != nil {
+		return fmt.Errorf("error al contar retas de la zona: %w", err)
+	}
+	if retas > 0 {
+		return entities.ErrZonaConRetas
+	}
+
+	// El FOR UPDATE hace esperar a los INSERT de retas en esta zona, que fallan por la llave foránea
+	// una vez que la zona se borra
+	if _, err := tx.Exec("DELETE FROM zonas WHERE id = ?", id); err != nil {
+		return fmt.Errorf("error al eliminar zona: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar eliminación: %w", err)
+	}
+	return nil
+}
+
+// RegistrarAuditoria guarda un evento de administración en la tabla auditoria
+func (repo *MySQLZonaRepository) RegistrarAuditoria(evento, actorID, objetivo, detalle string) error {
+	query := "INSERT INTO auditoria (id, evento, actor_id, objetivo, detalle, created_at) VALUES (?, ?, ?, ?, ?, ?)"
+	_, err := repo.db.Exec(query, uuid.New().String(), evento, actorID, objetivo, detalle, time.Now())
+	if err != nil {
+		return fmt.Errorf("error al registrar evento de auditoría: %w", err)
+	}
+	return nil
+}
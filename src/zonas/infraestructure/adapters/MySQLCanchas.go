@@ -0,0 +1,152 @@
+package adapters
+
+import (
+	"database/sql"
+	"fmt"
+	"games-football-api/src/core"
+	"games-football-api/src/zonas/domain/entities"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// columnasCancha son las columnas que se leen de una cancha, en el orden de escanearCancha
+const columnasCancha = `id, zona_id, nombre, direccion, superficie, capacidad, latitud, longitud,
+	TIME_FORMAT(hora_apertura, '%H:%i'), TIME_FORMAT(hora_cierre, '%H:%i'), created_at`
+
+func escanearCancha(row interface{ Scan(...interface{}) error }) (*entities.Cancha, error) {
+	var cancha entities.Cancha
+	var latitud, longitud sql.NullFloat64
+	err := row.Scan(&cancha.ID, &cancha.ZonaID, &cancha.Nombre, &cancha.Direccion, &cancha.Superficie, &cancha.Capacidad,
+		&latitud, &longitud, &cancha.HoraApertura, &cancha.HoraCierre, &cancha.CreadoEn)
+	if err != nil {
+		return nil, err
+	}
+	if latitud.Valid && longitud.Valid {
+		cancha.Latitud = &latitud.Float64
+		cancha.Longitud = &longitud.Float64
+	}
+	return &cancha, nil
+}
+
+// ListarCanchas retorna las canchas de una zona ordenadas por nombre
+func (repo *MySQLZonaRepository) ListarCanchas(zonaID string) ([]entities.Cancha, error) {
+	rows, err := repo.db.Query("SELECT "+columnasCancha+" FROM canchas WHERE zona_id = ? ORDER BY nombre", zonaID)
+	if err != nil {
+		return nil, fmt.Errorf("error al listar canchas: %w", err)
+	}
+	defer rows.Close()
+
+	canchas := []entities.Cancha{}
+	for rows.Next() {
+		cancha, err := escanearCancha(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer cancha: %w", err)
+		}
+		canchas = append(canchas, *cancha)
+	}
+	return canchas, rows.Err()
+}
+
+// ObtenerCancha busca una cancha por su id
+func (repo *MySQLZonaRepository) ObtenerCancha(id string) (*entities.Cancha, error) {
+	cancha, err := escanearCancha(repo.db.QueryRow("SELECT "+columnasCancha+" FROM canchas WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, core.ErrCanchaNoEncontrada
+		}
+		return nil, fmt.Errorf("error al consultar cancha: %w", err)
+	}
+	return cancha, nil
+}
+
+// CrearCancha inserta una cancha nueva
+func (repo *MySQLZonaRepository) CrearCancha(cancha *entities.Cancha) error {
+	cancha.ID = uuid.New().String()
+	cancha.CreadoEn = time.Now()
+
+	query := `
+		INSERT INTO canchas (id, zona_id, nombre, direccion, superficie, capacidad, latitud, longitud, hora_apertura, hora_cierre, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := repo.db.Exec(query, cancha.ID, cancha.ZonaID, cancha.Nombre, cancha.Direccion, cancha.Superficie, cancha.Capacidad,
+		cancha.Latitud, cancha.Longitud, cancha.HoraApertura, cancha.HoraCierre, cancha.CreadoEn)
+	if err != nil {
+		return fmt.Errorf("error al crear cancha: %w", err)
+	}
+	return nil
+}
+
+// ActualizarCancha guarda la cancha y, en la misma transacción, copia su ubicación a las retas abiertas
+// o llenas que la apartan (la búsqueda de retas cercanas usa la ubicación guardada en cada reta)
+func (repo *MySQLZonaRepository) ActualizarCancha(cancha *entities.Cancha) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE canchas
+		SET nombre = ?, direccion = ?, superficie = ?, capacidad = ?, latitud = ?, longitud = ?, hora_apertura = ?, hora_cierre = ?
+		WHERE id = ?
+	`
+	result, err := tx.Exec(query, cancha.Nombre, cancha.Direccion, cancha.Superficie, cancha.Capacidad,
+		cancha.Latitud, cancha.Longitud, cancha.HoraApertura, cancha.HoraCierre, cancha.ID)
+	if err != nil {
+		return fmt.Errorf("error al actualizar cancha: %w", err)
+	}
+	if filas, _ := result.RowsAffected(); filas == 0 {
+		// Sin cambios reales MySQL reporta 0 filas; se distingue de una cancha inexistente
+		if _, err := repo.ObtenerCancha(cancha.ID); err != nil {
+			return err
+		}
+	}
+
+	retasQuery := "UPDATE retas SET latitud = ?, longitud = ? WHERE cancha_id = ? AND estado IN ('abierta', 'llena')"
+	if _, err := tx.Exec(retasQuery, cancha.Latitud, cancha.Longitud, cancha.ID); err != nil {
+		return fmt.Errorf("error al actualizar la ubicación de las retas: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar actualización: %w", err)
+	}
+	return nil
+}
+
+// EliminarCancha borra la cancha si ninguna reta abierta, llena o en juego la aparta; las retas
+// finalizadas o canceladas se quedan sin cancha (la llave foránea es ON DELETE SET NULL)
+func (repo *MySQLZonaRepository) EliminarCancha(id string) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	// El FOR UPDATE hace esperar a las retas que intentan apartar la cancha en este momento
+	var existe string
+	if err := tx.QueryRow("SELECT id FROM canchas WHERE id = ? FOR UPDATE", id).Scan(&existe); err != nil {
+		if err == sql.ErrNoRows {
+			return core.ErrCanchaNoEncontrada
+		}
+		return fmt.Errorf("error al consultar cancha: %w", err)
+	}
+
+	var pendientes int
+	query := "SELECT COUNT(*) FROM retas WHERE cancha_id = ? AND estado IN ('abierta', 'llena', 'en_juego')"
+	if err := tx.QueryRow(query, id).Scan(&pendientes); err != nil {
+		return fmt.Errorf("error al contar retas de la cancha: %w", err)
+	}
+	if pendientes > 0 {
+		return entities.ErrCanchaConRetas
+	}
+
+	if _, err := tx.Exec("DELETE FROM canchas WHERE id = ?", id); err != nil {
+		return fmt.Errorf("error al eliminar cancha: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar eliminación: %w", err)
+	}
+	return nil
+}
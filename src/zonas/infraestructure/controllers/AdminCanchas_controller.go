@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/zonas/application"
+	"games-football-api/src/zonas/domain/entities"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminCanchasController struct {
+	crearCanchaUseCase    *application.CrearCanchaUseCase
+	editarCanchaUseCase   *application.EditarCanchaUseCase
+	eliminarCanchaUseCase *application.EliminarCanchaUseCase
+}
+
+func NewAdminCanchasController(crearCanchaUseCase *application.CrearCanchaUseCase, editarCanchaUseCase *application.EditarCanchaUseCase, eliminarCanchaUseCase *application.EliminarCanchaUseCase) *AdminCanchasController {
+	return &AdminCanchasController{
+		crearCanchaUseCase:    crearCanchaUseCase,
+		editarCanchaUseCase:   editarCanchaUseCase,
+		eliminarCanchaUseCase: eliminarCanchaUseCase,
+	}
+}
+
+// CanchaRequest representa el cuerpo de la petición para crear o editar una cancha
+type CanchaRequest struct {
+	Nombre       string   `json:"nombre" binding:"required"`
+	Direccion    string   `json:"direccion" binding:"required"`
+	Superficie   string   `json:"superficie" binding:"required"`
+	Capacidad    int      `json:"capacidad" binding:"required"`
+	Latitud      *float64 `json:"latitud"`
+	Longitud     *float64 `json:"longitud"`
+	HoraApertura string   `json:"hora_apertura" binding:"required"`
+	HoraCierre   string   `json:"hora_cierre" binding:"required"`
+}
+
+// datos convierte la petición en los datos de dominio de la cancha
+func (req CanchaRequest) datos() entities.DatosCancha {
+	return entities.DatosCancha{
+		Nombre:       req.Nombre,
+		Direccion:    req.Direccion,
+		Superficie:   req.Superficie,
+		Capacidad:    req.Capacidad,
+		Latitud:      req.Latitud,
+		Longitud:     req.Longitud,
+		HoraApertura: req.HoraApertura,
+		HoraCierre:   req.HoraCierre,
+	}
+}
+
+const mensajeCamposCancha = "Campos requeridos: nombre, direccion, superficie, capacidad, hora_apertura, hora_cierre"
+
+// HandleCrear maneja la petición POST que crea una cancha en la zona :id
+func (ac *AdminCanchasController) HandleCrear(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req CanchaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": mensajeCamposCancha,
+		})
+		return
+	}
+
+	cancha, err := ac.crearCanchaUseCase.Execute(claims.UsuarioID, c.Param("id"), req.datos())
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status":  "success",
+		"mensaje": "Cancha creada",
+		"cancha":  cancha,
+	})
+}
+
+// HandleEditar maneja la petición PUT que reemplaza los datos de una cancha
+func (ac *AdminCanchasController) HandleEditar(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req CanchaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": mensajeCamposCancha,
+		})
+		return
+	}
+
+	cancha, err := ac.editarCanchaUseCase.Execute(claims.UsuarioID, c.Param("id"), req.datos())
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Cancha actualizada",
+		"cancha":  cancha,
+	})
+}
+
+// HandleEliminar maneja la petición DELETE que elimina una cancha sin retas pendientes
+func (ac *AdminCanchasController) HandleEliminar(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req EliminarZonaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: motivo",
+		})
+		return
+	}
+
+	if err := ac.eliminarCanchaUseCase.Execute(claims.UsuarioID, c.Param("id"), req.Motivo); err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Cancha eliminada",
+	})
+}
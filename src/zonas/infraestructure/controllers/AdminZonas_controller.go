@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/zonas/application"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminZonasController struct {
+	crearZonaUseCase    *application.CrearZonaUseCase
+	editarZonaUseCase   *application.EditarZonaUseCase
+	eliminarZonaUseCase *application.EliminarZonaUseCase
+}
+
+func NewAdminZonasController(crearZonaUseCase *application.CrearZonaUseCase, editarZonaUseCase *application.EditarZonaUseCase, eliminarZonaUseCase *application.EliminarZonaUseCase) *AdminZonasController {
+	return &AdminZonasController{
+		crearZonaUseCase:    crearZonaUseCase,
+		editarZonaUseCase:   editarZonaUseCase,
+		eliminarZonaUseCase: eliminarZonaUseCase,
+	}
+}
+
+// CrearZonaRequest representa el cuerpo de la petición para crear una zona
+type CrearZonaRequest struct {
+	ID       string   `json:"id" binding:"required"`
+	Nombre   string   `json:"nombre" binding:"required"`
+	Latitud  *float64 `json:"latitud" binding:"required"`
+	Longitud *float64 `json:"longitud" binding:"required"`
+	RadioKm  *float64 `json:"radio_km" binding:"required"`
+}
+
+// EditarZonaRequest representa el cuerpo de la petición para editar una zona; los campos omitidos no cambian
+type EditarZonaRequest struct {
+	Nombre   *string  `json:"nombre"`
+	Latitud  *float64 `json:"latitud"`
+	Longitud *float64 `json:"longitud"`
+	RadioKm  *float64 `json:"radio_km"`
+}
+
+// EliminarZonaRequest representa el cuerpo de la petición para eliminar una zona o una cancha
+type EliminarZonaRequest struct {
+	Motivo string `json:"motivo" binding:"required"`
+}
+
+// HandleCrear maneja la petición POST que crea una zona
+func (ac *AdminZonasController) HandleCrear(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req CrearZonaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: id, nombre, latitud, longitud, radio_km",
+		})
+		return
+	}
+
+	zona, err := ac.crearZonaUseCase.Execute(claims.UsuarioID, req.ID, req.Nombre, *req.Latitud, *req.Longitud, *req.RadioKm)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status":  "success",
+		"mensaje": "Zona creada",
+		"zona":    zona,
+	})
+}
+
+// HandleEditar maneja la petición PUT que cambia el nombre, el centro o el radio de una zona
+func (ac *AdminZonasController) HandleEditar(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req EditarZonaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Cuerpo inválido: nombre, latitud, longitud, radio_km",
+		})
+		return
+	}
+
+	zona, err := ac.editarZonaUseCase.Execute(claims.UsuarioID, c.Param("id"), req.Nombre, req.Latitud, req.Longitud, req.RadioKm)
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Zona actualizada",
+		"zona":    zona,
+	})
+}
+
+// HandleEliminar maneja la petición DELETE que elimina una zona sin retas
+func (ac *AdminZonasController) HandleEliminar(c *gin.Context) {
+	claims, _ := core.ClaimsDesdeContexto(c)
+
+	var req EliminarZonaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"mensaje": "Campos requeridos: motivo",
+		})
+		return
+	}
+
+	if err := ac.eliminarZonaUseCase.Execute(claims.UsuarioID, c.Param("id"), req.Motivo); err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"mensaje": "Zona eliminada",
+	})
+}
@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"games-football-api/src/zonas/application"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CanchasController struct {
+	listarCanchasUseCase *application.ListarCanchasUseCase
+	obtenerCanchaUseCase *application.ObtenerCanchaUseCase
+}
+
+func NewCanchasController(listarCanchasUseCase *application.ListarCanchasUseCase, obtenerCanchaUseCase *application.ObtenerCanchaUseCase) *CanchasController {
+	return &CanchasController{
+		listarCanchasUseCase: listarCanchasUseCase,
+		obtenerCanchaUseCase: obtenerCanchaUseCase,
+	}
+}
+
+// HandleListar maneja la petición GET que lista las canchas de una zona
+func (cc *CanchasController) HandleListar(c *gin.Context) {
+	canchas, err := cc.listarCanchasUseCase.Execute(c.Param("id"))
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"zona_id": c.Param("id"),
+		"canchas": canchas,
+	})
+}
+
+// HandleObtener maneja la petición GET que retorna una cancha
+func (cc *CanchasController) HandleObtener(c *gin.Context) {
+	cancha, err := cc.obtenerCanchaUseCase.Execute(c.Param("id"))
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"cancha": cancha,
+	})
+}
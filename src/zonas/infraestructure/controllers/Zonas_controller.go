@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"games-football-api/src/zonas/application"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ZonasController struct {
+	listarZonasUseCase *application.ListarZonasUseCase
+	obtenerZonaUseCase *application.ObtenerZonaUseCase
+}
+
+func NewZonasController(listarZonasUseCase *application.ListarZonasUseCase, obtenerZonaUseCase *application.ObtenerZonaUseCase) *ZonasController {
+	return &ZonasController{
+		listarZonasUseCase: listarZonasUseCase,
+		obtenerZonaUseCase: obtenerZonaUseCase,
+	}
+}
+
+// HandleListar maneja la petición GET que lista todas las zonas
+func (zc *ZonasController) HandleListar(c *gin.Context) {
+	zonas, err := zc.listarZonasUseCase.Execute()
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"zonas":  zonas,
+	})
+}
+
+// HandleObtener maneja la petición GET que retorna una zona
+func (zc *ZonasController) HandleObtener(c *gin.Context) {
+	zona, err := zc.obtenerZonaUseCase.Execute(c.Param("id"))
+	if err != nil {
+		c.JSON(statusPorError(err), gin.H{
+			"status":  "error",
+			"mensaje": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"zona":   zona,
+	})
+}
@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"errors"
+	"games-football-api/src/core"
+	"games-football-api/src/zonas/domain/entities"
+	"net/http"
+)
+
+// statusPorError traduce los errores de dominio de las zonas y canchas a códigos HTTP
+func statusPorError(err error) int {
+	switch {
+	case errors.Is(err, entities.ErrZonaNoEncontrada), errors.Is(err, core.ErrCanchaNoEncontrada):
+		return http.StatusNotFound
+	case errors.Is(err, entities.ErrZonaExistente), errors.Is(err, entities.ErrZonaConRetas), errors.Is(err, entities.ErrCanchaConRetas):
+		return http.StatusConflict
+	case errors.Is(err, entities.ErrZonaIDInvalido), errors.Is(err, entities.ErrZonaNombreVacio), errors.Is(err, entities.ErrZonaNombreLargo),
+		errors.Is(err, entities.ErrZonaCoordenadas), errors.Is(err, entities.ErrZonaRadio),
+		errors.Is(err, entities.ErrZonaSinCambios), errors.Is(err, core.ErrMotivoRequerido), errors.Is(err, core.ErrMotivoLargo),
+		errors.Is(err, entities.ErrCanchaNombreVacio), errors.Is(err, entities.ErrCanchaDireccionVacia), errors.Is(err, entities.ErrCanchaTextoLargo),
+		errors.Is(err, entities.ErrSuperficieInvalida), errors.Is(err, entities.ErrCapacidadInvalida), errors.Is(err, entities.ErrHorarioInvalido),
+		errors.Is(err, entities.ErrCanchaUbicacion):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
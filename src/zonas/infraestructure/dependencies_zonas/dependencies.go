@@ -0,0 +1,52 @@
+package dependencieszonas
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/zonas/application"
+	"games-football-api/src/zonas/infraestructure/adapters"
+	"games-football-api/src/zonas/infraestructure/controllers"
+	"games-football-api/src/zonas/infraestructure/routers"
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitZonas(r *gin.Engine) {
+	// Inicializar la conexión a la base de datos
+	db, err := core.NewMySQL()
+	if err != nil {
+		log.Fatalf("Error al conectar a la base de datos: %v", err)
+	}
+
+	// Inicializar el validador de tokens para las rutas de administración
+	jwtManager, err := core.NewJWTManager()
+	if err != nil {
+		log.Fatalf("Error al configurar JWT: %v", err)
+	}
+
+	// Crear el repositorio
+	zonaRepo := adapters.NewMySQLZonaRepository(db)
+
+	// Crear los casos de uso
+	listarZonasUseCase := application.NewListarZonasUseCase(zonaRepo)
+	obtenerZonaUseCase := application.NewObtenerZonaUseCase(zonaRepo)
+	crearZonaUseCase := application.NewCrearZonaUseCase(zonaRepo)
+	editarZonaUseCase := application.NewEditarZonaUseCase(zonaRepo)
+	eliminarZonaUseCase := application.NewEliminarZonaUseCase(zonaRepo)
+	listarCanchasUseCase := application.NewListarCanchasUseCase(zonaRepo)
+	obtenerCanchaUseCase := application.NewObtenerCanchaUseCase(zonaRepo)
+	crearCanchaUseCase := application.NewCrearCanchaUseCase(zonaRepo)
+	editarCanchaUseCase := application.NewEditarCanchaUseCase(zonaRepo)
+	eliminarCanchaUseCase := application.NewEliminarCanchaUseCase(zonaRepo)
+
+	// Crear los controladores
+	zonasController := controllers.NewZonasController(listarZonasUseCase, obtenerZonaUseCase)
+	adminZonasController := controllers.NewAdminZonasController(crearZonaUseCase, editarZonaUseCase, eliminarZonaUseCase)
+	canchasController := controllers.NewCanchasController(listarCanchasUseCase, obtenerCanchaUseCase)
+	adminCanchasController := controllers.NewAdminCanchasController(crearCanchaUseCase, editarCanchaUseCase, eliminarCanchaUseCase)
+
+	// Registrar las rutas
+	routers.ZonasRouter(r, core.AuthMiddleware(jwtManager), core.NewCuentasMySQL(db), zonasController, adminZonasController, canchasController, adminCanchasController)
+
+	log.Println("Módulo de Zonas inicializado correctamente")
+}
@@ -0,0 +1,35 @@
+package routers
+
+import (
+	"games-football-api/src/core"
+	"games-football-api/src/zonas/infraestructure/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ZonasRouter(r *gin.Engine, authMiddleware gin.HandlerFunc, cuentas core.LectorCuentas, zonasController *controllers.ZonasController, adminZonasController *controllers.AdminZonasController, canchasController *controllers.CanchasController, adminCanchasController *controllers.AdminCanchasController) {
+	// Catálogo público para los selectores de zona y de cancha de los clientes
+	zonasGroup := r.Group("/api/zonas")
+	{
+		zonasGroup.GET("", zonasController.HandleListar)
+		zonasGroup.GET("/:id", zonasController.HandleObtener)
+		zonasGroup.GET("/:id/canchas", canchasController.HandleListar)
+	}
+	r.GET("/api/canchas/:id", canchasController.HandleObtener)
+
+	// Administración de zonas (solo admins)
+	adminGroup := r.Group("/api/admin/zonas", authMiddleware, core.RequiereRol(cuentas, core.RolAdmin))
+	{
+		adminGroup.POST("", adminZonasController.HandleCrear)
+		adminGroup.PUT("/:id", adminZonasController.HandleEditar)
+		adminGroup.DELETE("/:id", adminZonasController.HandleEliminar)
+		adminGroup.POST("/:id/canchas", adminCanchasController.HandleCrear)
+	}
+
+	// Administración de canchas (solo admins)
+	adminCanchasGroup := r.Group("/api/admin/canchas", authMiddleware, core.RequiereRol(cuentas, core.RolAdmin))
+	{
+		adminCanchasGroup.PUT("/:id", adminCanchasController.HandleEditar)
+		adminCanchasGroup.DELETE("/:id", adminCanchasController.HandleEliminar)
+	}
+}